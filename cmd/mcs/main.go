@@ -13,6 +13,6 @@ var Version = "dev"
 func main() {
 	if err := cli.Execute(Version); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"slices"
+	"strings"
+)
+
+// AlertType identifies the condition an AlertEvent reports.
+type AlertType string
+
+// Alert types derived from the vehicle's alert snapshots.
+const (
+	AlertDoorOpen   AlertType = "door_open"
+	AlertWindowOpen AlertType = "window_open"
+	AlertHazardsOn  AlertType = "hazards_on"
+)
+
+// AlertEvent is a condition reported in one of the vehicle's alert snapshots,
+// e.g. a door left open.
+type AlertEvent struct {
+	Type AlertType
+
+	// Target names what the alert is about, e.g. "driver door" or "trunk".
+	// Empty when the alert concerns the whole vehicle.
+	Target string
+
+	// OccurrenceDate is when the snapshot was taken, in YYYYMMDDHHmmss format.
+	OccurrenceDate string
+}
+
+// GetAlertEvents lists the conditions reported across all alert snapshots in
+// the response, newest first. The API only reports door, window, and hazard
+// state in its snapshots, so those are the only alerts available. Returns an
+// empty slice when no snapshot reports anything.
+func (r *VehicleStatusResponse) GetAlertEvents() []AlertEvent {
+	events := []AlertEvent{}
+	for _, info := range r.AlertInfos {
+		events = append(events, alertEventsFromInfo(info)...)
+	}
+
+	// Timestamps are fixed-width, so they sort lexically.
+	slices.SortStableFunc(events, func(a, b AlertEvent) int {
+		return strings.Compare(b.OccurrenceDate, a.OccurrenceDate)
+	})
+
+	return events
+}
+
+// alertEventsFromInfo lists the conditions reported in a single alert snapshot.
+func alertEventsFromInfo(info AlertInfo) []AlertEvent {
+	timestamp := alertTimestamp(info)
+	var events []AlertEvent
+	add := func(alertType AlertType, target string) {
+		events = append(events, AlertEvent{Type: alertType, Target: target, OccurrenceDate: timestamp})
+	}
+
+	doors := doorStatusFromInfo(info.Door)
+	for _, door := range []struct {
+		name   string
+		isOpen bool
+	}{
+		{"driver door", doors.DriverOpen},
+		{"passenger door", doors.PassengerOpen},
+		{"rear left door", doors.RearLeftOpen},
+		{"rear right door", doors.RearRightOpen},
+		{"trunk", doors.TrunkOpen},
+		{"hood", doors.HoodOpen},
+		{"fuel lid", doors.FuelLidOpen},
+	} {
+		if door.isOpen {
+			add(AlertDoorOpen, door.name)
+		}
+	}
+
+	windows := windowStatusFromInfo(info.Pw)
+	for i, name := range []string{"driver window", "passenger window", "rear left window", "rear right window"} {
+		if windows.positions()[i] > WindowClosed {
+			add(AlertWindowOpen, name)
+		}
+	}
+
+	if int(info.HazardLamp.HazardSw) == HazardLightsOn {
+		add(AlertHazardsOn, "")
+	}
+
+	return events
+}
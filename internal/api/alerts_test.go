@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getAlertEvents fetches a vehicle status built from alertInfos and returns its alert events.
+func getAlertEvents(t *testing.T, alertInfos []any) []AlertEvent {
+	t.Helper()
+	responseData := map[string]any{
+		"resultCode": "200S00",
+		"alertInfos": alertInfos,
+	}
+
+	server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+
+	result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+	require.NoError(t, err)
+
+	return result.GetAlertEvents()
+}
+
+func TestGetAlertEvents(t *testing.T) {
+	t.Parallel()
+	events := getAlertEvents(t, []any{
+		map[string]any{
+			"OccurrenceDate": "20231201120000",
+			"Door": map[string]any{
+				"DrStatDrv":    float64(0),
+				"DrStatTrnkLg": float64(1),
+			},
+			"HazardLamp": map[string]any{"HazardSw": float64(1)},
+		},
+		map[string]any{
+			// No OccurrenceDate: falls back to the position timestamp.
+			"PositionInfo": map[string]any{"AcquisitionDatetime": "20231201130000"},
+			"Door":         map[string]any{"DrStatDrv": float64(1)},
+			"Pw":           map[string]any{"PwPosRl": float64(50)},
+		},
+	})
+
+	assert.Equal(t, []AlertEvent{
+		{Type: AlertDoorOpen, Target: "driver door", OccurrenceDate: "20231201130000"},
+		{Type: AlertWindowOpen, Target: "rear left window", OccurrenceDate: "20231201130000"},
+		{Type: AlertDoorOpen, Target: "trunk", OccurrenceDate: "20231201120000"},
+		{Type: AlertHazardsOn, OccurrenceDate: "20231201120000"},
+	}, events)
+}
+
+func TestGetAlertEvents_None(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		alertInfos []any
+	}{
+		{name: "no alert infos", alertInfos: []any{}},
+		{
+			name: "all closed",
+			alertInfos: []any{
+				map[string]any{
+					"OccurrenceDate": "20231201120000",
+					"Door":           map[string]any{"DrStatDrv": float64(0), "LockLinkSwDrv": float64(1)},
+					"Pw":             map[string]any{"PwPosDrv": float64(0)},
+					"HazardLamp":     map[string]any{"HazardSw": float64(0)},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			events := getAlertEvents(t, tt.alertInfos)
+			assert.NotNil(t, events)
+			assert.Empty(t, events)
+		})
+	}
+}
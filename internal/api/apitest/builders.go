@@ -0,0 +1,211 @@
+// Package apitest provides builders for realistic Mazda API responses, so code that
+// consumes the api package can be unit-tested without a live connection.
+package apitest
+
+import "github.com/cv/mcs/internal/api"
+
+// VehicleStatusBuilder provides a fluent API for building mock VehicleStatusResponse objects.
+type VehicleStatusBuilder struct {
+	response *api.VehicleStatusResponse
+}
+
+// NewVehicleStatus creates a new builder with sensible defaults.
+func NewVehicleStatus() *VehicleStatusBuilder {
+	return &VehicleStatusBuilder{
+		response: &api.VehicleStatusResponse{
+			ResultCode: api.ResultCodeSuccess,
+			AlertInfos: []api.AlertInfo{
+				{
+					Door: api.DoorInfo{
+						DrStatDrv:       float64(api.DoorClosed),
+						DrStatPsngr:     float64(api.DoorClosed),
+						DrStatRl:        float64(api.DoorClosed),
+						DrStatRr:        float64(api.DoorClosed),
+						DrStatTrnkLg:    float64(api.DoorClosed),
+						DrStatHood:      float64(api.DoorClosed),
+						LockLinkSwDrv:   float64(api.DoorUnlocked),
+						LockLinkSwPsngr: float64(api.DoorUnlocked),
+						LockLinkSwRl:    float64(api.DoorUnlocked),
+						LockLinkSwRr:    float64(api.DoorUnlocked),
+					},
+				},
+			},
+			RemoteInfos: []api.RemoteInfo{{}},
+		},
+	}
+}
+
+// WithDoorStatus sets the door status for the mock response.
+func (b *VehicleStatusBuilder) WithDoorStatus(status api.DoorStatus) *VehicleStatusBuilder {
+	doorInfo := &b.response.AlertInfos[0].Door
+
+	doorInfo.DrStatDrv = boolToDoorState(status.DriverOpen)
+	doorInfo.DrStatPsngr = boolToDoorState(status.PassengerOpen)
+	doorInfo.DrStatRl = boolToDoorState(status.RearLeftOpen)
+	doorInfo.DrStatRr = boolToDoorState(status.RearRightOpen)
+	doorInfo.DrStatTrnkLg = boolToDoorState(status.TrunkOpen)
+	doorInfo.DrStatHood = boolToDoorState(status.HoodOpen)
+	doorInfo.FuelLidOpenStatus = boolToDoorState(status.FuelLidOpen)
+
+	doorInfo.LockLinkSwDrv = boolToLockState(status.DriverLocked)
+	doorInfo.LockLinkSwPsngr = boolToLockState(status.PassengerLocked)
+	doorInfo.LockLinkSwRl = boolToLockState(status.RearLeftLocked)
+	doorInfo.LockLinkSwRr = boolToLockState(status.RearRightLocked)
+
+	return b
+}
+
+// WithWindowPositions sets each window's open percentage (0 = closed).
+func (b *VehicleStatusBuilder) WithWindowPositions(driver, passenger, rearLeft, rearRight float64) *VehicleStatusBuilder {
+	pw := &b.response.AlertInfos[0].Pw
+
+	pw.PwPosDrv = driver
+	pw.PwPosPsngr = passenger
+	pw.PwPosRl = rearLeft
+	pw.PwPosRr = rearRight
+
+	return b
+}
+
+// Build returns the constructed VehicleStatusResponse.
+func (b *VehicleStatusBuilder) Build() *api.VehicleStatusResponse {
+	return b.response
+}
+
+// boolToDoorState converts a boolean door state to the API's numeric representation.
+func boolToDoorState(isOpen bool) float64 {
+	if isOpen {
+		return float64(api.DoorOpen)
+	}
+
+	return float64(api.DoorClosed)
+}
+
+// boolToLockState converts a boolean lock state to the API's numeric representation.
+func boolToLockState(isLocked bool) float64 {
+	if isLocked {
+		return float64(api.DoorLocked)
+	}
+
+	return float64(api.DoorUnlocked)
+}
+
+// EVVehicleStatusBuilder provides a fluent API for building mock EVVehicleStatusResponse objects.
+type EVVehicleStatusBuilder struct {
+	response *api.EVVehicleStatusResponse
+}
+
+// NewEVVehicleStatus creates a new builder with sensible defaults.
+func NewEVVehicleStatus() *EVVehicleStatusBuilder {
+	return &EVVehicleStatusBuilder{
+		response: &api.EVVehicleStatusResponse{
+			ResultCode: api.ResultCodeSuccess,
+			ResultData: []api.EVResultData{
+				{
+					OccurrenceDate: "2025-01-15 12:00:00",
+					PlusBInformation: api.PlusBInformation{
+						VehicleInfo: api.EVVehicleInfo{
+							ChargeInfo: api.ChargeInfo{
+								SmaphSOC:          80.0,
+								SmaphRemDrvDistKm: 200.0,
+							},
+							RemoteHvacInfo: &api.RemoteHvacInfo{
+								HVAC:           float64(api.HVACStatusOff),
+								FrontDefroster: defrosterValue(false),
+								RearDefogger:   defrosterValue(false),
+								InCarTeDC:      20.0,
+								TargetTemp:     22.0,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// WithHVAC sets the HVAC on/off state.
+func (b *EVVehicleStatusBuilder) WithHVAC(on bool) *EVVehicleStatusBuilder {
+	if on {
+		b.response.ResultData[0].PlusBInformation.VehicleInfo.RemoteHvacInfo.HVAC = float64(api.HVACStatusOn)
+	} else {
+		b.response.ResultData[0].PlusBInformation.VehicleInfo.RemoteHvacInfo.HVAC = float64(api.HVACStatusOff)
+	}
+
+	return b
+}
+
+// WithHVACSettings sets detailed HVAC configuration.
+func (b *EVVehicleStatusBuilder) WithHVACSettings(on bool, targetTemp float64, frontDefrost, rearDefrost bool) *EVVehicleStatusBuilder {
+	hvac := b.response.ResultData[0].PlusBInformation.VehicleInfo.RemoteHvacInfo
+
+	if on {
+		hvac.HVAC = float64(api.HVACStatusOn)
+	} else {
+		hvac.HVAC = float64(api.HVACStatusOff)
+	}
+
+	hvac.TargetTemp = targetTemp
+
+	hvac.FrontDefroster = defrosterValue(frontDefrost)
+	hvac.RearDefogger = defrosterValue(rearDefrost)
+
+	return b
+}
+
+// WithoutDefrosters removes the defroster fields, as reported by vehicles
+// without remote defrosters.
+func (b *EVVehicleStatusBuilder) WithoutDefrosters() *EVVehicleStatusBuilder {
+	hvac := b.response.ResultData[0].PlusBInformation.VehicleInfo.RemoteHvacInfo
+	hvac.FrontDefroster = nil
+	hvac.RearDefogger = nil
+
+	return b
+}
+
+// defrosterValue returns a raw defroster field value.
+func defrosterValue(on bool) *float64 {
+	v := float64(api.DefrosterOff)
+	if on {
+		v = float64(api.DefrosterOn)
+	}
+
+	return &v
+}
+
+// WithCharging sets the charging state.
+func (b *EVVehicleStatusBuilder) WithCharging(charging bool) *EVVehicleStatusBuilder {
+	chargeInfo := &b.response.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo
+	chargeInfo.ChargerConnectorFitting = float64(api.ChargerConnected)
+	if charging {
+		chargeInfo.ChargeStatusSub = float64(api.ChargeStatusCharging)
+	} else {
+		chargeInfo.ChargeStatusSub = float64(api.ChargeStatusNotCharging)
+	}
+
+	return b
+}
+
+// WithPluggedIn sets whether the charger is connected.
+func (b *EVVehicleStatusBuilder) WithPluggedIn(pluggedIn bool) *EVVehicleStatusBuilder {
+	chargeInfo := &b.response.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo
+	if pluggedIn {
+		chargeInfo.ChargerConnectorFitting = float64(api.ChargerConnected)
+	} else {
+		chargeInfo.ChargerConnectorFitting = float64(api.ChargerDisconnected)
+	}
+
+	return b
+}
+
+// WithoutHVAC sets the RemoteHvacInfo to nil (simulates vehicle without HVAC data).
+func (b *EVVehicleStatusBuilder) WithoutHVAC() *EVVehicleStatusBuilder {
+	b.response.ResultData[0].PlusBInformation.VehicleInfo.RemoteHvacInfo = nil
+
+	return b
+}
+
+// Build returns the constructed EVVehicleStatusResponse.
+func (b *EVVehicleStatusBuilder) Build() *api.EVVehicleStatusResponse {
+	return b.response
+}
@@ -0,0 +1,61 @@
+package apitest
+
+import (
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVehicleStatusBuilder tests that built responses round-trip through the api getters.
+func TestVehicleStatusBuilder(t *testing.T) {
+	t.Parallel()
+	want := api.DoorStatus{
+		TrunkOpen:       true,
+		FuelLidOpen:     true,
+		DriverLocked:    true,
+		PassengerLocked: true,
+		RearLeftLocked:  true,
+		RearRightLocked: true,
+	}
+
+	doors, err := NewVehicleStatus().WithDoorStatus(want).Build().GetDoorsInfo()
+	require.NoError(t, err)
+	assert.True(t, doors.TrunkOpen)
+	assert.True(t, doors.FuelLidOpen)
+	assert.False(t, doors.DriverOpen)
+	assert.True(t, doors.DriverLocked)
+	assert.True(t, doors.RearRightLocked)
+
+	windows, err := NewVehicleStatus().WithWindowPositions(0, 25, 0, 100).Build().GetWindowsInfo()
+	require.NoError(t, err)
+	assert.Equal(t, api.WindowStatus{PassengerPosition: 25, RearRightPosition: 100}, windows)
+}
+
+// TestEVVehicleStatusBuilder tests that built EV responses round-trip through the api getters.
+func TestEVVehicleStatusBuilder(t *testing.T) {
+	t.Parallel()
+	status := NewEVVehicleStatus().WithCharging(true).WithHVACSettings(true, 21, true, false).Build()
+
+	battery, err := status.GetBatteryInfo()
+	require.NoError(t, err)
+	assert.True(t, battery.PluggedIn)
+	assert.True(t, battery.Charging)
+
+	hvac, err := status.GetHvacInfo()
+	require.NoError(t, err)
+	assert.True(t, hvac.HVACOn)
+	assert.InDelta(t, 21.0, hvac.TargetTempC, 0.001)
+	assert.True(t, hvac.FrontDefrosterOn())
+	assert.False(t, hvac.RearDefrosterOn())
+	require.NotNil(t, hvac.RearDefroster)
+
+	hvac, err = NewEVVehicleStatus().WithoutDefrosters().Build().GetHvacInfo()
+	require.NoError(t, err)
+	assert.Nil(t, hvac.FrontDefroster)
+	assert.Nil(t, hvac.RearDefroster)
+
+	_, err = NewEVVehicleStatus().WithoutHVAC().Build().GetHvacInfo()
+	require.Error(t, err)
+}
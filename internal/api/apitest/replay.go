@@ -0,0 +1,272 @@
+package apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+)
+
+// RecordEnv is the environment variable that switches NewTransport from
+// replaying fixtures to recording them against the real API.
+const RecordEnv = "MCS_RECORD"
+
+// Fixture is a recorded sequence of API exchanges. Requests and responses are
+// stored decrypted, so fixtures are readable, diffable, and independent of the
+// encryption key used when they were recorded.
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+
+	// Query and Body are the decrypted request parameters, empty when absent.
+	Query string `json:"query,omitempty"`
+	Body  string `json:"body,omitempty"`
+
+	Status int `json:"status"`
+
+	// Payload is the decrypted response payload of a successful exchange.
+	// Response is the raw response body of an error exchange, which the API
+	// sends unencrypted. Exactly one of them is set.
+	Payload  json.RawMessage `json:"payload,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+}
+
+// matches reports whether the interaction was recorded for this request.
+func (i Interaction) matches(method, path, query, body string) bool {
+	return i.Method == method && i.Path == path && i.Query == query && i.Body == body
+}
+
+// NewTransport returns a RoundTripper for api.Client.SetTransport. By default it
+// replays the fixture at path. When MCS_RECORD is set it sends requests to the
+// real API instead, and writes the exchanges to path when the test ends.
+//
+// encKey returns the client's current encryption key (client.Keys.EncKey). It is
+// read on every request because a recording session fetches keys as it goes.
+// Only exchanges encrypted with that key are recorded; key retrieval and login
+// pass through unrecorded. Recorded fixtures hold real account data, so review
+// them before committing.
+func NewTransport(t testing.TB, path string, encKey func() string) http.RoundTripper {
+	t.Helper()
+	if os.Getenv(RecordEnv) != "" {
+		recorder := &Recorder{Next: http.DefaultTransport, EncKey: encKey}
+		t.Cleanup(func() {
+			if err := recorder.Save(path); err != nil {
+				t.Errorf("failed to save fixture: %v", err)
+			}
+		})
+
+		return recorder
+	}
+
+	replayer, err := LoadReplayer(path, encKey)
+	if err != nil {
+		t.Fatalf("failed to load fixture: %v", err)
+	}
+
+	return replayer
+}
+
+// Recorder is a RoundTripper that forwards requests to Next and records the
+// exchanges it can decrypt with EncKey.
+type Recorder struct {
+	Next   http.RoundTripper
+	EncKey func() string
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := r.EncKey()
+	query, body, requestErr := decryptRequest(req, key)
+
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil || requestErr != nil {
+		return resp, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	interaction, ok := recordResponse(respBody, key)
+	if !ok {
+		return resp, nil
+	}
+	interaction.Method = req.Method
+	interaction.Path = req.URL.Path
+	interaction.Query = query
+	interaction.Body = body
+	interaction.Status = resp.StatusCode
+
+	r.mu.Lock()
+	r.fixture.Interactions = append(r.fixture.Interactions, interaction)
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save writes the recorded exchanges to path as indented JSON.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixture: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// recordResponse converts an API response body into an interaction. It reports
+// false for responses that aren't API envelopes encrypted with key.
+func recordResponse(respBody []byte, key string) (Interaction, bool) {
+	var envelope api.APIBaseResponse
+	if err := json.Unmarshal(respBody, &envelope); err != nil || envelope.State == "" {
+		return Interaction{}, false
+	}
+
+	if envelope.Payload == "" {
+		return Interaction{Response: respBody}, true
+	}
+
+	payload, err := api.DecryptAES128CBC(envelope.Payload, key, api.IV)
+	if err != nil || !json.Valid(payload) {
+		return Interaction{}, false
+	}
+
+	return Interaction{Payload: payload}, true
+}
+
+// Replayer is a RoundTripper that answers requests from a Fixture without
+// network access. Payloads are encrypted with EncKey as the API would.
+type Replayer struct {
+	EncKey func() string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+// NewReplayer creates a Replayer for the given fixture.
+func NewReplayer(fixture Fixture, encKey func() string) *Replayer {
+	return &Replayer{
+		EncKey:       encKey,
+		interactions: fixture.Interactions,
+		used:         make([]bool, len(fixture.Interactions)),
+	}
+}
+
+// LoadReplayer creates a Replayer for the fixture file at path.
+func LoadReplayer(path string, encKey func() string) (*Replayer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture %s: %w", path, err)
+	}
+
+	return NewReplayer(fixture, encKey), nil
+}
+
+// RoundTrip implements http.RoundTripper. Each recorded interaction answers one
+// request, in order, so repeated identical requests (e.g. polling) replay the
+// recorded sequence.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := r.EncKey()
+	query, body, err := decryptRequest(req, key)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.interactions {
+		if r.used[i] || !interaction.matches(req.Method, req.URL.Path, query, body) {
+			continue
+		}
+		r.used[i] = true
+
+		return replayResponse(req, interaction, key)
+	}
+
+	return nil, fmt.Errorf("no recorded response for %s %s (body %q)", req.Method, req.URL.Path, body)
+}
+
+// replayResponse builds the HTTP response for a recorded interaction.
+func replayResponse(req *http.Request, interaction Interaction, key string) (*http.Response, error) {
+	respBody := []byte(interaction.Response)
+	if interaction.Payload != nil {
+		encrypted, err := api.EncryptAES128CBC(interaction.Payload, key, api.IV)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt payload: %w", err)
+		}
+		respBody, err = json.Marshal(api.APIBaseResponse{State: "S", Payload: encrypted})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal response: %w", err)
+		}
+	}
+
+	return &http.Response{
+		StatusCode: interaction.Status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(respBody)),
+		Request:    req,
+	}, nil
+}
+
+// decryptRequest returns the decrypted query parameters and body of an API
+// request. The body is restored so the request can still be sent.
+func decryptRequest(req *http.Request, key string) (query, body string, err error) {
+	if params := req.URL.Query().Get("params"); params != "" {
+		decrypted, err := api.DecryptAES128CBC(params, key, api.IV)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to decrypt query: %w", err)
+		}
+		query = string(decrypted)
+	}
+
+	if req.Body == nil {
+		return query, "", nil
+	}
+	encrypted, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(encrypted))
+	if len(encrypted) == 0 {
+		return query, "", nil
+	}
+
+	decrypted, err := api.DecryptAES128CBC(string(encrypted), key, api.IV)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt body: %w", err)
+	}
+
+	return query, string(decrypted), nil
+}
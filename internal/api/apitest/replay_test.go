@@ -0,0 +1,145 @@
+package apitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// replayEncKey is the encryption key used by clients under replay.
+const replayEncKey = "replaytestkey123"
+
+// roundTripFunc adapts a function to http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newReplayClient creates a client with cached credentials that sends requests through transport.
+func newReplayClient(t *testing.T, encKey string, transport func(*api.Client) http.RoundTripper) *api.Client {
+	t.Helper()
+	client, err := api.NewClient("test@example.com", "password", api.RegionMNAO)
+	require.NoError(t, err)
+	client.SetCachedCredentials("test-token", 9999999999, encKey, "replaytestsign12")
+	client.SetTransport(transport(client))
+
+	return client
+}
+
+// fakeUpstream answers vehicle status requests like the API, encrypting with key.
+// Lock requests get a "request in progress" error.
+func fakeUpstream(t *testing.T, key string) roundTripFunc {
+	t.Helper()
+
+	return func(req *http.Request) (*http.Response, error) {
+		var body []byte
+		switch filepath.Base(filepath.Dir(req.URL.Path)) {
+		case "doorLock":
+			body, _ = json.Marshal(map[string]any{"state": "E", "errorCode": api.ErrorCodeRequestIssue, "extraCode": api.ExtraCodeRequestInProgress})
+		default:
+			status := NewVehicleStatus().WithDoorStatus(api.DoorStatus{TrunkOpen: true}).Build()
+			payload, err := json.Marshal(status)
+			require.NoError(t, err)
+			encrypted, err := api.EncryptAES128CBC(payload, key, api.IV)
+			require.NoError(t, err)
+			body, _ = json.Marshal(map[string]any{"state": "S", "payload": encrypted})
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{},
+			Body:       io.NopCloser(bytes.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+// TestRecorderReplayer_RoundTrip records exchanges under one key and replays them under another.
+func TestRecorderReplayer_RoundTrip(t *testing.T) {
+	t.Parallel()
+	fixturePath := filepath.Join(t.TempDir(), "fixture.json")
+	const recordKey = "recordtestkey123"
+
+	var recorder *Recorder
+	recordingClient := newReplayClient(t, recordKey, func(client *api.Client) http.RoundTripper {
+		recorder = &Recorder{Next: fakeUpstream(t, recordKey), EncKey: func() string { return client.Keys.EncKey }}
+
+		return recorder
+	})
+	recorded, err := recordingClient.GetVehicleStatus(context.Background(), "INTERNAL123")
+	require.NoError(t, err)
+	recordedErr := recordingClient.DoorLock(context.Background(), "INTERNAL123")
+	require.Error(t, recordedErr)
+	require.NoError(t, recorder.Save(fixturePath))
+
+	replayer, err := LoadReplayer(fixturePath, func() string { return replayEncKey })
+	require.NoError(t, err)
+	replayClient := newReplayClient(t, replayEncKey, func(*api.Client) http.RoundTripper { return replayer })
+
+	replayed, err := replayClient.GetVehicleStatus(context.Background(), "INTERNAL123")
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+
+	err = replayClient.DoorLock(context.Background(), "INTERNAL123")
+	var inProgress *api.RequestInProgressError
+	require.ErrorAs(t, err, &inProgress)
+
+	// Each recorded interaction answers one request.
+	_, err = replayClient.GetVehicleStatus(context.Background(), "INTERNAL123")
+	require.ErrorContains(t, err, "no recorded response")
+}
+
+// TestRecorder_SkipsUndecryptableExchanges tests that key retrieval and login aren't recorded.
+func TestRecorder_SkipsUndecryptableExchanges(t *testing.T) {
+	t.Parallel()
+	recorder := &Recorder{
+		Next:   fakeUpstream(t, "someotherkey1234"),
+		EncKey: func() string { return "recordtestkey123" },
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://example.com/prod/service/checkVersion", nil)
+	require.NoError(t, err)
+
+	resp, err := recorder.RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Empty(t, recorder.fixture.Interactions)
+}
+
+// TestNewTransport_ReplaysFixture replays testdata/vehicle_status.json. With MCS_RECORD set,
+// it instead records the fixture from the real API using MCS_EMAIL, MCS_PASSWORD and MCS_REGION.
+func TestNewTransport_ReplaysFixture(t *testing.T) {
+	t.Parallel()
+	fixturePath := filepath.Join("testdata", "vehicle_status.json")
+
+	var client *api.Client
+	if os.Getenv(RecordEnv) != "" {
+		var err error
+		client, err = api.NewClient(os.Getenv("MCS_EMAIL"), os.Getenv("MCS_PASSWORD"), api.Region(os.Getenv("MCS_REGION")))
+		require.NoError(t, err)
+		client.SetTransport(NewTransport(t, fixturePath, func() string { return client.Keys.EncKey }))
+	} else {
+		client = newReplayClient(t, replayEncKey, func(c *api.Client) http.RoundTripper {
+			return NewTransport(t, fixturePath, func() string { return c.Keys.EncKey })
+		})
+	}
+
+	baseInfos, err := client.GetVecBaseInfos(context.Background())
+	require.NoError(t, err)
+	internalVIN, err := baseInfos.GetInternalVIN()
+	require.NoError(t, err)
+
+	status, err := client.GetVehicleStatus(context.Background(), internalVIN)
+	require.NoError(t, err)
+	_, err = status.GetTiresInfo()
+	require.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -138,17 +139,110 @@ type Client struct {
 	debug             bool
 	sensorDataBuilder *sensordata.SensorDataBuilder
 	sleepFunc         func(context.Context, time.Duration) error
+	retryLimits       RetryLimits
+	userAgent         string
+
+	// Transport settings from ClientOptions, applied once all options are.
+	tlsConfig     *tls.Config
+	minTLSVersion uint16
+	disableHTTP2  bool
+}
+
+// ClientOption configures optional Client settings in NewClient.
+type ClientOption func(*Client)
+
+// WithTLSConfig makes the client use tlsConfig for HTTPS connections, e.g. to
+// trust the CA of a TLS-inspecting corporate proxy. Proxy settings from the
+// environment still apply, and the minimum TLS version is never lowered below
+// the one set by WithMinTLSVersion (TLS 1.2 by default).
+func WithTLSConfig(tlsConfig *tls.Config) ClientOption {
+	return func(c *Client) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// WithMinTLSVersion sets the oldest TLS version the client accepts, e.g.
+// tls.VersionTLS13. The default is DefaultMinTLSVersion.
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) {
+		c.minTLSVersion = version
+	}
+}
+
+// WithoutHTTP2 makes the client use only HTTP/1.1, for proxies that break HTTP/2.
+func WithoutHTTP2() ClientOption {
+	return func(c *Client) {
+		c.disableHTTP2 = true
+	}
+}
+
+// WithUserAgent sets the User-Agent sent with base API requests, e.g. to mimic
+// another app version. The default is UserAgentBaseAPI; login requests to the
+// Usher API always send UserAgentUsherAPI.
+func WithUserAgent(userAgent string) ClientOption {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// DefaultMinTLSVersion is the oldest TLS version the client accepts by default.
+const DefaultMinTLSVersion = tls.VersionTLS12
+
+// newTransport builds the client's HTTP transport from the TLS options: the
+// default transport's settings, including proxies from the environment, with
+// tlsConfig (if any), at least minVersion, and HTTP/1.1 only if disableHTTP2 is set.
+func newTransport(tlsConfig *tls.Config, minVersion uint16, disableHTTP2 bool) *http.Transport {
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment, ForceAttemptHTTP2: true}
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+		transport = defaultTransport.Clone()
+	}
+
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: minVersion}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+		tlsConfig.MinVersion = max(tlsConfig.MinVersion, minVersion)
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if disableHTTP2 {
+		var protocols http.Protocols
+		protocols.SetHTTP1(true)
+		transport.Protocols = &protocols
+	}
+
+	return transport
+}
+
+// NewTransport returns the HTTP transport a client created with opts uses, for
+// other requests that should honor the same TLS and proxy settings, such as
+// address lookups.
+func NewTransport(opts ...ClientOption) http.RoundTripper {
+	client := &Client{minTLSVersion: DefaultMinTLSVersion}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return newTransport(client.tlsConfig, client.minTLSVersion, client.disableHTTP2)
+}
+
+// WithRetryLimits sets how many times a request is retried for each class of
+// error. See DefaultRetryLimits for the defaults.
+func WithRetryLimits(limits RetryLimits) ClientOption {
+	return func(c *Client) {
+		c.retryLimits = limits
+	}
 }
 
 // NewClient creates a new API client.
-func NewClient(email, password string, region Region) (*Client, error) {
+func NewClient(email, password string, region Region, opts ...ClientOption) (*Client, error) {
 	if !region.IsValid() {
 		return nil, fmt.Errorf("invalid region: %s", region)
 	}
 
 	config := RegionConfigs[string(region)]
 
-	return &Client{
+	client := &Client{
 		email:             email,
 		password:          password,
 		region:            region,
@@ -161,7 +255,16 @@ func NewClient(email, password string, region Region) (*Client, error) {
 		debug:             false,
 		sensorDataBuilder: sensordata.NewSensorDataBuilder(),
 		sleepFunc:         sleepWithContext,
-	}, nil
+		retryLimits:       DefaultRetryLimits(),
+		userAgent:         UserAgentBaseAPI,
+		minTLSVersion:     DefaultMinTLSVersion,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	client.httpClient.Transport = newTransport(client.tlsConfig, client.minTLSVersion, client.disableHTTP2)
+
+	return client, nil
 }
 
 // SetDebug enables or disables debug logging.
@@ -169,12 +272,23 @@ func (c *Client) SetDebug(debug bool) {
 	c.debug = debug
 }
 
-// SetCachedCredentials sets the client's cached authentication credentials.
+// SetTransport replaces the HTTP transport used for all requests, e.g. with a
+// recording or replaying transport in tests.
+func (c *Client) SetTransport(transport http.RoundTripper) {
+	c.httpClient.Transport = transport
+}
+
+// SetCachedCredentials sets the client's cached authentication credentials. The
+// keys' age is unknown; set Keys.FetchedAt afterwards if it was cached too.
 func (c *Client) SetCachedCredentials(accessToken string, accessTokenExpirationTs int64, encKey, signKey string) {
 	c.accessToken = accessToken
 	c.accessTokenExpirationTs = accessTokenExpirationTs
-	c.Keys.EncKey = encKey
-	c.Keys.SignKey = signKey
+	c.Keys = Keys{EncKey: encKey, SignKey: signKey}
+}
+
+// SetPassword replaces the password used for the next login.
+func (c *Client) SetPassword(password string) {
+	c.password = password
 }
 
 // GetCredentials returns the current authentication credentials for caching.
@@ -193,7 +307,7 @@ func (c *Client) GetEncryptionKeys(ctx context.Context) error {
 		"device-id":     c.baseAPIDeviceID,
 		"app-code":      c.appCode,
 		"app-os":        AppOS,
-		"user-agent":    UserAgentBaseAPI,
+		"user-agent":    c.userAgent,
 		"app-version":   AppVersion,
 		"app-unique-id": AppPackageID,
 		"access-token":  "",
@@ -241,12 +355,29 @@ func (c *Client) GetEncryptionKeys(ctx context.Context) error {
 		return fmt.Errorf("failed to decrypt payload: %w", err)
 	}
 
-	c.Keys.EncKey = decrypted.EncKey
-	c.Keys.SignKey = decrypted.SignKey
+	c.updateKeys(decrypted)
 
 	return nil
 }
 
+// updateKeys stores keys from a checkVersion response, logging when they replace
+// different keys already held by the client (i.e. the server rotated them mid-session).
+func (c *Client) updateKeys(keys *CheckVersionResponse) {
+	if c.Keys.EncKey != keys.EncKey || c.Keys.SignKey != keys.SignKey {
+		if c.Keys.EncKey != "" || c.Keys.SignKey != "" {
+			c.logDebugf("Encryption keys rotated by server")
+		}
+	}
+
+	c.Keys = Keys{EncKey: keys.EncKey, SignKey: keys.SignKey, FetchedAt: time.Now()}
+}
+
+// RotateKeys fetches fresh encryption and signing keys from the server,
+// replacing any the client already holds.
+func (c *Client) RotateKeys(ctx context.Context) error {
+	return c.GetEncryptionKeys(ctx)
+}
+
 // GetUsherEncryptionKey retrieves the RSA public key from Usher API.
 func (c *Client) GetUsherEncryptionKey(ctx context.Context) (string, string, error) {
 	// Ensure we have a timeout for the request
@@ -293,7 +424,7 @@ func (c *Client) GetUsherEncryptionKey(ctx context.Context) (string, string, err
 func validateLoginResponse(response *LoginResponse) error {
 	switch response.Status {
 	case "INVALID_CREDENTIAL":
-		return errors.New("invalid email or password")
+		return NewInvalidCredentialError()
 	case "USER_LOCKED":
 		return errors.New("account is locked")
 	case "OK":
@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -68,6 +71,61 @@ func TestClient_GetEncryptionKeys(t *testing.T) {
 	assert.Equalf(t, "test-sign-key-456", client.Keys.SignKey, "Expected signKey='test-sign-key-456', got '%s'", client.Keys.SignKey)
 }
 
+func TestClient_RotateKeys(t *testing.T) {
+	t.Parallel()
+	testClient := &Client{appCode: "202007270941270111799"}
+	decryptionKey := testClient.getDecryptionKeyFromAppCode()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responseJSON, _ := json.Marshal(map[string]any{
+			"encKey":  "rotated-enc-key",
+			"signKey": "rotated-sign-key",
+		})
+		encrypted, _ := EncryptAES128CBC(responseJSON, decryptionKey, "0102030405060708")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"state":   "S",
+			"payload": encrypted,
+		})
+	}))
+	defer server.Close()
+
+	client := &Client{
+		email:      "test@example.com",
+		password:   "password",
+		region:     RegionMNAO,
+		baseURL:    server.URL + "/prod/",
+		appCode:    "202007270941270111799",
+		httpClient: server.Client(),
+	}
+	client.baseAPIDeviceID = GenerateUUIDFromSeed(client.email)
+	client.Keys.EncKey = "stale-enc-key"
+	client.Keys.SignKey = "stale-sign-key"
+
+	err := client.RotateKeys(context.Background())
+	require.NoError(t, err, "RotateKeys() error = %v")
+
+	assert.Equal(t, "rotated-enc-key", client.Keys.EncKey)
+	assert.Equal(t, "rotated-sign-key", client.Keys.SignKey)
+}
+
+func TestClient_updateKeys(t *testing.T) {
+	t.Parallel()
+	client := &Client{}
+
+	client.updateKeys(&CheckVersionResponse{EncKey: "enc-1", SignKey: "sign-1"})
+	assert.Equal(t, "enc-1", client.Keys.EncKey)
+	assert.Equal(t, "sign-1", client.Keys.SignKey)
+
+	client.updateKeys(&CheckVersionResponse{EncKey: "enc-1", SignKey: "sign-1"})
+	assert.Equal(t, "enc-1", client.Keys.EncKey, "Expected unchanged keys to be kept")
+
+	client.updateKeys(&CheckVersionResponse{EncKey: "enc-2", SignKey: "sign-2"})
+	assert.Equal(t, "enc-2", client.Keys.EncKey)
+	assert.Equal(t, "sign-2", client.Keys.SignKey)
+}
+
 func TestClient_GetUsherEncryptionKey(t *testing.T) {
 	t.Parallel()
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -153,6 +211,47 @@ func TestClient_Login(t *testing.T) {
 	assert.NotEqual(t, 0, client.accessTokenExpirationTs, "Expected accessTokenExpirationTs to be set")
 }
 
+func TestClient_Login_InvalidCredential(t *testing.T) {
+	t.Parallel()
+	loginCalls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/appapi/v1/" + EndpointEncryptionKey:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"data": map[string]any{
+					"publicKey":     "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAlVKZRa1pkk88B1ydifsFNEv/pOf854egpFu1HHf1wr3YKqmLSG1p39YhNqGLQzIDit1jTLz3MYAOeWiFQSz7h5hvMNccq76zh3Hsg93LurcKA9EmYoj9VsqUetk0evXoqOSGKXPgZosbGT0t8AW2CC7s8FeSPz2tH9T7zjvKQvdyS0BFrVFo1EUBa1UEdMfYW0jLsvLOCYP911X1zTlewV/sTQnAtiTHCrd3jfH2of8PYtTOsmfqCDdL476yGMgeHJ+ZXA/IX2beSrHXU0gCNc/agD+ScCZgpRjfptSbRtBHqtmU4IyF0eqQXCCcrcutjzSHg+3ppmB9x/YvhJvmGQIDAQAB",
+					"versionPrefix": "v1:",
+				},
+			})
+
+		case "/appapi/v1/" + EndpointLogin:
+			loginCalls++
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"status": "INVALID_CREDENTIAL",
+			})
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{
+		email:      "test@example.com",
+		password:   "wrong-password",
+		region:     RegionMNAO,
+		usherURL:   server.URL + "/appapi/v1/",
+		httpClient: server.Client(),
+	}
+	client.usherAPIDeviceID = GenerateUsherDeviceID(client.email)
+
+	err := client.Login(context.Background())
+	require.Error(t, err, "Expected Login() to fail")
+
+	assert.Truef(t, IsInvalidCredential(err), "Expected InvalidCredentialError, got %T: %v", err, err)
+	assert.EqualError(t, err, "incorrect email or password")
+	assert.Equal(t, 1, loginCalls, "Expected a single login attempt")
+	assert.Empty(t, client.accessToken, "Expected accessToken to remain unset")
+}
+
 func TestClient_IsTokenValid(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -330,3 +429,124 @@ func TestRegion_IsValid(t *testing.T) {
 		})
 	}
 }
+
+// TestNewClient_WithTLSConfig tests that the TLS option's CA pool is applied to the transport.
+func TestNewClient_WithTLSConfig(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	get := func(client *Client) error {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := client.httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		return resp.Body.Close()
+	}
+
+	// The test server's certificate isn't trusted by default.
+	client, err := NewClient("test@example.com", "password", RegionMNAO)
+	require.NoError(t, err)
+	require.Error(t, get(client))
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	client, err = NewClient("test@example.com", "password", RegionMNAO, WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+	require.NoError(t, err)
+
+	transport, ok := client.httpClient.Transport.(*http.Transport)
+	require.True(t, ok, "expected an *http.Transport, got %T", client.httpClient.Transport)
+	assert.Same(t, pool, transport.TLSClientConfig.RootCAs)
+	assert.NotNil(t, transport.Proxy, "proxy settings from the environment should still apply")
+	require.NoError(t, get(client))
+}
+
+// TestNewClient_Transport tests the minimum TLS version and HTTP/2 options.
+func TestNewClient_Transport(t *testing.T) {
+	t.Parallel()
+	transportOf := func(t *testing.T, opts ...ClientOption) *http.Transport {
+		t.Helper()
+		client, err := NewClient("test@example.com", "password", RegionMNAO, opts...)
+		require.NoError(t, err)
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		require.True(t, ok, "expected an *http.Transport, got %T", client.httpClient.Transport)
+
+		return transport
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		t.Parallel()
+		transport := transportOf(t)
+		assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+		assert.Nil(t, transport.Protocols, "HTTP/2 should be left to the default")
+		assert.NotNil(t, transport.Proxy)
+	})
+
+	t.Run("min TLS version", func(t *testing.T) {
+		t.Parallel()
+		transport := transportOf(t, WithMinTLSVersion(tls.VersionTLS13))
+		assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+	})
+
+	t.Run("min TLS version raises a custom TLS config", func(t *testing.T) {
+		t.Parallel()
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		transport := transportOf(t, WithTLSConfig(tlsConfig), WithMinTLSVersion(tls.VersionTLS13))
+		assert.Equal(t, uint16(tls.VersionTLS13), transport.TLSClientConfig.MinVersion)
+		assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion, "the caller's config should not be modified")
+	})
+
+	t.Run("without HTTP/2", func(t *testing.T) {
+		t.Parallel()
+		transport := transportOf(t, WithoutHTTP2())
+		require.NotNil(t, transport.Protocols)
+		assert.True(t, transport.Protocols.HTTP1())
+		assert.False(t, transport.Protocols.HTTP2())
+	})
+
+	t.Run("protocol negotiated with an HTTP/2 server", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(r.Proto))
+		}))
+		server.EnableHTTP2 = true
+		server.StartTLS()
+		defer server.Close()
+		pool := x509.NewCertPool()
+		pool.AddCert(server.Certificate())
+
+		protoOf := func(opts ...ClientOption) string {
+			client := &http.Client{Transport: transportOf(t, append(opts, WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))...)}
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			return string(body)
+		}
+
+		assert.Equal(t, "HTTP/2.0", protoOf())
+		assert.Equal(t, "HTTP/1.1", protoOf(WithoutHTTP2()))
+	})
+}
+
+// TestNewClient_WithRetryLimits tests the default and overridden retry limits.
+func TestNewClient_WithRetryLimits(t *testing.T) {
+	t.Parallel()
+	client, err := NewClient("test@example.com", "password", RegionMNAO)
+	require.NoError(t, err)
+	assert.Equal(t, RetryLimits{EncryptionKey: MaxRetries, TokenRefresh: MaxTokenRefreshRetries, Transient: MaxTransientRetries}, client.retryLimits)
+
+	limits := RetryLimits{EncryptionKey: 1, TokenRefresh: 0}
+	client, err = NewClient("test@example.com", "password", RegionMNAO, WithRetryLimits(limits))
+	require.NoError(t, err)
+	assert.Equal(t, limits, client.retryLimits)
+}
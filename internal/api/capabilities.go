@@ -0,0 +1,96 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VehicleCapabilities lists the remote features a vehicle supports. Unknown models
+// support everything, so that new models aren't locked out of commands that work.
+type VehicleCapabilities struct {
+	// RemoteEngineStart is whether mcs start can start the engine.
+	RemoteEngineStart bool
+	// WindowStatus is whether the vehicle reports its window positions.
+	WindowStatus bool
+	// Sunroof is whether the vehicle reports a sunroof.
+	Sunroof bool
+}
+
+// allCapabilities is what a model without known restrictions supports.
+func allCapabilities() VehicleCapabilities {
+	return VehicleCapabilities{
+		RemoteEngineStart: true,
+		WindowStatus:      true,
+		Sunroof:           true,
+	}
+}
+
+// modelCapability is the capabilities of the models whose code starts with prefix.
+type modelCapability struct {
+	prefix       string
+	capabilities VehicleCapabilities
+}
+
+// modelCapabilities lists models known not to support every capability, by model
+// code prefix. The longest matching prefix wins; models not listed support everything.
+var modelCapabilities = []modelCapability{
+	// MX-30: the EV has no engine, and the R-EV's range extender can't be started remotely.
+	{prefix: "DR", capabilities: VehicleCapabilities{RemoteEngineStart: false, WindowStatus: true, Sunroof: true}},
+}
+
+// combustionOnlyModels lists model code prefixes of models sold only with a
+// combustion engine or a non-plug-in hybrid, so that they're classified ICE even
+// when their names don't say. Platforms shared with plug-in models, such as the
+// CX-90's KK and the MX-30's DR, are left out.
+var combustionOnlyModels = []string{
+	"BP", // Mazda3
+	"DK", // CX-3
+	"DM", // CX-30
+	"GJ", // Mazda6
+	"GL", // Mazda6
+	"KF", // CX-5
+	"ND", // MX-5
+	"TC", // CX-9
+	"VA", // CX-50
+}
+
+// Capabilities looks up the capabilities of a model by its model code (e.g. "KKEH").
+// Unknown and empty model codes support everything.
+func Capabilities(modelCode string) VehicleCapabilities {
+	code := strings.ToUpper(strings.TrimSpace(modelCode))
+	capabilities := allCapabilities()
+	longest := 0
+	for _, model := range modelCapabilities {
+		if len(model.prefix) > longest && strings.HasPrefix(code, model.prefix) {
+			capabilities = model.capabilities
+			longest = len(model.prefix)
+		}
+	}
+
+	return capabilities
+}
+
+// EconnectType is the vehicle's connectivity tier, reported as econnectType by
+// GetVecBaseInfos. It is shown for reference only: no tier is known to lack any
+// service, so nothing is gated on it.
+type EconnectType int
+
+const (
+	// EconnectTypeUnknown means the vehicle didn't report a connectivity tier.
+	EconnectTypeUnknown EconnectType = 0
+	// EconnectTypeStandard is the only tier seen so far, on vehicles with full
+	// remote services.
+	EconnectTypeStandard EconnectType = 1
+)
+
+// String names the tier, e.g. "standard", or gives its number if unknown.
+func (e EconnectType) String() string {
+	switch e {
+	case EconnectTypeUnknown:
+		return "unknown"
+	case EconnectTypeStandard:
+		return "standard"
+	default:
+		return fmt.Sprintf("econnect type %d", int(e))
+	}
+}
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCapabilities(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		modelCode string
+		want      VehicleCapabilities
+	}{
+		{"known model without restrictions", "KKEH", allCapabilities()},
+		{"unknown model", "ZZ99", allCapabilities()},
+		{"no model code", "", allCapabilities()},
+		{"restricted model", "DR4B", VehicleCapabilities{RemoteEngineStart: false, WindowStatus: true, Sunroof: true}},
+		{"restricted model lowercase", " dr4b ", VehicleCapabilities{RemoteEngineStart: false, WindowStatus: true, Sunroof: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, Capabilities(tt.modelCode))
+		})
+	}
+}
+
+func TestEconnectType(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		econnectType EconnectType
+		wantName     string
+	}{
+		{EconnectTypeStandard, "standard"},
+		{EconnectTypeUnknown, "unknown"},
+		{EconnectType(7), "econnect type 7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantName, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantName, tt.econnectType.String())
+		})
+	}
+}
@@ -2,22 +2,85 @@ package api
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
+	"syscall"
 	"time"
 )
 
 const (
-	// MaxRetries is the maximum number of retries for API requests.
+	// MaxRetries is the default maximum number of retries for encryption errors.
 	MaxRetries = 4
+	// MaxTokenRefreshRetries is the default maximum number of logins after an expired token.
+	MaxTokenRefreshRetries = 2
+	// MaxTransientRetries is the default maximum number of retries after a network
+	// failure or an unavailable server.
+	MaxTransientRetries = 3
 )
 
+// RetryLimits caps how many times a single request is retried for each class of
+// error. Each class has its own budget, so repeated encryption errors don't use up
+// the retries left for an expired token.
+type RetryLimits struct {
+	EncryptionKey int // re-fetching the encryption keys after an encryption error
+	TokenRefresh  int // logging in again after the access token expired
+	Transient     int // timeouts, dropped connections, and HTTP 502/503/504
+}
+
+// DefaultRetryLimits returns the retry limits used unless WithRetryLimits is given.
+func DefaultRetryLimits() RetryLimits {
+	return RetryLimits{
+		EncryptionKey: MaxRetries,
+		TokenRefresh:  MaxTokenRefreshRetries,
+		Transient:     MaxTransientRetries,
+	}
+}
+
+// retryClass identifies a class of retryable error with its own retry budget.
+type retryClass int
+
+const (
+	retryClassEncryptionKey retryClass = iota
+	retryClassTokenRefresh
+	retryClassTransient
+)
+
+// limit returns the retry budget for class.
+func (l RetryLimits) limit(class retryClass) int {
+	switch class {
+	case retryClassEncryptionKey:
+		return l.EncryptionKey
+	case retryClassTokenRefresh:
+		return l.TokenRefresh
+	case retryClassTransient:
+		return l.Transient
+	default:
+		return 0
+	}
+}
+
+// retryCounts tracks how many times a request has been retried in each class.
+type retryCounts map[retryClass]int
+
+// total returns the number of retries across all classes.
+func (r retryCounts) total() int {
+	total := 0
+	for _, count := range r {
+		total += count
+	}
+
+	return total
+}
+
 // calculateBackoff returns the backoff duration for a given retry count.
 // Uses exponential backoff: 1s, 2s, 4s, 8s.
 func calculateBackoff(retryCount int) time.Duration {
@@ -47,62 +110,103 @@ func sleepWithContext(ctx context.Context, duration time.Duration) error {
 }
 
 // APIRequest makes an API request with proper encryption, signing, and error handling.
+// The request is treated as a read: it is retried after transient failures.
 func (c *Client) APIRequest(ctx context.Context, method, uri string, queryParams map[string]string, bodyParams map[string]any, needsKeys, needsAuth bool) (map[string]any, error) {
-	return c.apiRequestWithRetry(ctx, method, uri, queryParams, bodyParams, needsKeys, needsAuth, 0)
+	return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, true, retryCounts{}, c.sendAPIRequest)
 }
 
 // APIRequestJSON makes an API request and returns the raw decrypted JSON bytes.
+// The request is treated as a read: it is retried after transient failures.
 func (c *Client) APIRequestJSON(ctx context.Context, method, uri string, queryParams map[string]string, bodyParams map[string]any, needsKeys, needsAuth bool) ([]byte, error) {
-	return c.apiRequestJSONWithRetry(ctx, method, uri, queryParams, bodyParams, needsKeys, needsAuth, 0)
+	return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, true, retryCounts{}, c.sendAPIRequestJSON)
+}
+
+// controlRequest sends a command to the vehicle. Unlike APIRequest it is only
+// retried after transient failures that happened before the request was sent,
+// since the server may already have acted on a command whose response was lost.
+func (c *Client) controlRequest(ctx context.Context, uri string, bodyParams map[string]any) (map[string]any, error) {
+	return genericRetry(ctx, c, "POST", uri, nil, bodyParams, true, true, false, retryCounts{}, c.sendAPIRequest)
 }
 
 // retryFunc is the type for functions that can be retried.
 type retryFunc[T any] func(ctx context.Context, method, uri string, queryParams map[string]string, bodyParams map[string]any, needsKeys, needsAuth bool) (T, error)
 
-// handleRetryableError attempts to recover from an encryption or token error by refreshing credentials.
-// Returns true if the error was handled and a retry should be attempted.
-func handleRetryableError[T any](
-	ctx context.Context,
-	c *Client,
-	err error,
-	retryCount int,
-) (shouldRetry bool, retryErr error) {
+// classifyRetryableError returns the retry class of err, or false if err isn't retryable.
+// Context cancellation and deadlines are never retried, and neither are other HTTP
+// errors such as 4xx responses. Requests that aren't idempotent are only retried
+// after transient failures to connect, when the request never reached the server.
+func classifyRetryableError(err error, idempotent bool) (retryClass, bool) {
 	var encErr *EncryptionError
 	var tokenErr *TokenExpiredError
+	var unavailableErr *ServerUnavailableError
 
-	if errors.As(err, &encErr) {
-		// Retrieve new encryption keys and retry
-		if err := c.GetEncryptionKeys(ctx); err != nil {
-			return false, fmt.Errorf("failed to retrieve encryption keys: %w", err)
-		}
-		// Apply backoff delay before retry
-		backoff := calculateBackoff(retryCount + 1)
-		if err := c.sleepFunc(ctx, backoff); err != nil {
-			return false, err
-		}
+	switch {
+	case errors.As(err, &encErr):
+		return retryClassEncryptionKey, true
+	case errors.As(err, &tokenErr):
+		return retryClassTokenRefresh, true
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return 0, false
+	case isConnectError(err):
+		return retryClassTransient, true
+	case idempotent && (errors.As(err, &unavailableErr) || isTransientNetworkError(err)):
+		return retryClassTransient, true
+	default:
+		return 0, false
+	}
+}
 
-		return true, nil
+// isTransientNetworkError reports whether err is a network failure that may succeed
+// on retry: a timeout, a reset connection, or a connection closed early.
+func isTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
 	}
 
-	if errors.As(err, &tokenErr) {
-		// Login again and retry
-		if err := c.Login(ctx); err != nil {
-			return false, fmt.Errorf("failed to login: %w", err)
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// isConnectError reports whether err is a failure to connect to the server, so
+// that the request was never sent: a refused connection, or a failed dial or DNS
+// lookup.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// handleRetryableError recovers from an error of the given class, by refreshing the
+// encryption keys or logging in again, then waits before the retry. Transient errors
+// need no recovery beyond the wait. The backoff grows
+// with the total number of retries so far, whatever their class.
+func handleRetryableError(ctx context.Context, c *Client, class retryClass, retries retryCounts) error {
+	switch class {
+	case retryClassEncryptionKey:
+		// Drop the rejected keys so a failed fetch doesn't leave them in use.
+		c.Keys = Keys{}
+		if err := c.GetEncryptionKeys(ctx); err != nil {
+			return fmt.Errorf("failed to retrieve encryption keys: %w", err)
 		}
-		// Apply backoff delay before retry
-		backoff := calculateBackoff(retryCount + 1)
-		if err := c.sleepFunc(ctx, backoff); err != nil {
-			return false, err
+	case retryClassTokenRefresh:
+		if err := c.Login(ctx); err != nil {
+			return fmt.Errorf("failed to login: %w", err)
 		}
-
-		return true, nil
+	case retryClassTransient:
+		// Nothing to refresh; the backoff below gives the server time to recover.
 	}
 
-	return false, nil
+	return c.sleepFunc(ctx, calculateBackoff(retries.total()+1))
 }
 
 // genericRetry implements the retry logic with exponential backoff for API requests.
-// It handles encryption errors and token expiration by refreshing credentials and retrying.
+// It handles encryption errors and token expiration by refreshing credentials and
+// retrying, and retries transient network and server failures (only failures to
+// connect unless idempotent), up to the client's retry limit for each class of error.
 func genericRetry[T any](
 	ctx context.Context,
 	c *Client,
@@ -110,15 +214,12 @@ func genericRetry[T any](
 	queryParams map[string]string,
 	bodyParams map[string]any,
 	needsKeys, needsAuth bool,
-	retryCount int,
+	idempotent bool,
+	retries retryCounts,
 	executeFunc retryFunc[T],
 ) (T, error) {
 	var zero T // zero value for type T
 
-	if retryCount > MaxRetries {
-		return zero, NewAPIError("Request exceeded max number of retries")
-	}
-
 	// Check for context cancellation
 	if err := ctx.Err(); err != nil {
 		return zero, err
@@ -138,29 +239,24 @@ func genericRetry[T any](
 
 	response, err := executeFunc(ctx, method, uri, queryParams, bodyParams, needsKeys, needsAuth)
 	if err != nil {
-		// Handle retryable errors
-		shouldRetry, retryErr := handleRetryableError[T](ctx, c, err, retryCount)
-		if retryErr != nil {
-			return zero, retryErr
+		class, retryable := classifyRetryableError(err, idempotent)
+		if !retryable {
+			return zero, err
+		}
+		if retries[class] >= c.retryLimits.limit(class) {
+			return zero, fmt.Errorf("request exceeded max number of retries: %w", err)
 		}
-		if shouldRetry {
-			return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, retryCount+1, executeFunc)
+		if err := handleRetryableError(ctx, c, class, retries); err != nil {
+			return zero, err
 		}
+		retries[class]++
 
-		return zero, err
+		return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, idempotent, retries, executeFunc)
 	}
 
 	return response, nil
 }
 
-func (c *Client) apiRequestWithRetry(ctx context.Context, method, uri string, queryParams map[string]string, bodyParams map[string]any, needsKeys, needsAuth bool, retryCount int) (map[string]any, error) {
-	return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, retryCount, c.sendAPIRequest)
-}
-
-func (c *Client) apiRequestJSONWithRetry(ctx context.Context, method, uri string, queryParams map[string]string, bodyParams map[string]any, needsKeys, needsAuth bool, retryCount int) ([]byte, error) {
-	return genericRetry(ctx, c, method, uri, queryParams, bodyParams, needsKeys, needsAuth, retryCount, c.sendAPIRequestJSON)
-}
-
 // handleAPIResponse processes the API response and returns the encrypted payload or an error.
 // It centralizes error handling logic for all API responses.
 func handleAPIResponse(response *APIBaseResponse) (string, error) {
@@ -189,15 +285,29 @@ func handleAPIResponse(response *APIBaseResponse) (string, error) {
 		}
 	}
 
-	// Generic error
-	if response.Message != "" {
-		return "", NewAPIError("Request failed: " + response.Message)
+	// Rate limiting is only reported through the message text
+	if isRateLimitMessage(response.Message) || isRateLimitMessage(response.Error) {
+		return "", NewRateLimitedError(DefaultRateLimitRetryAfter)
+	}
+
+	// Generic error, keeping the server's codes so callers can branch on them
+	message := "Request failed for an unknown reason"
+	if detail := cmp.Or(response.Message, response.Error); detail != "" {
+		message = "Request failed: " + detail
 	}
-	if response.Error != "" {
-		return "", NewAPIError("Request failed: " + response.Error)
+
+	return "", NewAPIError(int(response.ErrorCode), response.ExtraCode, message)
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds.
+// It returns 0 when the header is missing or not a number of seconds.
+func parseRetryAfter(header string) time.Duration {
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return 0
 	}
 
-	return "", NewAPIError("Request failed for an unknown reason")
+	return time.Duration(seconds) * time.Second
 }
 
 // preparedParams holds the prepared and encrypted request parameters.
@@ -296,7 +406,7 @@ func (c *Client) buildHTTPRequest(ctx context.Context, method, uri, timestamp st
 		"device-id":         c.baseAPIDeviceID,
 		"app-code":          c.appCode,
 		"app-os":            AppOS,
-		"user-agent":        UserAgentBaseAPI,
+		"user-agent":        c.userAgent,
 		"app-version":       AppVersion,
 		"app-unique-id":     AppPackageID,
 		"req-id":            "req_" + timestamp,
@@ -348,6 +458,13 @@ func (c *Client) executeAPIRequest(ctx context.Context, method, uri string, quer
 
 	c.logResponse(resp.StatusCode, body)
 
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return "", NewRateLimitedError(parseRetryAfter(resp.Header.Get("Retry-After")))
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return "", NewServerUnavailableError(resp.StatusCode)
+	}
+
 	var response APIBaseResponse
 	if err := json.Unmarshal(body, &response); err != nil {
 		return "", fmt.Errorf("failed to parse response: %w", err)
@@ -374,9 +491,10 @@ func (c *Client) sendAPIRequestJSON(ctx context.Context, method, uri string, que
 	return c.decryptPayloadBytes(encryptedPayload)
 }
 
-// ensureKeysPresent ensures encryption keys are available.
+// ensureKeysPresent ensures encryption keys are available, fetching them if the
+// client has none or they're older than KeysTTL.
 func (c *Client) ensureKeysPresent(ctx context.Context) error {
-	if c.Keys.EncKey == "" || c.Keys.SignKey == "" {
+	if c.Keys.EncKey == "" || c.Keys.SignKey == "" || c.Keys.Expired(time.Now()) {
 		return c.GetEncryptionKeys(ctx)
 	}
 
@@ -395,7 +513,7 @@ func (c *Client) ensureTokenValid(ctx context.Context) error {
 // encryptPayloadUsingKey encrypts a payload using the client's encryption key.
 func (c *Client) encryptPayloadUsingKey(payload string) (string, error) {
 	if c.Keys.EncKey == "" {
-		return "", NewAPIError("Missing encryption key")
+		return "", NewAPIError(0, "", "Missing encryption key")
 	}
 	if payload == "" {
 		return "", nil
@@ -407,7 +525,7 @@ func (c *Client) encryptPayloadUsingKey(payload string) (string, error) {
 // decryptPayloadUsingKey decrypts a payload using the client's encryption key.
 func (c *Client) decryptPayloadUsingKey(payload string) (map[string]any, error) {
 	if c.Keys.EncKey == "" {
-		return nil, NewAPIError("Missing encryption key")
+		return nil, NewAPIError(0, "", "Missing encryption key")
 	}
 
 	decrypted, err := DecryptAES128CBC(payload, c.Keys.EncKey, IV)
@@ -426,7 +544,7 @@ func (c *Client) decryptPayloadUsingKey(payload string) (map[string]any, error)
 // decryptPayloadBytes decrypts a payload and returns raw JSON bytes.
 func (c *Client) decryptPayloadBytes(payload string) ([]byte, error) {
 	if c.Keys.EncKey == "" {
-		return nil, NewAPIError("Missing encryption key")
+		return nil, NewAPIError(0, "", "Missing encryption key")
 	}
 
 	decrypted, err := DecryptAES128CBC(payload, c.Keys.EncKey, IV)
@@ -473,6 +591,15 @@ func (c *Client) logRequest(method, url string, headers map[string]string, body
 	}
 }
 
+// logDebugf logs a formatted message when debug mode is enabled.
+func (c *Client) logDebugf(format string, args ...any) {
+	if !c.debug {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "DEBUG: "+format+"\n", args...)
+}
+
 // logResponse logs response details when debug mode is enabled.
 func (c *Client) logResponse(statusCode int, body []byte) {
 	if !c.debug {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -83,6 +84,46 @@ func TestAPIRequest_RetryOnEncryptionError(t *testing.T) {
 	assert.Equalf(t, 3, requestCount, "Expected 3 requests (error + get keys + retry), got %d", requestCount)
 }
 
+// TestAPIRequest_KeysCachedUntilTTL tests that fetched keys are reused for requests
+// within KeysTTL and fetched again once they expire.
+func TestAPIRequest_KeysCachedUntilTTL(t *testing.T) {
+	t.Parallel()
+	keyGets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload any = map[string]any{"resultCode": ResultCodeSuccess}
+		encKey := testEncKey
+		if r.URL.Path == "/"+EndpointCheckVersion {
+			keyGets++
+			payload = map[string]any{"encKey": testEncKey, "signKey": testSignKey}
+			encKey = (&Client{appCode: "202007270941270111799"}).getDecryptionKeyFromAppCode()
+		}
+		responseJSON, _ := json.Marshal(payload)
+		encrypted, _ := EncryptAES128CBC(responseJSON, encKey, IV)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"state": "S", "payload": encrypted})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test@example.com", "password", RegionMNAO)
+	require.NoError(t, err)
+	client.baseURL = server.URL + "/"
+
+	for range 2 {
+		_, err = client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{}, true, false)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, keyGets, "second request within the TTL should reuse the keys")
+	assert.WithinDuration(t, time.Now(), client.Keys.FetchedAt, time.Minute)
+
+	client.Keys.FetchedAt = time.Now().Add(-KeysTTL - time.Minute)
+	_, err = client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{}, true, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, keyGets, "expired keys should be fetched again")
+	assert.False(t, client.Keys.Expired(time.Now()))
+	assert.False(t, Keys{EncKey: testEncKey, SignKey: testSignKey}.Expired(time.Now()), "keys of unknown age don't expire")
+}
+
 // TestAPIRequest_MaxRetries tests that max retries is enforced.
 func TestAPIRequest_MaxRetries(t *testing.T) {
 	t.Parallel()
@@ -132,7 +173,293 @@ func TestAPIRequest_MaxRetries(t *testing.T) {
 	_, err = client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, true, false)
 	require.Error(t, err, "Expected error due to max retries, got nil")
 
-	assert.EqualError(t, err, "Request exceeded max number of retries")
+	assert.EqualError(t, err, "request exceeded max number of retries: Server rejected encrypted request")
+}
+
+// TestAPIRequest_RetryLimitsPerClass tests that each class of retryable error has its
+// own retry budget.
+func TestAPIRequest_RetryLimitsPerClass(t *testing.T) {
+	t.Parallel()
+	const testPublicKey = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAlVKZRa1pkk88B1ydifsFNEv/pOf854egpFu1HHf1wr3YKqmLSG1p39YhNqGLQzIDit1jTLz3MYAOeWiFQSz7h5hvMNccq76zh3Hsg93LurcKA9EmYoj9VsqUetk0evXoqOSGKXPgZosbGT0t8AW2CC7s8FeSPz2tH9T7zjvKQvdyS0BFrVFo1EUBa1UEdMfYW0jLsvLOCYP911X1zTlewV/sTQnAtiTHCrd3jfH2of8PYtTOsmfqCDdL476yGMgeHJ+ZXA/IX2beSrHXU0gCNc/agD+ScCZgpRjfptSbRtBHqtmU4IyF0eqQXCCcrcutjzSHg+3ppmB9x/YvhJvmGQIDAQAB"
+	limits := RetryLimits{EncryptionKey: 2, TokenRefresh: 1}
+
+	tests := []struct {
+		name          string
+		errorCodes    []int // returned by the endpoint, in order, before it succeeds
+		expectError   bool
+		expectLogins  int
+		expectKeyGets int
+	}{
+		{
+			name:          "encryption retries don't use the token budget",
+			errorCodes:    []int{ErrorCodeEncryption, ErrorCodeEncryption, ErrorCodeTokenExpired},
+			expectLogins:  1,
+			expectKeyGets: 2,
+		},
+		{
+			name:          "token budget exhausted",
+			errorCodes:    []int{ErrorCodeTokenExpired, ErrorCodeEncryption, ErrorCodeTokenExpired},
+			expectError:   true,
+			expectLogins:  1,
+			expectKeyGets: 1,
+		},
+		{
+			name:          "encryption budget exhausted",
+			errorCodes:    []int{ErrorCodeEncryption, ErrorCodeTokenExpired, ErrorCodeEncryption, ErrorCodeEncryption},
+			expectError:   true,
+			expectLogins:  1,
+			expectKeyGets: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			endpointCalls, logins, keyGets := 0, 0, 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/appapi/v1/" + EndpointEncryptionKey:
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"data": map[string]any{"publicKey": testPublicKey, "versionPrefix": "v1:"},
+					})
+				case "/appapi/v1/" + EndpointLogin:
+					logins++
+					_ = json.NewEncoder(w).Encode(map[string]any{
+						"status": "OK",
+						"data": map[string]any{
+							"accessToken":             "test-access-token",
+							"accessTokenExpirationTs": time.Now().Unix() + 3600,
+						},
+					})
+				case "/" + EndpointCheckVersion:
+					keyGets++
+					responseJSON, _ := json.Marshal(map[string]any{"encKey": "testenckey123456", "signKey": "testsignkey12345"})
+					client := &Client{appCode: "202007270941270111799"}
+					encrypted, _ := EncryptAES128CBC(responseJSON, client.getDecryptionKeyFromAppCode(), IV)
+					_ = json.NewEncoder(w).Encode(map[string]any{"state": "S", "payload": encrypted})
+				default:
+					endpointCalls++
+					if endpointCalls <= len(tt.errorCodes) {
+						_ = json.NewEncoder(w).Encode(map[string]any{"state": "E", "errorCode": tt.errorCodes[endpointCalls-1]})
+
+						return
+					}
+					responseJSON, _ := json.Marshal(map[string]any{"resultCode": "200S00"})
+					encrypted, _ := EncryptAES128CBC(responseJSON, "testenckey123456", IV)
+					_ = json.NewEncoder(w).Encode(map[string]any{"state": "S", "payload": encrypted})
+				}
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test@example.com", "password", RegionMNAO, WithRetryLimits(limits))
+			require.NoError(t, err)
+			client.baseURL = server.URL + "/"
+			client.usherURL = server.URL + "/appapi/v1/"
+			client.Keys.EncKey = "testenckey123456"
+			client.Keys.SignKey = "testsignkey12345"
+			client.sleepFunc = func(ctx context.Context, d time.Duration) error { return nil }
+
+			_, err = client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, true, false)
+			if tt.expectError {
+				require.ErrorContains(t, err, "request exceeded max number of retries: ")
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectLogins, logins, "logins")
+			assert.Equal(t, tt.expectKeyGets, keyGets, "encryption key fetches")
+		})
+	}
+}
+
+// TestAPIRequest_TransientRetry tests that unavailable servers and dropped connections
+// are retried with backoff, while other HTTP errors fail fast.
+func TestAPIRequest_TransientRetry(t *testing.T) {
+	t.Parallel()
+	const (
+		dropConnection = -1
+		succeed        = 0
+	)
+
+	tests := []struct {
+		name           string
+		responses      []int // HTTP status per call; succeed after the list runs out
+		expectError    string
+		expectCalls    int
+		expectBackoffs []time.Duration
+	}{
+		{
+			name:           "503 twice then success",
+			responses:      []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectCalls:    3,
+			expectBackoffs: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:           "dropped connection then success",
+			responses:      []int{dropConnection, http.StatusGatewayTimeout},
+			expectCalls:    3,
+			expectBackoffs: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:        "transient budget exhausted",
+			responses:   []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			expectError: "request exceeded max number of retries: server unavailable (HTTP 502)",
+			expectCalls: MaxTransientRetries + 1,
+			expectBackoffs: []time.Duration{
+				time.Second, 2 * time.Second, 4 * time.Second,
+			},
+		},
+		{
+			name:        "4xx fails fast",
+			responses:   []int{http.StatusBadRequest},
+			expectError: "failed to parse response",
+			expectCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				status := succeed
+				if calls <= len(tt.responses) {
+					status = tt.responses[calls-1]
+				}
+
+				switch status {
+				case succeed:
+					responseJSON, _ := json.Marshal(map[string]any{"resultCode": "200S00"})
+					encrypted, _ := EncryptAES128CBC(responseJSON, "testenckey123456", IV)
+					w.Header().Set("Content-Type", "application/json")
+					_ = json.NewEncoder(w).Encode(map[string]any{"state": "S", "payload": encrypted})
+				case dropConnection:
+					hijacker, ok := w.(http.Hijacker)
+					require.True(t, ok)
+					conn, _, err := hijacker.Hijack()
+					require.NoError(t, err)
+					_ = conn.Close()
+				default:
+					http.Error(w, http.StatusText(status), status)
+				}
+			}))
+			defer server.Close()
+
+			client := setupTestClient(t)
+			client.baseURL = server.URL + "/"
+			var backoffs []time.Duration
+			client.sleepFunc = func(ctx context.Context, d time.Duration) error {
+				backoffs = append(backoffs, d)
+
+				return nil
+			}
+
+			_, err := client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, true, false)
+			if tt.expectError != "" {
+				require.ErrorContains(t, err, tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectCalls, calls, "requests")
+			assert.Equal(t, tt.expectBackoffs, backoffs, "backoffs")
+		})
+	}
+}
+
+// TestAPIRequest_RetriesExhaustedKeepsLastError tests that a request that runs out
+// of retries still reports the last failure, type and status included.
+func TestAPIRequest_RetriesExhaustedKeepsLastError(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := setupTestClient(t)
+	client.baseURL = server.URL + "/"
+	client.sleepFunc = func(ctx context.Context, d time.Duration) error { return nil }
+
+	_, err := client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, true, false)
+	require.EqualError(t, err, "request exceeded max number of retries: server unavailable (HTTP 503)")
+
+	var unavailable *ServerUnavailableError
+	require.ErrorAs(t, err, &unavailable)
+	assert.Equal(t, http.StatusServiceUnavailable, unavailable.StatusCode)
+}
+
+// TestControlRequest_NotResentAfterTransientFailure tests that a command whose
+// response was lost is not sent again, since the vehicle may already have acted
+// on it, while a command that never reached the server is retried.
+func TestControlRequest_NotResentAfterTransientFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		respond func(w http.ResponseWriter)
+	}{
+		{
+			name: "timeout",
+			respond: func(w http.ResponseWriter) {
+				time.Sleep(200 * time.Millisecond)
+			},
+		},
+		{
+			name: "dropped connection",
+			respond: func(w http.ResponseWriter) {
+				hijacker, ok := w.(http.Hijacker)
+				if !ok {
+					return
+				}
+				if conn, _, err := hijacker.Hijack(); err == nil {
+					_ = conn.Close()
+				}
+			},
+		},
+		{
+			name: "504",
+			respond: func(w http.ResponseWriter) {
+				http.Error(w, http.StatusText(http.StatusGatewayTimeout), http.StatusGatewayTimeout)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls.Add(1)
+				tt.respond(w)
+			}))
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+			client.httpClient.Timeout = 50 * time.Millisecond
+			client.sleepFunc = func(ctx context.Context, d time.Duration) error { return nil }
+
+			require.Error(t, client.EngineStart(context.Background(), "test-vin"))
+			assert.Equal(t, int32(1), calls.Load(), "the command must not be re-sent")
+		})
+	}
+
+	t.Run("connection refused", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		client := createTestClient(t, server.URL)
+		var backoffs int
+		client.sleepFunc = func(ctx context.Context, d time.Duration) error {
+			backoffs++
+
+			return nil
+		}
+
+		err := client.EngineStart(context.Background(), "test-vin")
+		require.ErrorContains(t, err, "request exceeded max number of retries: ")
+		assert.Equal(t, MaxTransientRetries, backoffs, "a request that was never sent is retried")
+	})
 }
 
 // TestAPIRequest_EngineStartLimitError tests the engine start limit error.
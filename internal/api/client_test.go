@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -91,6 +96,28 @@ func TestAPIRequest_Success(t *testing.T) {
 	assert.EqualValuesf(t, "Success", result["message"], "Expected message Success, got %v", result["message"])
 }
 
+// TestAPIRequest_UserAgent tests that WithUserAgent replaces the default User-Agent.
+func TestAPIRequest_UserAgent(t *testing.T) {
+	t.Parallel()
+	var gotUserAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"state": "E", "errorCode": 600003, "message": "quota exceeded"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient("test@example.com", "password", RegionMNAO, WithUserAgent("MyMazda-Android/9.1.0"))
+	require.NoError(t, err)
+	client.baseURL = server.URL + "/"
+	client.Keys.EncKey = "testenckey123456"
+	client.Keys.SignKey = "testsignkey12345"
+
+	_, err = client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, false, false)
+	require.Error(t, err)
+	assert.Equal(t, "MyMazda-Android/9.1.0", gotUserAgent)
+}
+
 // TestAPIRequest_EncryptionError tests handling of encryption error response.
 func TestAPIRequest_EncryptionError(t *testing.T) {
 	t.Parallel()
@@ -141,6 +168,133 @@ func TestAPIRequest_RequestInProgress(t *testing.T) {
 	assert.ErrorAs(t, err, new(*RequestInProgressError))
 }
 
+// TestAPIRequest_RateLimited tests handling of rate-limit responses.
+func TestAPIRequest_RateLimited(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		wantRetryAfter time.Duration
+	}{
+		{
+			name: "HTTP 429 with Retry-After",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Retry-After", "120")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRetryAfter: 120 * time.Second,
+		},
+		{
+			name: "HTTP 429 without Retry-After",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+			wantRetryAfter: DefaultRateLimitRetryAfter,
+		},
+		{
+			name: "error message",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"state":     "E",
+					"errorCode": 920000,
+					"message":   "Too Many Requests",
+				})
+			},
+			wantRetryAfter: DefaultRateLimitRetryAfter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			client := setupTestClient(t)
+			client.baseURL = server.URL + "/"
+
+			_, err := client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, false, false)
+			require.Error(t, err, "Expected error, got nil")
+
+			var rateErr *RateLimitedError
+			require.ErrorAs(t, err, &rateErr)
+			assert.Equal(t, tt.wantRetryAfter, rateErr.RetryAfter)
+			assert.EqualError(t, err, fmt.Sprintf("rate limited, try again in %d seconds", int(tt.wantRetryAfter.Seconds())))
+		})
+	}
+}
+
+// TestAPIRequest_ErrorDetails tests that the codes and message of an error response are kept on the APIError.
+func TestAPIRequest_ErrorDetails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		response  map[string]any
+		want      APIError
+		wantError string
+		wantCodes string
+	}{
+		{
+			name:      "error code and message",
+			response:  map[string]any{"state": "E", "errorCode": 600003, "message": "quota exceeded"},
+			want:      APIError{ErrorCode: 600003, Message: "Request failed: quota exceeded"},
+			wantError: "Request failed: quota exceeded",
+			wantCodes: "600003",
+		},
+		{
+			name:      "unknown extra code",
+			response:  map[string]any{"state": "E", "errorCode": 920000, "extraCode": "400S99", "message": "request rejected"},
+			want:      APIError{ErrorCode: 920000, ExtraCode: "400S99", Message: "Request failed: request rejected"},
+			wantError: "Request failed: request rejected",
+			wantCodes: "920000/400S99",
+		},
+		{
+			name:      "message in error field",
+			response:  map[string]any{"state": "E", "errorCode": 600003, "error": "quota exceeded"},
+			want:      APIError{ErrorCode: 600003, Message: "Request failed: quota exceeded"},
+			wantError: "Request failed: quota exceeded",
+			wantCodes: "600003",
+		},
+		{
+			name:      "no error code",
+			response:  map[string]any{"state": "E", "message": "bad request"},
+			want:      APIError{Message: "Request failed: bad request"},
+			wantError: "Request failed: bad request",
+		},
+		{
+			name:      "no message",
+			response:  map[string]any{"state": "E", "errorCode": 600003},
+			want:      APIError{ErrorCode: 600003, Message: "Request failed for an unknown reason"},
+			wantError: "Request failed for an unknown reason",
+			wantCodes: "600003",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(tt.response)
+			}))
+			defer server.Close()
+
+			client := setupTestClient(t)
+			client.baseURL = server.URL + "/"
+
+			_, err := client.APIRequest(context.Background(), "POST", "test/endpoint", nil, map[string]any{"test": "data"}, false, false)
+			require.Error(t, err, "Expected error, got nil")
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, tt.want, *apiErr)
+			assert.EqualError(t, err, tt.wantError)
+			assert.Equal(t, tt.wantCodes, apiErr.Codes())
+		})
+	}
+}
+
 // TestEncryptPayloadUsingKey tests payload encryption.
 func TestEncryptPayloadUsingKey(t *testing.T) {
 	t.Parallel()
@@ -329,6 +483,63 @@ func TestAPIRequest_GET_WithQuery(t *testing.T) {
 	assert.EqualValuesf(t, ResultCodeSuccess, result["resultCode"], "Expected resultCode 200S00, got %v", result["resultCode"])
 }
 
+// netTimeoutError is a net.Error that reports a timeout.
+type netTimeoutError struct{}
+
+func (netTimeoutError) Error() string   { return "i/o timeout" }
+func (netTimeoutError) Timeout() bool   { return true }
+func (netTimeoutError) Temporary() bool { return true }
+
+// TestClassifyRetryableError tests which errors are retried, and in which class.
+func TestClassifyRetryableError(t *testing.T) {
+	t.Parallel()
+	urlErr := func(err error) error {
+		return fmt.Errorf("failed to send request: %w", &url.Error{Op: "Post", URL: "https://example.com", Err: err})
+	}
+
+	dialErr := urlErr(&net.OpError{Op: "dial", Net: "tcp", Err: netTimeoutError{}})
+
+	tests := []struct {
+		name          string
+		err           error
+		wantClass     retryClass
+		wantRetryable bool
+		// wantCommand is whether a command (not idempotent) is retried too.
+		wantCommand bool
+	}{
+		{name: "encryption error", err: NewEncryptionError(), wantClass: retryClassEncryptionKey, wantRetryable: true, wantCommand: true},
+		{name: "token expired", err: NewTokenExpiredError(), wantClass: retryClassTokenRefresh, wantRetryable: true, wantCommand: true},
+		{name: "503", err: NewServerUnavailableError(http.StatusServiceUnavailable), wantClass: retryClassTransient, wantRetryable: true},
+		{name: "network timeout", err: urlErr(netTimeoutError{}), wantClass: retryClassTransient, wantRetryable: true},
+		{name: "connection reset", err: urlErr(syscall.ECONNRESET), wantClass: retryClassTransient, wantRetryable: true},
+		{name: "connection closed", err: urlErr(io.EOF), wantClass: retryClassTransient, wantRetryable: true},
+		{name: "connection refused", err: urlErr(syscall.ECONNREFUSED), wantClass: retryClassTransient, wantRetryable: true, wantCommand: true},
+		{name: "dial timeout", err: dialErr, wantClass: retryClassTransient, wantRetryable: true, wantCommand: true},
+		{name: "context deadline", err: urlErr(context.DeadlineExceeded)},
+		{name: "context canceled", err: urlErr(context.Canceled)},
+		{name: "invalid credential", err: NewInvalidCredentialError()},
+		{name: "rate limited", err: NewRateLimitedError(0)},
+		{name: "other API error", err: NewAPIError(0, "", "Request failed: bad request")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			class, retryable := classifyRetryableError(tt.err, true)
+			assert.Equal(t, tt.wantRetryable, retryable)
+			if tt.wantRetryable {
+				assert.Equal(t, tt.wantClass, class)
+			}
+
+			class, retryable = classifyRetryableError(tt.err, false)
+			assert.Equal(t, tt.wantCommand, retryable, "command")
+			if tt.wantCommand {
+				assert.Equal(t, tt.wantClass, class, "command")
+			}
+		})
+	}
+}
+
 // TestCalculateBackoff tests the backoff calculation.
 func TestCalculateBackoff(t *testing.T) {
 	t.Parallel()
@@ -31,9 +31,31 @@ func boolToInt(b bool) int {
 	return 0
 }
 
-// controlEndpoint sends a control command to the vehicle with optional additional parameters.
-// This is the generic method that all control endpoints use internally.
-func (c *Client) controlEndpoint(ctx context.Context, endpoint, actionDesc, internalVIN string, additionalParams map[string]any) error {
+// controlActions describes each control endpoint for error messages, e.g.
+// "failed to lock doors".
+var controlActions = map[string]string{
+	EndpointDoorLock:             "lock doors",
+	EndpointDoorUnlock:           "unlock doors",
+	EndpointLightOn:              "turn lights on",
+	EndpointLightOff:             "turn lights off",
+	EndpointEngineStart:          "start engine",
+	EndpointEngineStop:           "stop engine",
+	EndpointChargeStart:          "start charging",
+	EndpointChargeStop:           "stop charging",
+	EndpointHVACOn:               "turn HVAC on",
+	EndpointHVACOff:              "turn HVAC off",
+	EndpointRefreshVehicleStatus: "refresh vehicle status",
+	EndpointUpdateHVACSetting:    "set HVAC settings",
+}
+
+// SendControl sends a control command to the vehicle, with optional additional
+// body parameters. This is the generic method that all control endpoints use.
+func (c *Client) SendControl(ctx context.Context, endpoint, internalVIN string, additionalParams map[string]any) error {
+	actionDesc, ok := controlActions[endpoint]
+	if !ok {
+		actionDesc = "send " + endpoint
+	}
+
 	bodyParams := map[string]any{
 		"internaluserid": InternalUserID,
 		"internalvin":    internalVIN,
@@ -42,7 +64,7 @@ func (c *Client) controlEndpoint(ctx context.Context, endpoint, actionDesc, inte
 	// Merge additional parameters if provided
 	maps.Copy(bodyParams, additionalParams)
 
-	response, err := c.APIRequest(ctx, "POST", endpoint, nil, bodyParams, true, true)
+	response, err := c.controlRequest(ctx, endpoint, bodyParams)
 	if err != nil {
 		return err
 	}
@@ -55,70 +77,70 @@ func (c *Client) controlEndpoint(ctx context.Context, endpoint, actionDesc, inte
 	return checkResultCode(resultCode, actionDesc)
 }
 
-// executeControl sends a simple control command to the vehicle (no additional parameters).
-func (c *Client) executeControl(ctx context.Context, endpoint, actionDesc, internalVIN string) error {
-	return c.controlEndpoint(ctx, endpoint, actionDesc, internalVIN, nil)
-}
-
 // DoorLock locks the vehicle doors.
 func (c *Client) DoorLock(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointDoorLock, "lock doors", internalVIN)
+	return c.SendControl(ctx, EndpointDoorLock, internalVIN, nil)
 }
 
 // DoorUnlock unlocks the vehicle doors.
 func (c *Client) DoorUnlock(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointDoorUnlock, "unlock doors", internalVIN)
+	return c.SendControl(ctx, EndpointDoorUnlock, internalVIN, nil)
 }
 
 // LightsOn turns the vehicle hazard lights on.
 func (c *Client) LightsOn(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointLightOn, "turn lights on", internalVIN)
+	return c.SendControl(ctx, EndpointLightOn, internalVIN, nil)
 }
 
 // LightsOff turns the vehicle hazard lights off.
 func (c *Client) LightsOff(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointLightOff, "turn lights off", internalVIN)
+	return c.SendControl(ctx, EndpointLightOff, internalVIN, nil)
 }
 
 // EngineStart starts the vehicle engine remotely.
 func (c *Client) EngineStart(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointEngineStart, "start engine", internalVIN)
+	return c.SendControl(ctx, EndpointEngineStart, internalVIN, nil)
 }
 
 // EngineStop stops the vehicle engine remotely.
 func (c *Client) EngineStop(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointEngineStop, "stop engine", internalVIN)
+	return c.SendControl(ctx, EndpointEngineStop, internalVIN, nil)
 }
 
 // ChargeStart starts charging the vehicle (EV/PHEV only).
 func (c *Client) ChargeStart(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointChargeStart, "start charging", internalVIN)
+	return c.SendControl(ctx, EndpointChargeStart, internalVIN, nil)
 }
 
 // ChargeStop stops charging the vehicle (EV/PHEV only).
 func (c *Client) ChargeStop(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointChargeStop, "stop charging", internalVIN)
+	return c.SendControl(ctx, EndpointChargeStop, internalVIN, nil)
 }
 
 // HVACOn turns the vehicle HVAC system on.
 func (c *Client) HVACOn(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointHVACOn, "turn HVAC on", internalVIN)
+	return c.SendControl(ctx, EndpointHVACOn, internalVIN, nil)
 }
 
 // HVACOff turns the vehicle HVAC system off.
 func (c *Client) HVACOff(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointHVACOff, "turn HVAC off", internalVIN)
+	return c.SendControl(ctx, EndpointHVACOff, internalVIN, nil)
 }
 
 // RefreshVehicleStatus requests the vehicle to refresh its status (PHEV/EV only).
 func (c *Client) RefreshVehicleStatus(ctx context.Context, internalVIN string) error {
-	return c.executeControl(ctx, EndpointRefreshVehicleStatus, "refresh vehicle status", internalVIN)
+	return c.SendControl(ctx, EndpointRefreshVehicleStatus, internalVIN, nil)
 }
 
 // SetHVACSetting sets HVAC temperature and defroster settings.
 func (c *Client) SetHVACSetting(ctx context.Context, internalVIN string, temperature float64, tempUnit TemperatureUnit, frontDefroster, rearDefroster bool) error {
+	return c.SendControl(ctx, EndpointUpdateHVACSetting, internalVIN, HVACSettingParams(temperature, tempUnit, frontDefroster, rearDefroster))
+}
+
+// HVACSettingParams returns the body parameters of EndpointUpdateHVACSetting.
+func HVACSettingParams(temperature float64, tempUnit TemperatureUnit, frontDefroster, rearDefroster bool) map[string]any {
 	// The API expects HVAC settings to be nested under "hvacsettings"
-	additionalParams := map[string]any{
+	return map[string]any{
 		"hvacsettings": map[string]any{
 			"Temperature":     temperature,
 			"TemperatureType": int(tempUnit),
@@ -126,6 +148,4 @@ func (c *Client) SetHVACSetting(ctx context.Context, internalVIN string, tempera
 			"RearDefogger":    boolToInt(rearDefroster),
 		},
 	}
-
-	return c.controlEndpoint(ctx, EndpointUpdateHVACSetting, "set HVAC settings", internalVIN, additionalParams)
 }
@@ -1,6 +1,12 @@
 package api
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // API error codes returned by the server.
 const (
@@ -24,20 +30,50 @@ const (
 	ExtraCodeEngineStartLimit = "400S11"
 )
 
-// APIError represents a general API error.
+// DefaultRateLimitRetryAfter is the cooldown suggested when a rate-limit response
+// doesn't say how long to wait.
+const DefaultRateLimitRetryAfter = 60 * time.Second
+
+// ErrBatteryHealthUnavailable is returned when the vehicle does not report battery state of health.
+var ErrBatteryHealthUnavailable = errors.New("health data not reported by this vehicle")
+
+// ErrAuxBatteryUnavailable is returned when the vehicle does not report its 12V battery voltage.
+var ErrAuxBatteryUnavailable = errors.New("12V battery voltage not reported by this vehicle")
+
+// APIError represents a general API error. ErrorCode and ExtraCode are the codes
+// from the server's error response, and are zero for errors raised by the client itself.
 type APIError struct {
-	Message string
+	ErrorCode int
+	ExtraCode string
+	Message   string
 }
 
-// NewAPIError creates a new API error.
-func NewAPIError(message string) *APIError {
-	return &APIError{Message: message}
+// NewAPIError creates a new API error. Pass 0 and "" as the codes for errors
+// that didn't come from an error response.
+func NewAPIError(errorCode int, extraCode, message string) *APIError {
+	return &APIError{ErrorCode: errorCode, ExtraCode: extraCode, Message: message}
 }
 
+// Error returns the message.
 func (e *APIError) Error() string {
 	return e.Message
 }
 
+// Codes returns the server's codes for display, e.g. "600003" or
+// "920000/400S99", or "" for errors raised by the client itself.
+func (e *APIError) Codes() string {
+	if e.ErrorCode == 0 {
+		return ""
+	}
+
+	code := strconv.Itoa(e.ErrorCode)
+	if e.ExtraCode != "" {
+		code += "/" + e.ExtraCode
+	}
+
+	return code
+}
+
 // EncryptionError represents an encryption error (error code 600001).
 type EncryptionError struct {
 	APIError
@@ -60,22 +96,84 @@ type EngineStartLimitError struct {
 
 // NewEncryptionError creates a new encryption error.
 func NewEncryptionError() *EncryptionError {
-	return &EncryptionError{APIError{Message: "Server rejected encrypted request"}}
+	return &EncryptionError{APIError{ErrorCode: ErrorCodeEncryption, Message: "Server rejected encrypted request"}}
 }
 
 // NewTokenExpiredError creates a new token expired error.
 func NewTokenExpiredError() *TokenExpiredError {
-	return &TokenExpiredError{APIError{Message: "Token expired"}}
+	return &TokenExpiredError{APIError{ErrorCode: ErrorCodeTokenExpired, Message: "Token expired"}}
 }
 
 // NewRequestInProgressError creates a new request in progress error.
 func NewRequestInProgressError() *RequestInProgressError {
-	return &RequestInProgressError{APIError{Message: "Request already in progress, please wait and try again"}}
+	return &RequestInProgressError{APIError{ErrorCode: ErrorCodeRequestIssue, ExtraCode: ExtraCodeRequestInProgress, Message: "Request already in progress, please wait and try again"}}
 }
 
 // NewEngineStartLimitError creates a new engine start limit error.
 func NewEngineStartLimitError() *EngineStartLimitError {
-	return &EngineStartLimitError{APIError{Message: "The engine can only be remotely started 2 consecutive times. Please drive the vehicle to reset the counter."}}
+	return &EngineStartLimitError{APIError{ErrorCode: ErrorCodeRequestIssue, ExtraCode: ExtraCodeEngineStartLimit, Message: "The engine can only be remotely started 2 consecutive times. Please drive the vehicle to reset the counter."}}
+}
+
+// ServerUnavailableError represents a gateway or availability failure (HTTP 502,
+// 503, or 504) that is usually transient and worth retrying.
+type ServerUnavailableError struct {
+	APIError
+
+	StatusCode int
+}
+
+// NewServerUnavailableError creates a new server unavailable error for statusCode.
+func NewServerUnavailableError(statusCode int) *ServerUnavailableError {
+	return &ServerUnavailableError{
+		APIError:   APIError{Message: fmt.Sprintf("server unavailable (HTTP %d)", statusCode)},
+		StatusCode: statusCode,
+	}
+}
+
+// InvalidCredentialError represents a login rejected because the email or password is wrong.
+// It is not retryable: repeating the login with the same credentials will fail again.
+type InvalidCredentialError struct {
+	APIError
+}
+
+// NewInvalidCredentialError creates a new invalid credential error.
+func NewInvalidCredentialError() *InvalidCredentialError {
+	return &InvalidCredentialError{APIError{Message: "incorrect email or password"}}
+}
+
+// IsInvalidCredential reports whether err is, or wraps, an InvalidCredentialError.
+func IsInvalidCredential(err error) bool {
+	var credErr *InvalidCredentialError
+
+	return errors.As(err, &credErr)
+}
+
+// RateLimitedError represents a request rejected because the client is polling too aggressively.
+type RateLimitedError struct {
+	APIError
+
+	// RetryAfter is how long to wait before trying again.
+	RetryAfter time.Duration
+}
+
+// NewRateLimitedError creates a new rate limited error. A non-positive retryAfter
+// falls back to DefaultRateLimitRetryAfter.
+func NewRateLimitedError(retryAfter time.Duration) *RateLimitedError {
+	if retryAfter <= 0 {
+		retryAfter = DefaultRateLimitRetryAfter
+	}
+
+	return &RateLimitedError{
+		APIError:   APIError{Message: fmt.Sprintf("rate limited, try again in %d seconds", int(retryAfter.Seconds()))},
+		RetryAfter: retryAfter,
+	}
+}
+
+// isRateLimitMessage reports whether an API error message describes rate limiting.
+func isRateLimitMessage(message string) bool {
+	message = strings.ToLower(message)
+
+	return strings.Contains(message, "too many requests") || strings.Contains(message, "rate limit")
 }
 
 // ResultCodeError represents an error due to an unsuccessful result code.
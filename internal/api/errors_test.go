@@ -2,6 +2,7 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -92,3 +93,51 @@ func TestNewResultCodeError(t *testing.T) {
 	expectedMsg := "failed to unlock doors: result code 400E01"
 	assert.Equal(t, expectedMsg, err.Error())
 }
+
+// TestAPIError_Codes tests that the typed errors carry the codes they stand for.
+func TestAPIError_Codes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		err           APIError
+		wantErrorCode int
+		wantExtraCode string
+	}{
+		{name: "encryption", err: NewEncryptionError().APIError, wantErrorCode: ErrorCodeEncryption},
+		{name: "token expired", err: NewTokenExpiredError().APIError, wantErrorCode: ErrorCodeTokenExpired},
+		{name: "request in progress", err: NewRequestInProgressError().APIError, wantErrorCode: ErrorCodeRequestIssue, wantExtraCode: ExtraCodeRequestInProgress},
+		{name: "engine start limit", err: NewEngineStartLimitError().APIError, wantErrorCode: ErrorCodeRequestIssue, wantExtraCode: ExtraCodeEngineStartLimit},
+		{name: "client error", err: *NewAPIError(0, "", "Missing encryption key")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantErrorCode, tt.err.ErrorCode)
+			assert.Equal(t, tt.wantExtraCode, tt.err.ExtraCode)
+		})
+	}
+}
+
+// TestIsInvalidCredential tests detection of invalid credential errors, including wrapped ones.
+func TestIsInvalidCredential(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "invalid credential error", err: NewInvalidCredentialError(), want: true},
+		{name: "wrapped invalid credential error", err: fmt.Errorf("failed to login: %w", NewInvalidCredentialError()), want: true},
+		{name: "other API error", err: NewTokenExpiredError(), want: false},
+		{name: "plain error", err: errors.New("incorrect email or password"), want: false},
+		{name: "nil error", err: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsInvalidCredential(tt.err))
+		})
+	}
+}
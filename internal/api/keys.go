@@ -1,7 +1,23 @@
 package api
 
+import "time"
+
+// KeysTTL is how long keys fetched from the checkVersion endpoint are used before
+// the client fetches them again.
+const KeysTTL = 12 * time.Hour
+
 // Keys holds cryptographic material used by the client.
 type Keys struct {
 	EncKey  string
 	SignKey string
+
+	// FetchedAt is when the keys were fetched from the server. It is zero for keys
+	// of unknown age, e.g. set from a cache that didn't record it.
+	FetchedAt time.Time
+}
+
+// Expired reports whether the keys were fetched more than KeysTTL before now.
+// Keys of unknown age never expire; they're replaced after an encryption error.
+func (k Keys) Expired(now time.Time) bool {
+	return !k.FetchedAt.IsZero() && now.Sub(k.FetchedAt) > KeysTTL
 }
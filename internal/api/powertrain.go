@@ -0,0 +1,88 @@
+package api
+
+import (
+	"slices"
+	"strings"
+)
+
+// Powertrain classifies a vehicle's drivetrain.
+type Powertrain string
+
+const (
+	// PowertrainUnknown means the model couldn't be classified.
+	PowertrainUnknown Powertrain = "unknown"
+	// PowertrainICE is a conventional internal combustion (including non-plug-in hybrid) vehicle.
+	PowertrainICE Powertrain = "ICE"
+	// PowertrainPHEV is a plug-in hybrid electric vehicle.
+	PowertrainPHEV Powertrain = "PHEV"
+	// PowertrainEV is a battery electric vehicle.
+	PowertrainEV Powertrain = "EV"
+)
+
+// ClassifyPowertrain infers the powertrain from the model and carline names
+// (e.g. "CX-90 PHEV", "MX-30 EV"). Names without a powertrain marker, such as
+// "CX-5" or an "MX-30" that may be electric, return PowertrainUnknown, so that
+// capability checks don't lock out electrified vehicles named without a suffix;
+// VehicleDetails.Powertrain falls back to the model code for those.
+func ClassifyPowertrain(modelName, carlineName string) Powertrain {
+	name := strings.ToUpper(strings.TrimSpace(modelName + " " + carlineName))
+	if name == "" {
+		return PowertrainUnknown
+	}
+
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == ' ' || r == '-' || r == '(' || r == ')'
+	})
+
+	switch {
+	case slices.Contains(words, "PHEV") || strings.Contains(name, "PLUG-IN") || strings.Contains(name, "R-EV"):
+		return PowertrainPHEV
+	case slices.Contains(words, "EV") || slices.Contains(words, "ELECTRIC"):
+		return PowertrainEV
+	case slices.Contains(words, "HYBRID"):
+		return PowertrainICE
+	default:
+		return PowertrainUnknown
+	}
+}
+
+// IsElectrified reports whether the vehicle has a high-voltage battery (PHEV or EV).
+func (p Powertrain) IsElectrified() bool {
+	return p == PowertrainPHEV || p == PowertrainEV
+}
+
+// SupportsRemoteRefresh reports whether the vehicle can be asked to push fresh status.
+// Unknown powertrains are allowed so that unrecognized models aren't locked out.
+func (p Powertrain) SupportsRemoteRefresh() bool {
+	return p != PowertrainICE
+}
+
+// SupportsRemoteEngineStart reports whether the vehicle has an engine that can be
+// started remotely. Unknown powertrains are allowed, as with SupportsRemoteRefresh.
+func (p Powertrain) SupportsRemoteEngineStart() bool {
+	return p != PowertrainEV
+}
+
+// powertrainFromModelCode returns PowertrainICE for the model codes of
+// combustion-only models, and PowertrainUnknown for any other.
+func powertrainFromModelCode(modelCode string) Powertrain {
+	code := strings.ToUpper(strings.TrimSpace(modelCode))
+	for _, prefix := range combustionOnlyModels {
+		if strings.HasPrefix(code, prefix) {
+			return PowertrainICE
+		}
+	}
+
+	return PowertrainUnknown
+}
+
+// Powertrain classifies the vehicle's drivetrain from its model details: from
+// the powertrain named in the model or carline, or otherwise from the model code
+// of a combustion-only model such as the CX-5.
+func (d VehicleDetails) Powertrain() Powertrain {
+	if powertrain := ClassifyPowertrain(d.ModelName, d.CarlineName); powertrain != PowertrainUnknown {
+		return powertrain
+	}
+
+	return powertrainFromModelCode(d.ModelCode)
+}
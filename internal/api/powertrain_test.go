@@ -0,0 +1,89 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPowertrain(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		modelName   string
+		carlineName string
+		want        Powertrain
+	}{
+		{"plug-in hybrid", "CX-90 PHEV", "", PowertrainPHEV},
+		{"plug-in hybrid lowercase", "cx-70 phev", "", PowertrainPHEV},
+		{"plug-in hybrid from carline", "CX-90", "CX-90 PHEV Premium Plus", PowertrainPHEV},
+		{"range extender", "MX-30 R-EV", "", PowertrainPHEV},
+		{"battery electric", "MX-30 EV", "", PowertrainEV},
+		{"electric carline", "MX-30", "MX-30 Electric", PowertrainEV},
+		{"non-plug-in hybrid", "CX-50 Hybrid", "", PowertrainICE},
+		{"no powertrain marker", "CX-5", "CX-5 2.5 S Premium", PowertrainUnknown},
+		{"electric model named without a suffix", "MX-30", "", PowertrainUnknown},
+		{"EV inside another word is not electric", "CX-90 Premium SEVEN", "", PowertrainUnknown},
+		{"no model data", "", "", PowertrainUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ClassifyPowertrain(tt.modelName, tt.carlineName))
+		})
+	}
+}
+
+// TestVehicleDetails_Powertrain tests that combustion-only models are recognized
+// by model code when their names don't name a powertrain.
+func TestVehicleDetails_Powertrain(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		modelName string
+		modelCode string
+		want      Powertrain
+	}{
+		{"CX-5", "CX-5", "KF2P", PowertrainICE},
+		{"CX-30", "CX-30", "DM8P", PowertrainICE},
+		{"Mazda3 lowercase code", "MAZDA3", "bpep", PowertrainICE},
+		{"name wins over code", "CX-50 Hybrid", "VA", PowertrainICE},
+		{"PHEV platform without a marker", "CX-90", "KKEH", PowertrainUnknown},
+		{"MX-30 without a marker", "MX-30", "DR4B", PowertrainUnknown},
+		{"PHEV named", "CX-90 PHEV", "KKEH", PowertrainPHEV},
+		{"no model code", "CX-5", "", PowertrainUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			details := VehicleDetails{OtherInformationParsed: OtherInformationParsed{ModelName: tt.modelName, ModelCode: tt.modelCode}}
+			assert.Equal(t, tt.want, details.Powertrain())
+		})
+	}
+}
+
+func TestPowertrain_Capabilities(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		powertrain      Powertrain
+		electrified     bool
+		supportsRefresh bool
+		supportsStart   bool
+	}{
+		{PowertrainICE, false, false, true},
+		{PowertrainPHEV, true, true, true},
+		{PowertrainEV, true, true, false},
+		{PowertrainUnknown, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.powertrain), func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.electrified, tt.powertrain.IsElectrified())
+			assert.Equal(t, tt.supportsRefresh, tt.powertrain.SupportsRemoteRefresh())
+			assert.Equal(t, tt.supportsStart, tt.powertrain.SupportsRemoteEngineStart())
+		})
+	}
+}
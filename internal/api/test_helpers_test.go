@@ -159,3 +159,13 @@ func createControlTestServer(t *testing.T, expectedPath string) *httptest.Server
 		WithBodyValidation(),
 	)
 }
+
+// floatPtr returns a pointer to v, for optional fields in expected values.
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+// boolPtr returns a pointer to v, for optional fields in expected values.
+func boolPtr(v bool) *bool {
+	return &v
+}
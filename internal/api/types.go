@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -36,6 +37,40 @@ func (v InternalVIN) String() string {
 	return string(v)
 }
 
+// flexFloat is a float64 that also accepts numeric strings, which some regions
+// send for fields that are numbers elsewhere (e.g. "SmaphSOC": "66").
+type flexFloat float64
+
+// UnmarshalJSON handles unmarshaling from either a number or a numeric string.
+// An empty string is treated as zero, like an absent field.
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	// Try number first
+	var n float64
+	if err := json.Unmarshal(data, &n); err == nil {
+		*f = flexFloat(n)
+
+		return nil
+	}
+
+	// Try numeric string
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			*f = 0
+
+			return nil
+		}
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			*f = flexFloat(n)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expected number or numeric string, got: %s", string(data))
+}
+
 // VecBaseInfosResponse represents the response from GetVecBaseInfos API.
 type VecBaseInfosResponse struct {
 	ResultCode   string        `json:"resultCode"`
@@ -44,10 +79,10 @@ type VecBaseInfosResponse struct {
 
 // VecBaseInfo represents a single vehicle's base information.
 type VecBaseInfo struct {
-	VIN          string  `json:"vin"`
-	Nickname     string  `json:"nickname"`
-	EconnectType int     `json:"econnectType"`
-	Vehicle      Vehicle `json:"Vehicle"`
+	VIN          string       `json:"vin"`
+	Nickname     string       `json:"nickname"`
+	EconnectType EconnectType `json:"econnectType"`
+	Vehicle      Vehicle      `json:"Vehicle"`
 }
 
 // UnmarshalJSON implements custom unmarshaling to parse the nested vehicleInformation JSON string.
@@ -102,6 +137,36 @@ type OtherInformationParsed struct {
 	InteriorColorName string `json:"interiorColorName"`
 }
 
+// VehicleDetails contains the identification details of a vehicle.
+type VehicleDetails struct {
+	VIN          string
+	Nickname     string
+	EconnectType EconnectType
+	OtherInformationParsed
+}
+
+// VehicleInfo identifies a vehicle on the account: the internal VIN that status
+// and remote command calls take, and the details of its model.
+type VehicleInfo struct {
+	InternalVIN InternalVIN
+	VIN         string
+	Nickname    string
+	ModelName   string
+	ModelYear   string
+
+	Carline       string
+	CarlineCode   string
+	ModelCode     string
+	ExteriorColor string
+	InteriorColor string
+	Transmission  string
+
+	// Powertrain is inferred from the model details.
+	Powertrain Powertrain
+	// EconnectType is the vehicle's connectivity tier.
+	EconnectType EconnectType
+}
+
 // VehicleStatusResponse represents the response from GetVehicleStatus API.
 type VehicleStatusResponse struct {
 	ResultCode  string       `json:"resultCode"`
@@ -111,36 +176,64 @@ type VehicleStatusResponse struct {
 
 // RemoteInfo contains remote vehicle information.
 type RemoteInfo struct {
+	OccurrenceDate   string           `json:"OccurrenceDate"`
 	ResidualFuel     ResidualFuel     `json:"ResidualFuel"`
 	DriveInformation DriveInformation `json:"DriveInformation"`
 	TPMSInformation  TPMSInformation  `json:"TPMSInformation"`
+
+	// BatteryStatus is the 12V auxiliary battery reading. Only some vehicles
+	// report it; nil otherwise.
+	BatteryStatus *AuxBatteryStatus `json:"BatteryStatus,omitempty"`
+}
+
+// AuxBatteryStatus contains the 12V auxiliary battery reading.
+type AuxBatteryStatus struct {
+	BatteryVoltage float64 `json:"BatteryVoltage"` // in volts
 }
 
 // ResidualFuel contains fuel information.
 type ResidualFuel struct {
 	FuelSegmentDActl  float64 `json:"FuelSegementDActl"`
 	RemDrvDistDActlKm float64 `json:"RemDrvDistDActlKm"`
+
+	// LowFuelWarning is assumed to be the state of the vehicle's own low-fuel
+	// warning lamp. The field name is unverified: no recorded response includes
+	// it. nil when not reported.
+	LowFuelWarning *float64 `json:"LowFuelWarning,omitempty"`
 }
 
-// DriveInformation contains drive-related information.
+// DriveInformation contains drive-related information. The Drv1 trip fields
+// are only included by some vehicles, and report -1 when unavailable.
 type DriveInformation struct {
 	OdoDispValue float64 `json:"OdoDispValue"`
+
+	Drv1Distnc   *float64 `json:"Drv1Distnc,omitempty"`   // trip distance in km
+	Drv1DrvTm    *float64 `json:"Drv1DrvTm,omitempty"`    // trip driving time in minutes
+	Drv1AmntFuel *float64 `json:"Drv1AmntFuel,omitempty"` // fuel used on the trip in liters
+	Drv1AvlFuelG *float64 `json:"Drv1AvlFuelG,omitempty"` // average fuel economy, in the vehicle's display units
+	Drv1AvlFuelE *float64 `json:"Drv1AvlFuelE,omitempty"` // average energy economy, in the vehicle's display units
 }
 
-// TPMSInformation contains tire pressure information.
+// TPMSInformation contains tire pressure information. Tire temperatures are
+// only included by some vehicles.
 type TPMSInformation struct {
-	FLTPrsDispPsi float64 `json:"FLTPrsDispPsi"`
-	FRTPrsDispPsi float64 `json:"FRTPrsDispPsi"`
-	RLTPrsDispPsi float64 `json:"RLTPrsDispPsi"`
-	RRTPrsDispPsi float64 `json:"RRTPrsDispPsi"`
+	FLTPrsDispPsi   float64  `json:"FLTPrsDispPsi"`
+	FRTPrsDispPsi   float64  `json:"FRTPrsDispPsi"`
+	RLTPrsDispPsi   float64  `json:"RLTPrsDispPsi"`
+	RRTPrsDispPsi   float64  `json:"RRTPrsDispPsi"`
+	FLTTempDispDegC *float64 `json:"FLTTempDispDegC,omitempty"`
+	FRTTempDispDegC *float64 `json:"FRTTempDispDegC,omitempty"`
+	RLTTempDispDegC *float64 `json:"RLTTempDispDegC,omitempty"`
+	RRTTempDispDegC *float64 `json:"RRTTempDispDegC,omitempty"`
 }
 
 // AlertInfo contains alert and position information.
 type AlertInfo struct {
-	PositionInfo PositionInfo `json:"PositionInfo"`
-	Door         DoorInfo     `json:"Door"`
-	Pw           WindowInfo   `json:"Pw"`
-	HazardLamp   HazardLamp   `json:"HazardLamp"`
+	OccurrenceDate string       `json:"OccurrenceDate"`
+	PositionInfo   PositionInfo `json:"PositionInfo"`
+	Door           DoorInfo     `json:"Door"`
+	Pw             WindowInfo   `json:"Pw"`
+	HazardLamp     HazardLamp   `json:"HazardLamp"`
 }
 
 // PositionInfo contains GPS location information.
@@ -148,6 +241,11 @@ type PositionInfo struct {
 	Latitude            float64 `json:"Latitude"`
 	Longitude           float64 `json:"Longitude"`
 	AcquisitionDatetime string  `json:"AcquisitionDatetime"`
+
+	// Heading (degrees clockwise from north) and SpeedKmh are only reported by
+	// some vehicles. Nil when absent.
+	Heading  *float64 `json:"Heading,omitempty"`
+	SpeedKmh *float64 `json:"Speed,omitempty"`
 }
 
 // DoorInfo contains door lock status.
@@ -165,6 +263,57 @@ type DoorInfo struct {
 	FuelLidOpenStatus float64 `json:"FuelLidOpenStatus"`
 }
 
+// UnmarshalJSON accepts door and lock statuses sent as numeric strings.
+func (d *DoorInfo) UnmarshalJSON(data []byte) error {
+	// Use an alias to avoid infinite recursion; the flexFloat fields shadow the
+	// alias's float64 fields of the same name.
+	type DoorInfoAlias DoorInfo
+	aux := struct {
+		*DoorInfoAlias
+		DrStatDrv         flexFloat `json:"DrStatDrv"`
+		DrStatPsngr       flexFloat `json:"DrStatPsngr"`
+		DrStatRl          flexFloat `json:"DrStatRl"`
+		DrStatRr          flexFloat `json:"DrStatRr"`
+		DrStatTrnkLg      flexFloat `json:"DrStatTrnkLg"`
+		DrStatHood        flexFloat `json:"DrStatHood"`
+		LockLinkSwDrv     flexFloat `json:"LockLinkSwDrv"`
+		LockLinkSwPsngr   flexFloat `json:"LockLinkSwPsngr"`
+		LockLinkSwRl      flexFloat `json:"LockLinkSwRl"`
+		LockLinkSwRr      flexFloat `json:"LockLinkSwRr"`
+		FuelLidOpenStatus flexFloat `json:"FuelLidOpenStatus"`
+	}{
+		DoorInfoAlias:     (*DoorInfoAlias)(d),
+		DrStatDrv:         flexFloat(d.DrStatDrv),
+		DrStatPsngr:       flexFloat(d.DrStatPsngr),
+		DrStatRl:          flexFloat(d.DrStatRl),
+		DrStatRr:          flexFloat(d.DrStatRr),
+		DrStatTrnkLg:      flexFloat(d.DrStatTrnkLg),
+		DrStatHood:        flexFloat(d.DrStatHood),
+		LockLinkSwDrv:     flexFloat(d.LockLinkSwDrv),
+		LockLinkSwPsngr:   flexFloat(d.LockLinkSwPsngr),
+		LockLinkSwRl:      flexFloat(d.LockLinkSwRl),
+		LockLinkSwRr:      flexFloat(d.LockLinkSwRr),
+		FuelLidOpenStatus: flexFloat(d.FuelLidOpenStatus),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	d.DrStatDrv = float64(aux.DrStatDrv)
+	d.DrStatPsngr = float64(aux.DrStatPsngr)
+	d.DrStatRl = float64(aux.DrStatRl)
+	d.DrStatRr = float64(aux.DrStatRr)
+	d.DrStatTrnkLg = float64(aux.DrStatTrnkLg)
+	d.DrStatHood = float64(aux.DrStatHood)
+	d.LockLinkSwDrv = float64(aux.LockLinkSwDrv)
+	d.LockLinkSwPsngr = float64(aux.LockLinkSwPsngr)
+	d.LockLinkSwRl = float64(aux.LockLinkSwRl)
+	d.LockLinkSwRr = float64(aux.LockLinkSwRr)
+	d.FuelLidOpenStatus = float64(aux.FuelLidOpenStatus)
+
+	return nil
+}
+
 // WindowInfo contains window position information.
 type WindowInfo struct {
 	PwPosDrv   float64 `json:"PwPosDrv"`
@@ -211,15 +360,102 @@ type ChargeInfo struct {
 	MaxChargeMinuteQBC      float64 `json:"MaxChargeMinuteQBC"`
 	BatteryHeaterON         float64 `json:"BatteryHeaterON"`
 	CstmzStatBatHeatAutoSW  float64 `json:"CstmzStatBatHeatAutoSW"`
+
+	// ChargeSchedule holds the configured charging windows (absent when none are set).
+	ChargeSchedule []ChargeScheduleSetting `json:"ChargeSchedule,omitempty"`
+
+	// BatterySOH is the vehicle's estimated high-voltage battery state of health in
+	// percent. Nil when the vehicle does not report it.
+	BatterySOH *float64 `json:"BatterySOH,omitempty"`
+
+	// LastChargeEndDate is when the last charging session ended, in
+	// YYYYMMDDHHmmss format. Empty when the vehicle does not report it.
+	LastChargeEndDate string `json:"LastChargeEndDate,omitempty"`
+
+	// ChargeType is the kind of charger in use (see ChargeTypeAC and
+	// ChargeTypeDC), and ChargePowerKw the current charging power in kW. Only some
+	// vehicles report them; nil otherwise.
+	ChargeType    *float64 `json:"ChargeType,omitempty"`
+	ChargePowerKw *float64 `json:"ChargePowerKw,omitempty"`
+}
+
+// ChargeRate describes the charge in progress.
+type ChargeRate struct {
+	Type    string  // "AC" or "DC"; empty when not reported
+	PowerKW float64 // zero when not reported
+}
+
+// GetChargeRate returns the type and power of the charge in progress, as far as
+// the vehicle reports them.
+func (c ChargeInfo) GetChargeRate() ChargeRate {
+	var rate ChargeRate
+	if c.ChargeType != nil {
+		switch int(*c.ChargeType) {
+		case ChargeTypeAC:
+			rate.Type = "AC"
+		case ChargeTypeDC:
+			rate.Type = "DC"
+		}
+	}
+	if c.ChargePowerKw != nil && *c.ChargePowerKw > 0 {
+		rate.PowerKW = *c.ChargePowerKw
+	}
+
+	return rate
+}
+
+// UnmarshalJSON accepts the battery level, range, and plug/charge statuses sent
+// as numeric strings.
+func (c *ChargeInfo) UnmarshalJSON(data []byte) error {
+	// Use an alias to avoid infinite recursion; the flexFloat fields shadow the
+	// alias's float64 fields of the same name.
+	type ChargeInfoAlias ChargeInfo
+	aux := struct {
+		*ChargeInfoAlias
+		SmaphSOC                flexFloat `json:"SmaphSOC"`
+		SmaphRemDrvDistKm       flexFloat `json:"SmaphRemDrvDistKm"`
+		ChargerConnectorFitting flexFloat `json:"ChargerConnectorFitting"`
+		ChargeStatusSub         flexFloat `json:"ChargeStatusSub"`
+	}{
+		ChargeInfoAlias:         (*ChargeInfoAlias)(c),
+		SmaphSOC:                flexFloat(c.SmaphSOC),
+		SmaphRemDrvDistKm:       flexFloat(c.SmaphRemDrvDistKm),
+		ChargerConnectorFitting: flexFloat(c.ChargerConnectorFitting),
+		ChargeStatusSub:         flexFloat(c.ChargeStatusSub),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	c.SmaphSOC = float64(aux.SmaphSOC)
+	c.SmaphRemDrvDistKm = float64(aux.SmaphRemDrvDistKm)
+	c.ChargerConnectorFitting = float64(aux.ChargerConnectorFitting)
+	c.ChargeStatusSub = float64(aux.ChargeStatusSub)
+
+	return nil
+}
+
+// ChargeScheduleSetting contains a single scheduled charging window.
+// Times are reported as HHmm strings (e.g. "2300").
+type ChargeScheduleSetting struct {
+	StartTime string  `json:"StartTime"`
+	EndTime   string  `json:"EndTime"`
+	Enabled   float64 `json:"Enabled"`
 }
 
 // RemoteHvacInfo contains HVAC system information.
 type RemoteHvacInfo struct {
-	HVAC           float64 `json:"HVAC"`
-	FrontDefroster float64 `json:"FrontDefroster"`
-	RearDefogger   float64 `json:"RearDefogger"`
-	InCarTeDC      float64 `json:"InCarTeDC"`
-	TargetTemp     float64 `json:"TargetTemp"`
+	HVAC       float64 `json:"HVAC"`
+	InCarTeDC  float64 `json:"InCarTeDC"`
+	TargetTemp float64 `json:"TargetTemp"`
+
+	// Vehicles without remote defrosters omit these fields. Nil when absent.
+	FrontDefroster *float64 `json:"FrontDefroster,omitempty"`
+	RearDefogger   *float64 `json:"RearDefogger,omitempty"`
+
+	// RemainingMinutes is how long remote climate keeps running before the vehicle
+	// switches it off automatically. Only some vehicles report it; nil otherwise.
+	RemainingMinutes *float64 `json:"RemainingMinutes,omitempty"`
 }
 
 // Helper methods for extracting data
@@ -235,19 +471,64 @@ func (r *VecBaseInfosResponse) GetInternalVIN() (string, error) {
 
 // GetVehicleInfo extracts vehicle identification info from the response.
 func (r *VecBaseInfosResponse) GetVehicleInfo() (vin, nickname, modelName, modelYear string, err error) {
+	details, err := r.GetVehicleDetails()
+	if err != nil {
+		return
+	}
+
+	return details.VIN, details.Nickname, details.ModelName, details.ModelYear, nil
+}
+
+// GetVehicleDetails extracts the full identification details of the first vehicle,
+// including carline, model code, colors, and transmission.
+func (r *VecBaseInfosResponse) GetVehicleDetails() (VehicleDetails, error) {
 	if len(r.VecBaseInfos) == 0 {
-		err = errors.New("no vehicles found")
+		return VehicleDetails{}, errors.New("no vehicles found")
+	}
 
-		return
+	return r.VecBaseInfos[0].Details(), nil
+}
+
+// GetPrimaryVehicle returns the first vehicle in the response, which is the one
+// commands act on.
+func (r *VecBaseInfosResponse) GetPrimaryVehicle() (VehicleInfo, error) {
+	if len(r.VecBaseInfos) == 0 {
+		return VehicleInfo{}, errors.New("no vehicles found")
 	}
-	info := r.VecBaseInfos[0]
-	vin = info.VIN
-	nickname = info.Nickname
-	// Use the parsed vehicleInformation (JSON string) which has the actual model data
-	modelName = info.Vehicle.VehicleInformation.OtherInformation.ModelName
-	modelYear = info.Vehicle.VehicleInformation.OtherInformation.ModelYear
 
-	return
+	return r.VecBaseInfos[0].Info(), nil
+}
+
+// Info returns the vehicle's internal VIN and identification details.
+func (v VecBaseInfo) Info() VehicleInfo {
+	details := v.Details()
+
+	return VehicleInfo{
+		InternalVIN:   v.Vehicle.CvInformation.InternalVIN,
+		VIN:           details.VIN,
+		Nickname:      details.Nickname,
+		ModelName:     details.ModelName,
+		ModelYear:     details.ModelYear,
+		Carline:       details.CarlineName,
+		CarlineCode:   details.CarlineCode,
+		ModelCode:     details.ModelCode,
+		ExteriorColor: details.ExteriorColorName,
+		InteriorColor: details.InteriorColorName,
+		Transmission:  details.TransmissionType,
+		Powertrain:    details.Powertrain(),
+		EconnectType:  details.EconnectType,
+	}
+}
+
+// Details extracts the full identification details of the vehicle.
+func (v VecBaseInfo) Details() VehicleDetails {
+	// Use the parsed vehicleInformation (JSON string) which has the actual model data
+	return VehicleDetails{
+		VIN:                    v.VIN,
+		Nickname:               v.Nickname,
+		EconnectType:           v.EconnectType,
+		OtherInformationParsed: v.Vehicle.VehicleInformation.OtherInformation,
+	}
 }
 
 // GetBatteryInfo extracts battery information from the EV status response.
@@ -256,6 +537,7 @@ func (r *EVVehicleStatusResponse) GetBatteryInfo() (BatteryInfo, error) {
 		return BatteryInfo{}, errors.New("no EV status data available")
 	}
 	chargeInfo := r.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo
+	chargeState := chargeStateFromStatus(int(chargeInfo.ChargeStatusSub))
 
 	return BatteryInfo{
 		BatteryLevel:     chargeInfo.SmaphSOC,
@@ -263,12 +545,49 @@ func (r *EVVehicleStatusResponse) GetBatteryInfo() (BatteryInfo, error) {
 		ChargeTimeACMin:  chargeInfo.MaxChargeMinuteAC,
 		ChargeTimeQBCMin: chargeInfo.MaxChargeMinuteQBC,
 		PluggedIn:        int(chargeInfo.ChargerConnectorFitting) == ChargerConnected,
-		Charging:         int(chargeInfo.ChargeStatusSub) == ChargeStatusCharging,
+		Charging:         chargeState == ChargeStateCharging,
+		ChargeState:      chargeState,
 		HeaterOn:         int(chargeInfo.BatteryHeaterON) == BatteryHeaterOn,
 		HeaterAuto:       int(chargeInfo.CstmzStatBatHeatAutoSW) == BatteryHeaterAutoEnabled,
+		LastChargedAt:    chargeInfo.LastChargeEndDate,
+		ChargeRate:       chargeInfo.GetChargeRate(),
 	}, nil
 }
 
+// GetChargeSchedule extracts the scheduled charging windows from the EV status response.
+// An empty slice means no schedule is configured.
+func (r *EVVehicleStatusResponse) GetChargeSchedule() ([]ChargeWindow, error) {
+	if len(r.ResultData) == 0 {
+		return nil, errors.New("no EV status data available")
+	}
+	settings := r.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.ChargeSchedule
+
+	windows := make([]ChargeWindow, 0, len(settings))
+	for _, setting := range settings {
+		windows = append(windows, ChargeWindow{
+			StartTime: setting.StartTime,
+			EndTime:   setting.EndTime,
+			Enabled:   int(setting.Enabled) == ChargeScheduleEnabled,
+		})
+	}
+
+	return windows, nil
+}
+
+// GetBatteryHealth extracts the estimated battery state of health (0-100%).
+// Returns ErrBatteryHealthUnavailable when the vehicle does not report it.
+func (r *EVVehicleStatusResponse) GetBatteryHealth() (float64, error) {
+	if len(r.ResultData) == 0 {
+		return 0, errors.New("no EV status data available")
+	}
+	soh := r.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.BatterySOH
+	if soh == nil || *soh <= 0 || *soh > 100 {
+		return 0, ErrBatteryHealthUnavailable
+	}
+
+	return *soh, nil
+}
+
 // GetHvacInfo extracts HVAC information from the EV status response.
 func (r *EVVehicleStatusResponse) GetHvacInfo() (HVACInfo, error) {
 	if len(r.ResultData) == 0 {
@@ -279,13 +598,31 @@ func (r *EVVehicleStatusResponse) GetHvacInfo() (HVACInfo, error) {
 		return HVACInfo{}, errors.New("no HVAC info available")
 	}
 
-	return HVACInfo{
+	info := HVACInfo{
 		HVACOn:         int(hvacInfo.HVAC) == HVACStatusOn,
-		FrontDefroster: int(hvacInfo.FrontDefroster) == DefrosterOn,
-		RearDefroster:  int(hvacInfo.RearDefogger) == DefrosterOn,
+		FrontDefroster: defrosterState(hvacInfo.FrontDefroster),
+		RearDefroster:  defrosterState(hvacInfo.RearDefogger),
 		InteriorTempC:  hvacInfo.InCarTeDC,
 		TargetTempC:    hvacInfo.TargetTemp,
-	}, nil
+	}
+	// A remaining time only means something while climate is running.
+	if info.HVACOn && hvacInfo.RemainingMinutes != nil && *hvacInfo.RemainingMinutes > 0 {
+		remaining := *hvacInfo.RemainingMinutes
+		info.RemainingMinutes = &remaining
+	}
+
+	return info, nil
+}
+
+// defrosterState converts a raw defroster value to on/off, or nil when the
+// vehicle doesn't report it.
+func defrosterState(raw *float64) *bool {
+	if raw == nil {
+		return nil
+	}
+	on := int(*raw) == DefrosterOn
+
+	return &on
 }
 
 // GetOccurrenceDate returns the occurrence date from the first result.
@@ -297,31 +634,97 @@ func (r *EVVehicleStatusResponse) GetOccurrenceDate() (string, error) {
 	return r.ResultData[0].OccurrenceDate, nil
 }
 
+// latestBy returns the entry with the most recent timestamp. The API doesn't
+// guarantee ordering when it returns several snapshots. Timestamps are
+// fixed-width YYYYMMDDHHmmss strings, so they compare lexically; ties and
+// missing timestamps keep the earlier entry. items must not be empty.
+func latestBy[T any](items []T, timestamp func(T) string) T {
+	latest := items[0]
+	for _, item := range items[1:] {
+		if timestamp(item) > timestamp(latest) {
+			latest = item
+		}
+	}
+
+	return latest
+}
+
+// latestAlertInfo returns the most recent alert snapshot, falling back to the
+// position timestamp for entries without an occurrence date.
+func (r *VehicleStatusResponse) latestAlertInfo() AlertInfo {
+	return latestBy(r.AlertInfos, alertTimestamp)
+}
+
+// alertTimestamp returns when an alert snapshot was taken: its occurrence date,
+// or the position timestamp for entries without one.
+func alertTimestamp(a AlertInfo) string {
+	if a.OccurrenceDate != "" {
+		return a.OccurrenceDate
+	}
+
+	return a.PositionInfo.AcquisitionDatetime
+}
+
+// latestRemoteInfo returns the most recent remote info snapshot.
+func (r *VehicleStatusResponse) latestRemoteInfo() RemoteInfo {
+	return latestBy(r.RemoteInfos, func(ri RemoteInfo) string { return ri.OccurrenceDate })
+}
+
+// GetOccurrenceDate returns when the vehicle status (doors, windows, position,
+// fuel, tires, and odometer) was last reported: the newer of the latest alert
+// and remote info timestamps. It is empty when neither reports one.
+func (r *VehicleStatusResponse) GetOccurrenceDate() (string, error) {
+	if len(r.AlertInfos) == 0 && len(r.RemoteInfos) == 0 {
+		return "", errors.New("no vehicle status data available")
+	}
+
+	var alertDate, remoteDate string
+	if len(r.AlertInfos) > 0 {
+		alertDate = alertTimestamp(r.latestAlertInfo())
+	}
+	if len(r.RemoteInfos) > 0 {
+		remoteDate = r.latestRemoteInfo().OccurrenceDate
+	}
+
+	return max(alertDate, remoteDate), nil
+}
+
 // GetFuelInfo extracts fuel information from the vehicle status response.
 func (r *VehicleStatusResponse) GetFuelInfo() (FuelInfo, error) {
 	if len(r.RemoteInfos) == 0 {
 		return FuelInfo{}, errors.New("no vehicle status data available")
 	}
-	fuel := r.RemoteInfos[0].ResidualFuel
+	fuel := r.latestRemoteInfo().ResidualFuel
 
-	return FuelInfo{
+	fuelInfo := FuelInfo{
 		FuelLevel: fuel.FuelSegmentDActl,
 		RangeKm:   fuel.RemDrvDistDActlKm,
-	}, nil
+	}
+	if fuel.LowFuelWarning != nil {
+		lowFuel := int(*fuel.LowFuelWarning) == LowFuelWarningOn
+		fuelInfo.APILowFuel = &lowFuel
+	}
+
+	return fuelInfo, nil
 }
 
-// GetTiresInfo extracts tire pressure information from the vehicle status response.
+// GetTiresInfo extracts tire pressure, and temperature where reported, from the
+// vehicle status response.
 func (r *VehicleStatusResponse) GetTiresInfo() (TireInfo, error) {
 	if len(r.RemoteInfos) == 0 {
 		return TireInfo{}, errors.New("no vehicle status data available")
 	}
-	tpms := r.RemoteInfos[0].TPMSInformation
+	tpms := r.latestRemoteInfo().TPMSInformation
 
 	return TireInfo{
-		FrontLeftPsi:  tpms.FLTPrsDispPsi,
-		FrontRightPsi: tpms.FRTPrsDispPsi,
-		RearLeftPsi:   tpms.RLTPrsDispPsi,
-		RearRightPsi:  tpms.RRTPrsDispPsi,
+		FrontLeftPsi:    tpms.FLTPrsDispPsi,
+		FrontRightPsi:   tpms.FRTPrsDispPsi,
+		RearLeftPsi:     tpms.RLTPrsDispPsi,
+		RearRightPsi:    tpms.RRTPrsDispPsi,
+		FrontLeftTempC:  tpms.FLTTempDispDegC,
+		FrontRightTempC: tpms.FRTTempDispDegC,
+		RearLeftTempC:   tpms.RLTTempDispDegC,
+		RearRightTempC:  tpms.RRTTempDispDegC,
 	}, nil
 }
 
@@ -330,12 +733,16 @@ func (r *VehicleStatusResponse) GetLocationInfo() (LocationInfo, error) {
 	if len(r.AlertInfos) == 0 {
 		return LocationInfo{}, errors.New("no alert info available")
 	}
-	pos := r.AlertInfos[0].PositionInfo
+	pos := latestBy(r.AlertInfos, func(a AlertInfo) string {
+		return a.PositionInfo.AcquisitionDatetime
+	}).PositionInfo
 
 	return LocationInfo{
 		Latitude:  pos.Latitude,
 		Longitude: pos.Longitude,
 		Timestamp: pos.AcquisitionDatetime,
+		Heading:   pos.Heading,
+		SpeedKmh:  pos.SpeedKmh,
 	}, nil
 }
 
@@ -355,6 +762,72 @@ type DoorStatus struct {
 	AllLocked       bool
 }
 
+// DoorIssue is a door, or the trunk, hood, or fuel lid, that is open, or a door
+// that is closed but unlocked.
+type DoorIssue struct {
+	Name string // e.g. "Driver" or "Fuel lid"
+	Open bool   // whether it's open; otherwise it's a closed, unlocked door
+}
+
+// String describes the issue, e.g. "Driver unlocked" or "Trunk open".
+func (i DoorIssue) String() string {
+	if i.Open {
+		return i.Name + " open"
+	}
+
+	return i.Name + " unlocked"
+}
+
+// Issues lists the open and unlocked doors in driver, passenger, rear left, rear
+// right, trunk, hood, fuel lid order.
+func (s DoorStatus) Issues() []DoorIssue {
+	doors := []struct {
+		name     string
+		isOpen   bool
+		isLocked bool
+		hasLock  bool // trunk/hood/fuel lid don't have locks
+	}{
+		{"Driver", s.DriverOpen, s.DriverLocked, true},
+		{"Passenger", s.PassengerOpen, s.PassengerLocked, true},
+		{"Rear left", s.RearLeftOpen, s.RearLeftLocked, true},
+		{"Rear right", s.RearRightOpen, s.RearRightLocked, true},
+		{"Trunk", s.TrunkOpen, false, false},
+		{"Hood", s.HoodOpen, false, false},
+		{"Fuel lid", s.FuelLidOpen, false, false},
+	}
+
+	var issues []DoorIssue
+	for _, door := range doors {
+		if door.isOpen {
+			issues = append(issues, DoorIssue{Name: door.name, Open: true})
+		} else if door.hasLock && !door.isLocked {
+			issues = append(issues, DoorIssue{Name: door.name})
+		}
+	}
+
+	return issues
+}
+
+// Summary describes the doors in a phrase such as "All locked" or "Driver
+// unlocked, Trunk open", as shown in the DOORS line of mcs status.
+func (s DoorStatus) Summary() string {
+	if s.AllLocked {
+		return "All locked"
+	}
+
+	issues := s.Issues()
+	if len(issues) == 0 {
+		return "Status unknown"
+	}
+
+	descriptions := make([]string, len(issues))
+	for i, issue := range issues {
+		descriptions[i] = issue.String()
+	}
+
+	return strings.Join(descriptions, ", ")
+}
+
 // BatteryInfo represents battery and charging information.
 type BatteryInfo struct {
 	BatteryLevel     float64
@@ -363,22 +836,44 @@ type BatteryInfo struct {
 	ChargeTimeQBCMin float64
 	PluggedIn        bool
 	Charging         bool
+	ChargeState      ChargeState
 	HeaterOn         bool
 	HeaterAuto       bool
+	LastChargedAt    string // API timestamp; empty when the vehicle doesn't report it
+	ChargeRate       ChargeRate
+}
+
+// ChargeWindow represents a scheduled charging window.
+type ChargeWindow struct {
+	StartTime string
+	EndTime   string
+	Enabled   bool
 }
 
 // FuelInfo represents fuel information.
 type FuelInfo struct {
 	FuelLevel float64
 	RangeKm   float64
+	// APILowFuel is whether the vehicle's low-fuel warning lamp is on, as
+	// opposed to a threshold chosen by the user; nil when not reported.
+	APILowFuel *bool
 }
 
-// TireInfo represents tire pressure information.
+// TireInfo represents tire pressure and temperature information.
 type TireInfo struct {
-	FrontLeftPsi  float64
-	FrontRightPsi float64
-	RearLeftPsi   float64
-	RearRightPsi  float64
+	FrontLeftPsi    float64
+	FrontRightPsi   float64
+	RearLeftPsi     float64
+	RearRightPsi    float64
+	FrontLeftTempC  *float64 // nil when the vehicle doesn't report it
+	FrontRightTempC *float64 // nil when the vehicle doesn't report it
+	RearLeftTempC   *float64 // nil when the vehicle doesn't report it
+	RearRightTempC  *float64 // nil when the vehicle doesn't report it
+}
+
+// HasTemperatures reports whether any tire temperature was reported.
+func (t TireInfo) HasTemperatures() bool {
+	return t.FrontLeftTempC != nil || t.FrontRightTempC != nil || t.RearLeftTempC != nil || t.RearRightTempC != nil
 }
 
 // LocationInfo represents GPS location information.
@@ -386,11 +881,43 @@ type LocationInfo struct {
 	Latitude  float64
 	Longitude float64
 	Timestamp string
+	Heading   *float64 // nil when the vehicle doesn't report it
+	SpeedKmh  *float64 // nil when the vehicle doesn't report it
+}
+
+// AuxBatteryLowVoltage is the 12V battery voltage below which the battery is
+// reported as low. A healthy battery at rest reads about 12.4-12.7V.
+const AuxBatteryLowVoltage = 12.2
+
+// AuxBatteryInfo represents the 12V auxiliary battery.
+type AuxBatteryInfo struct {
+	VoltageV float64
+}
+
+// Low reports whether the voltage is below AuxBatteryLowVoltage, e.g. after doors
+// or lights were left on.
+func (a AuxBatteryInfo) Low() bool {
+	return a.VoltageV < AuxBatteryLowVoltage
 }
 
 // OdometerInfo represents odometer information.
 type OdometerInfo struct {
 	OdometerKm float64
+
+	// Trip holds the current trip meter, nil when the vehicle doesn't report one.
+	Trip *TripInfo
+}
+
+// TripInfo represents the vehicle's trip meter. Each field is nil when not reported.
+type TripInfo struct {
+	DistanceKm       *float64
+	DriveTimeMinutes *float64
+	FuelUsedLiters   *float64
+
+	// AvgFuelEconomy and AvgEnergyEconomy are in the units the vehicle displays,
+	// which depend on its market settings.
+	AvgFuelEconomy   *float64
+	AvgEnergyEconomy *float64
 }
 
 // WindowInfo represents window position information.
@@ -401,13 +928,65 @@ type WindowStatus struct {
 	RearRightPosition float64
 }
 
+// positions returns the window positions in driver, passenger, rear left, rear right order.
+func (w WindowStatus) positions() []float64 {
+	return []float64{w.DriverPosition, w.PassengerPosition, w.RearLeftPosition, w.RearRightPosition}
+}
+
+// OpenCount returns the number of windows that are not fully closed.
+func (w WindowStatus) OpenCount() int {
+	count := 0
+	for _, position := range w.positions() {
+		if position > WindowClosed {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AnyOpen reports whether any window is not fully closed.
+func (w WindowStatus) AnyOpen() bool {
+	return w.OpenCount() > 0
+}
+
 // HVACInfo represents HVAC system information.
 type HVACInfo struct {
 	HVACOn         bool
-	FrontDefroster bool
-	RearDefroster  bool
+	FrontDefroster *bool // nil when the vehicle doesn't report it
+	RearDefroster  *bool // nil when the vehicle doesn't report it
 	InteriorTempC  float64
 	TargetTempC    float64
+
+	// RemainingMinutes is how long climate keeps running before the vehicle's
+	// auto-shutoff. Nil when climate is off or the vehicle doesn't report it.
+	RemainingMinutes *float64
+}
+
+// FrontDefrosterOn reports whether the front defroster is known to be on.
+func (h HVACInfo) FrontDefrosterOn() bool {
+	return h.FrontDefroster != nil && *h.FrontDefroster
+}
+
+// RearDefrosterOn reports whether the rear defroster is known to be on.
+func (h HVACInfo) RearDefrosterOn() bool {
+	return h.RearDefroster != nil && *h.RearDefroster
+}
+
+// Mode infers whether the HVAC is heating or cooling the cabin by comparing the
+// interior and target temperatures. It is idle when the HVAC is off, no target
+// is reported, or the cabin is already at the target.
+func (h HVACInfo) Mode() HVACMode {
+	switch {
+	case !h.HVACOn || h.TargetTempC <= 0:
+		return HVACModeIdle
+	case h.InteriorTempC < h.TargetTempC:
+		return HVACModeHeating
+	case h.InteriorTempC > h.TargetTempC:
+		return HVACModeCooling
+	default:
+		return HVACModeIdle
+	}
 }
 
 // allDoorsLocked returns true if all doors are closed and locked.
@@ -419,6 +998,16 @@ func allDoorsLocked(status DoorStatus) bool {
 		status.RearLeftLocked && status.RearRightLocked
 }
 
+// GetLatestAlertInfo returns the most recent alert snapshot as the API sent it,
+// with the raw door, lock, window, and hazard codes the other getters interpret.
+func (r *VehicleStatusResponse) GetLatestAlertInfo() (AlertInfo, error) {
+	if len(r.AlertInfos) == 0 {
+		return AlertInfo{}, errors.New("no alert info available")
+	}
+
+	return r.latestAlertInfo(), nil
+}
+
 // GetDoorsInfo extracts door lock status from the vehicle status response.
 func (r *VehicleStatusResponse) GetDoorsInfo() (status DoorStatus, err error) {
 	if len(r.AlertInfos) == 0 {
@@ -426,8 +1015,13 @@ func (r *VehicleStatusResponse) GetDoorsInfo() (status DoorStatus, err error) {
 
 		return
 	}
-	door := r.AlertInfos[0].Door
+	status = doorStatusFromInfo(r.latestAlertInfo().Door)
 
+	return
+}
+
+// doorStatusFromInfo converts raw door and lock statuses into a DoorStatus.
+func doorStatusFromInfo(door DoorInfo) (status DoorStatus) {
 	// Open status (1=open, 0=closed)
 	status.DriverOpen = int(door.DrStatDrv) == DoorOpen
 	status.PassengerOpen = int(door.DrStatPsngr) == DoorOpen
@@ -455,9 +1049,31 @@ func (r *VehicleStatusResponse) GetOdometerInfo() (OdometerInfo, error) {
 		return OdometerInfo{}, errors.New("no vehicle status data available")
 	}
 
-	return OdometerInfo{
-		OdometerKm: r.RemoteInfos[0].DriveInformation.OdoDispValue,
-	}, nil
+	drive := r.latestRemoteInfo().DriveInformation
+	trip := TripInfo{
+		DistanceKm:       reportedValue(drive.Drv1Distnc),
+		DriveTimeMinutes: reportedValue(drive.Drv1DrvTm),
+		FuelUsedLiters:   reportedValue(drive.Drv1AmntFuel),
+		AvgFuelEconomy:   reportedValue(drive.Drv1AvlFuelG),
+		AvgEnergyEconomy: reportedValue(drive.Drv1AvlFuelE),
+	}
+
+	info := OdometerInfo{OdometerKm: drive.OdoDispValue}
+	if trip != (TripInfo{}) {
+		info.Trip = &trip
+	}
+
+	return info, nil
+}
+
+// reportedValue returns value, or nil when it is absent or negative (the API
+// reports -1 for values the vehicle doesn't provide).
+func reportedValue(value *float64) *float64 {
+	if value == nil || *value < 0 {
+		return nil
+	}
+
+	return value
 }
 
 // GetWindowsInfo extracts window position information from the vehicle status response.
@@ -465,14 +1081,32 @@ func (r *VehicleStatusResponse) GetWindowsInfo() (WindowStatus, error) {
 	if len(r.AlertInfos) == 0 {
 		return WindowStatus{}, errors.New("no alert info available")
 	}
-	pw := r.AlertInfos[0].Pw
 
+	return windowStatusFromInfo(r.latestAlertInfo().Pw), nil
+}
+
+// windowStatusFromInfo converts raw window positions into a WindowStatus.
+func windowStatusFromInfo(pw WindowInfo) WindowStatus {
 	return WindowStatus{
 		DriverPosition:    pw.PwPosDrv,
 		PassengerPosition: pw.PwPosPsngr,
 		RearLeftPosition:  pw.PwPosRl,
 		RearRightPosition: pw.PwPosRr,
-	}, nil
+	}
+}
+
+// GetAuxBatteryInfo extracts the 12V auxiliary battery voltage from the vehicle
+// status response. Returns ErrAuxBatteryUnavailable when the vehicle does not report it.
+func (r *VehicleStatusResponse) GetAuxBatteryInfo() (AuxBatteryInfo, error) {
+	if len(r.RemoteInfos) == 0 {
+		return AuxBatteryInfo{}, errors.New("no vehicle status data available")
+	}
+	battery := r.latestRemoteInfo().BatteryStatus
+	if battery == nil || battery.BatteryVoltage <= 0 {
+		return AuxBatteryInfo{}, ErrAuxBatteryUnavailable
+	}
+
+	return AuxBatteryInfo{VoltageV: battery.BatteryVoltage}, nil
 }
 
 // GetHazardInfo extracts hazard lights status from the vehicle status response.
@@ -482,7 +1116,7 @@ func (r *VehicleStatusResponse) GetHazardInfo() (hazardsOn bool, err error) {
 
 		return
 	}
-	hazardsOn = int(r.AlertInfos[0].HazardLamp.HazardSw) == HazardLightsOn
+	hazardsOn = int(r.latestAlertInfo().HazardLamp.HazardSw) == HazardLightsOn
 
 	return
 }
@@ -544,6 +1178,9 @@ const (
 	ResultCodeSuccess = "200S00"
 )
 
+// LowFuelWarningOn indicates the vehicle's low-fuel warning lamp is lit.
+const LowFuelWarningOn = 1
+
 // Charger status constants.
 const (
 	// ChargerConnected indicates the charger is connected/plugged in.
@@ -552,12 +1189,70 @@ const (
 	ChargerDisconnected = 0
 )
 
-// Charging status constants.
+// Charging status constants. Only ChargeStatusNotCharging and
+// ChargeStatusCharging have been seen in recorded responses; the other values are
+// unverified guesses at what the vehicle reports.
 const (
-	// ChargeStatusCharging indicates the vehicle is actively charging.
-	ChargeStatusCharging = 6
 	// ChargeStatusNotCharging indicates the vehicle is not charging.
 	ChargeStatusNotCharging = 0
+	// ChargeStatusScheduled is believed to mean the vehicle is plugged in and
+	// waiting for a scheduled charging window. Unverified.
+	ChargeStatusScheduled = 1
+	// ChargeStatusCharging indicates the vehicle is actively charging.
+	ChargeStatusCharging = 6
+	// ChargeStatusComplete is believed to mean charging finished at the target
+	// level. Unverified.
+	ChargeStatusComplete = 7
+	// ChargeStatusInterrupted is believed to mean charging stopped before
+	// completing, e.g. because of a charger fault or a lost supply. Unverified.
+	ChargeStatusInterrupted = 8
+)
+
+// ChargeState is what the charger is doing, decoded from ChargeStatusSub.
+type ChargeState string
+
+// Charge states. Values of ChargeStatusSub other than the ones above are
+// reported as "unknown (n)", e.g. "unknown (3)".
+const (
+	ChargeStateNotCharging ChargeState = "not_charging"
+	ChargeStateScheduled   ChargeState = "scheduled"
+	ChargeStateCharging    ChargeState = "charging"
+	ChargeStateComplete    ChargeState = "complete"
+	ChargeStateInterrupted ChargeState = "interrupted"
+)
+
+// chargeStateFromStatus decodes a ChargeStatusSub value.
+func chargeStateFromStatus(status int) ChargeState {
+	switch status {
+	case ChargeStatusNotCharging:
+		return ChargeStateNotCharging
+	case ChargeStatusScheduled:
+		return ChargeStateScheduled
+	case ChargeStatusCharging:
+		return ChargeStateCharging
+	case ChargeStatusComplete:
+		return ChargeStateComplete
+	case ChargeStatusInterrupted:
+		return ChargeStateInterrupted
+	default:
+		return ChargeState(fmt.Sprintf("unknown (%d)", status))
+	}
+}
+
+// Charge type constants.
+const (
+	// ChargeTypeAC indicates normal charging from an AC charger.
+	ChargeTypeAC = 1
+	// ChargeTypeDC indicates quick charging from a DC charger.
+	ChargeTypeDC = 2
+)
+
+// Charge schedule status constants.
+const (
+	// ChargeScheduleEnabled indicates a charging window is active.
+	ChargeScheduleEnabled = 1
+	// ChargeScheduleDisabled indicates a charging window is configured but inactive.
+	ChargeScheduleDisabled = 0
 )
 
 // Battery heater status constants.
@@ -584,6 +1279,16 @@ const (
 	HVACStatusOff = 0
 )
 
+// HVACMode is what the HVAC is inferred to be doing to the cabin temperature.
+type HVACMode string
+
+// HVAC modes returned by HVACInfo.Mode.
+const (
+	HVACModeHeating HVACMode = "heating"
+	HVACModeCooling HVACMode = "cooling"
+	HVACModeIdle    HVACMode = "idle"
+)
+
 // Defroster status constants.
 const (
 	// DefrosterOn indicates a defroster is on.
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 
@@ -62,7 +63,7 @@ func TestVecBaseInfosResponse_VehicleInfo(t *testing.T) {
 	info := resp.VecBaseInfos[0]
 	assert.Equalf(t, "JM3KKEHC1R0123456", info.VIN, "Expected VIN 'JM3KKEHC1R0123456', got '%s'", info.VIN)
 	assert.Equalf(t, "My CX-90", info.Nickname, "Expected Nickname 'My CX-90', got '%s'", info.Nickname)
-	assert.Equalf(t, 1, info.EconnectType, "Expected EconnectType 1, got %d", info.EconnectType)
+	assert.Equalf(t, EconnectTypeStandard, info.EconnectType, "Expected EconnectType 1, got %d", info.EconnectType)
 }
 
 func TestVecBaseInfosResponse_GetVehicleInfo(t *testing.T) {
@@ -94,6 +95,42 @@ func TestVecBaseInfosResponse_GetVehicleInfo(t *testing.T) {
 	assert.Equalf(t, "2024", modelYear, "Expected modelYear '2024', got '%s'", modelYear)
 }
 
+func TestVecBaseInfosResponse_GetVehicleDetails(t *testing.T) {
+	t.Parallel()
+	jsonData := `{
+		"resultCode": "200S00",
+		"vecBaseInfos": [
+			{
+				"vin": "JM3KKEHC1R0123456",
+				"nickname": "My Car",
+				"Vehicle": {
+					"CvInformation": {"internalVin": "12345"},
+					"vehicleInformation": "{\"OtherInformation\":{\"carlineName\":\"CX-90 PHEV Premium Plus\",\"carlineCode\":\"KK\",\"modelCode\":\"KKEH\",\"modelName\":\"CX-90 PHEV\",\"modelYear\":\"2024\",\"transmissionType\":\"A\",\"exteriorColorName\":\"Rhodium White Metallic\",\"interiorColorName\":\"Tan Nappa\"}}"
+				}
+			}
+		]
+	}`
+
+	var resp VecBaseInfosResponse
+	require.NoError(t, json.Unmarshal([]byte(jsonData), &resp))
+
+	details, err := resp.GetVehicleDetails()
+	require.NoError(t, err)
+	assert.Equal(t, "JM3KKEHC1R0123456", details.VIN)
+	assert.Equal(t, "My Car", details.Nickname)
+	assert.Equal(t, "CX-90 PHEV Premium Plus", details.CarlineName)
+	assert.Equal(t, "KK", details.CarlineCode)
+	assert.Equal(t, "KKEH", details.ModelCode)
+	assert.Equal(t, "CX-90 PHEV", details.ModelName)
+	assert.Equal(t, "2024", details.ModelYear)
+	assert.Equal(t, "A", details.TransmissionType)
+	assert.Equal(t, "Rhodium White Metallic", details.ExteriorColorName)
+	assert.Equal(t, "Tan Nappa", details.InteriorColorName)
+
+	_, err = (&VecBaseInfosResponse{}).GetVehicleDetails()
+	require.Error(t, err)
+}
+
 func TestVecBaseInfosResponse_GetVehicleInfo_Empty(t *testing.T) {
 	t.Parallel()
 	resp := &VecBaseInfosResponse{}
@@ -256,11 +293,42 @@ func TestEVVehicleStatusResponse_Unmarshal(t *testing.T) {
 	hvacInfo := result.PlusBInformation.VehicleInfo.RemoteHvacInfo
 	require.NotNil(t, hvacInfo, "Expected RemoteHvacInfo to be set")
 	assert.InDelta(t, 1, hvacInfo.HVAC, 0.0001)
-	assert.InDelta(t, 1, hvacInfo.FrontDefroster, 0.0001)
+	require.NotNil(t, hvacInfo.FrontDefroster)
+	assert.InDelta(t, 1, *hvacInfo.FrontDefroster, 0.0001)
 	assert.InDelta(t, 21.5, hvacInfo.InCarTeDC, 0.0001)
 	assert.InDelta(t, 22.0, hvacInfo.TargetTemp, 0.0001)
 }
 
+// TestEVVehicleStatusResponse_HvacRemainingMinutes tests parsing the remaining
+// climate runtime, which only counts while climate is on.
+func TestEVVehicleStatusResponse_HvacRemainingMinutes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		hvac string
+		want *float64
+	}{
+		{name: "running", hvac: `{"HVAC": 1, "InCarTeDC": 18, "TargetTemp": 22, "RemainingMinutes": 12}`, want: floatPtr(12)},
+		{name: "off", hvac: `{"HVAC": 0, "InCarTeDC": 18, "TargetTemp": 22, "RemainingMinutes": 12}`},
+		{name: "not reported", hvac: `{"HVAC": 1, "InCarTeDC": 18, "TargetTemp": 22}`},
+		{name: "zero", hvac: `{"HVAC": 1, "InCarTeDC": 18, "TargetTemp": 22, "RemainingMinutes": 0}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			jsonData := `{"resultCode": "200S00", "resultData": [{"PlusBInformation": {"VehicleInfo": {"RemoteHvacInfo": ` + tt.hvac + `}}}]}`
+
+			var resp EVVehicleStatusResponse
+			require.NoError(t, json.Unmarshal([]byte(jsonData), &resp))
+
+			hvacInfo, err := resp.GetHvacInfo()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hvacInfo.RemainingMinutes)
+		})
+	}
+}
+
 func TestEVVehicleStatusResponse_MissingHvacInfo(t *testing.T) {
 	t.Parallel()
 	jsonData := `{
@@ -319,6 +387,81 @@ func TestInternalVIN_UnmarshalLargeNumber(t *testing.T) {
 	assert.NotEmpty(t, string(vin), "Expected non-empty VIN")
 }
 
+func TestFlexFloat_Unmarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    flexFloat
+		wantErr bool
+	}{
+		{name: "number", input: `66`, want: 66},
+		{name: "decimal", input: `66.5`, want: 66.5},
+		{name: "numeric string", input: `"66"`, want: 66},
+		{name: "padded numeric string", input: `" 66.5 "`, want: 66.5},
+		{name: "empty string", input: `""`, want: 0},
+		{name: "null", input: `null`, want: 0},
+		{name: "non-numeric string", input: `"full"`, wantErr: true},
+		{name: "bool", input: `true`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var f flexFloat
+			err := json.Unmarshal([]byte(tt.input), &f)
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, float64(tt.want), float64(f), 0.0001)
+		})
+	}
+}
+
+func TestDoorInfo_UnmarshalNumericStrings(t *testing.T) {
+	t.Parallel()
+	var fromNumbers, fromStrings DoorInfo
+	require.NoError(t, json.Unmarshal([]byte(`{"DrStatDrv": 1, "DrStatHood": 0, "LockLinkSwDrv": 0, "LockLinkSwRr": 1}`), &fromNumbers))
+	require.NoError(t, json.Unmarshal([]byte(`{"DrStatDrv": "1", "DrStatHood": "0", "LockLinkSwDrv": "0", "LockLinkSwRr": "1"}`), &fromStrings))
+
+	assert.Equal(t, fromNumbers, fromStrings)
+	assert.InDelta(t, float64(DoorOpen), fromStrings.DrStatDrv, 0.0001)
+	assert.InDelta(t, float64(DoorUnlocked), fromStrings.LockLinkSwRr, 0.0001)
+}
+
+func TestChargeInfo_UnmarshalNumericStrings(t *testing.T) {
+	t.Parallel()
+	var fromNumbers, fromStrings ChargeInfo
+	require.NoError(t, json.Unmarshal([]byte(`{"SmaphSOC": 66, "SmaphRemDrvDistKm": 45.5, "ChargerConnectorFitting": 1, "LastChargeEndDate": "20250115060000"}`), &fromNumbers))
+	require.NoError(t, json.Unmarshal([]byte(`{"SmaphSOC": "66", "SmaphRemDrvDistKm": "45.5", "ChargerConnectorFitting": "1", "LastChargeEndDate": "20250115060000"}`), &fromStrings))
+
+	assert.Equal(t, fromNumbers, fromStrings)
+	assert.InDelta(t, 66.0, fromStrings.SmaphSOC, 0.0001)
+	assert.InDelta(t, 45.5, fromStrings.SmaphRemDrvDistKm, 0.0001)
+	assert.Equal(t, "20250115060000", fromStrings.LastChargeEndDate)
+}
+
+func TestEVVehicleStatusResponse_UnmarshalStringSOC(t *testing.T) {
+	t.Parallel()
+	data := `{
+		"resultCode": "200S00",
+		"resultData": [{
+			"OccurrenceDate": "20250115120000",
+			"PlusBInformation": {"VehicleInfo": {"ChargeInfo": {"SmaphSOC": "66", "ChargerConnectorFitting": "1"}}}
+		}]
+	}`
+	var resp EVVehicleStatusResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &resp))
+
+	batteryInfo, err := resp.GetBatteryInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 66.0, batteryInfo.BatteryLevel, 0.0001)
+	assert.True(t, batteryInfo.PluggedIn)
+}
+
 // Auth response struct tests
 
 func TestCheckVersionResponse_Unmarshal(t *testing.T) {
@@ -973,6 +1116,8 @@ func TestVehicleStatusResponse_GetHazardInfo(t *testing.T) {
 
 func TestEVVehicleStatusResponse_GetHvacInfo(t *testing.T) {
 	t.Parallel()
+	rawOn, rawOff := 1.0, 0.0
+	on, off := true, false
 	tests := []struct {
 		name    string
 		resp    *EVVehicleStatusResponse
@@ -988,8 +1133,8 @@ func TestEVVehicleStatusResponse_GetHvacInfo(t *testing.T) {
 							VehicleInfo: EVVehicleInfo{
 								RemoteHvacInfo: &RemoteHvacInfo{
 									HVAC:           1,
-									FrontDefroster: 1,
-									RearDefogger:   0,
+									FrontDefroster: &rawOn,
+									RearDefogger:   &rawOff,
 									InCarTeDC:      18.0,
 									TargetTemp:     22.0,
 								},
@@ -1000,8 +1145,8 @@ func TestEVVehicleStatusResponse_GetHvacInfo(t *testing.T) {
 			},
 			want: HVACInfo{
 				HVACOn:         true,
-				FrontDefroster: true,
-				RearDefroster:  false,
+				FrontDefroster: &on,
+				RearDefroster:  &off,
 				InteriorTempC:  18.0,
 				TargetTempC:    22.0,
 			},
@@ -1016,8 +1161,8 @@ func TestEVVehicleStatusResponse_GetHvacInfo(t *testing.T) {
 							VehicleInfo: EVVehicleInfo{
 								RemoteHvacInfo: &RemoteHvacInfo{
 									HVAC:           0,
-									FrontDefroster: 0,
-									RearDefogger:   0,
+									FrontDefroster: &rawOff,
+									RearDefogger:   &rawOff,
 									InCarTeDC:      20.0,
 									TargetTemp:     21.0,
 								},
@@ -1028,13 +1173,37 @@ func TestEVVehicleStatusResponse_GetHvacInfo(t *testing.T) {
 			},
 			want: HVACInfo{
 				HVACOn:         false,
-				FrontDefroster: false,
-				RearDefroster:  false,
+				FrontDefroster: &off,
+				RearDefroster:  &off,
 				InteriorTempC:  20.0,
 				TargetTempC:    21.0,
 			},
 			wantErr: false,
 		},
+		{
+			name: "defrosters not reported",
+			resp: &EVVehicleStatusResponse{
+				ResultData: []EVResultData{
+					{
+						PlusBInformation: PlusBInformation{
+							VehicleInfo: EVVehicleInfo{
+								RemoteHvacInfo: &RemoteHvacInfo{
+									HVAC:       0,
+									InCarTeDC:  20.0,
+									TargetTemp: 21.0,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: HVACInfo{
+				HVACOn:        false,
+				InteriorTempC: 20.0,
+				TargetTempC:   21.0,
+			},
+			wantErr: false,
+		},
 		{
 			name: "no HVAC info",
 			resp: &EVVehicleStatusResponse{
@@ -1109,6 +1278,7 @@ func TestEVVehicleStatusResponse_GetBatteryInfo(t *testing.T) {
 				ChargeTimeQBCMin: 45,
 				PluggedIn:        true,
 				Charging:         true,
+				ChargeState:      ChargeStateCharging,
 				HeaterOn:         true,
 				HeaterAuto:       true,
 			},
@@ -1141,6 +1311,7 @@ func TestEVVehicleStatusResponse_GetBatteryInfo(t *testing.T) {
 				ChargeTimeQBCMin: 0,
 				PluggedIn:        false,
 				Charging:         false,
+				ChargeState:      ChargeStateNotCharging,
 				HeaterOn:         false,
 				HeaterAuto:       false,
 			},
@@ -1173,6 +1344,7 @@ func TestEVVehicleStatusResponse_GetBatteryInfo(t *testing.T) {
 				ChargeTimeQBCMin: 0,
 				PluggedIn:        false,
 				Charging:         false,
+				ChargeState:      ChargeStateNotCharging,
 				HeaterOn:         false,
 				HeaterAuto:       true,
 			},
@@ -1200,3 +1372,664 @@ func TestEVVehicleStatusResponse_GetBatteryInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestEVVehicleStatusResponse_GetChargeSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		chargeInfo map[string]any
+		want       []ChargeWindow
+	}{
+		{
+			name: "schedule configured",
+			chargeInfo: map[string]any{
+				"SmaphSOC": 55,
+				"ChargeSchedule": []any{
+					map[string]any{"StartTime": "2300", "EndTime": "0600", "Enabled": float64(1)},
+					map[string]any{"StartTime": "1200", "EndTime": "1400", "Enabled": float64(0)},
+				},
+			},
+			want: []ChargeWindow{
+				{StartTime: "2300", EndTime: "0600", Enabled: true},
+				{StartTime: "1200", EndTime: "1400", Enabled: false},
+			},
+		},
+		{
+			name: "schedule absent",
+			chargeInfo: map[string]any{
+				"SmaphSOC": 55,
+			},
+			want: []ChargeWindow{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"resultData": []any{
+					map[string]any{
+						"OccurrenceDate": "20231201120000",
+						"PlusBInformation": map[string]any{
+							"VehicleInfo": map[string]any{
+								"ChargeInfo": tt.chargeInfo,
+							},
+						},
+					},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetEVVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetEVVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			got, err := result.GetChargeSchedule()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEVVehicleStatusResponse_GetChargeSchedule_NoData(t *testing.T) {
+	t.Parallel()
+	resp := &EVVehicleStatusResponse{}
+	_, err := resp.GetChargeSchedule()
+	require.Error(t, err)
+}
+
+func TestEVVehicleStatusResponse_GetBatteryHealth(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		chargeInfo map[string]any
+		want       float64
+		wantErr    error
+	}{
+		{
+			name:       "state of health reported",
+			chargeInfo: map[string]any{"SmaphSOC": 55, "BatterySOH": 93.5},
+			want:       93.5,
+		},
+		{
+			name:       "state of health absent",
+			chargeInfo: map[string]any{"SmaphSOC": 55},
+			wantErr:    ErrBatteryHealthUnavailable,
+		},
+		{
+			name:       "state of health zero",
+			chargeInfo: map[string]any{"SmaphSOC": 55, "BatterySOH": 0},
+			wantErr:    ErrBatteryHealthUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"resultData": []any{
+					map[string]any{
+						"OccurrenceDate": "20231201120000",
+						"PlusBInformation": map[string]any{
+							"VehicleInfo": map[string]any{
+								"ChargeInfo": tt.chargeInfo,
+							},
+						},
+					},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetEVVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetEVVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			got, err := result.GetBatteryHealth()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.001)
+		})
+	}
+}
+
+// TestVehicleStatusResponse_GetAuxBatteryInfo tests parsing the 12V battery
+// voltage, which only some vehicles report.
+func TestVehicleStatusResponse_GetAuxBatteryInfo(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		remoteInfo map[string]any
+		want       float64
+		wantLow    bool
+		wantErr    error
+	}{
+		{
+			name:       "voltage reported",
+			remoteInfo: map[string]any{"OccurrenceDate": "20231201120000", "BatteryStatus": map[string]any{"BatteryVoltage": 12.4}},
+			want:       12.4,
+		},
+		{
+			name:       "low voltage",
+			remoteInfo: map[string]any{"OccurrenceDate": "20231201120000", "BatteryStatus": map[string]any{"BatteryVoltage": 11.8}},
+			want:       11.8,
+			wantLow:    true,
+		},
+		{
+			name:       "voltage absent",
+			remoteInfo: map[string]any{"OccurrenceDate": "20231201120000"},
+			wantErr:    ErrAuxBatteryUnavailable,
+		},
+		{
+			name:       "voltage zero",
+			remoteInfo: map[string]any{"OccurrenceDate": "20231201120000", "BatteryStatus": map[string]any{"BatteryVoltage": 0}},
+			wantErr:    ErrAuxBatteryUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode":  "200S00",
+				"remoteInfos": []any{tt.remoteInfo},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			got, err := result.GetAuxBatteryInfo()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tt.want, got.VoltageV, 0.001)
+			assert.Equal(t, tt.wantLow, got.Low())
+		})
+	}
+}
+
+func TestEVVehicleStatusResponse_GetBatteryInfo_LastCharged(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		chargeInfo map[string]any
+		want       string
+	}{
+		{
+			name:       "last charge reported",
+			chargeInfo: map[string]any{"SmaphSOC": 55, "LastChargeEndDate": "20231129063000"},
+			want:       "20231129063000",
+		},
+		{
+			name:       "last charge absent",
+			chargeInfo: map[string]any{"SmaphSOC": 55},
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"resultData": []any{
+					map[string]any{
+						"OccurrenceDate": "20231201120000",
+						"PlusBInformation": map[string]any{
+							"VehicleInfo": map[string]any{
+								"ChargeInfo": tt.chargeInfo,
+							},
+						},
+					},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetEVVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetEVVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			got, err := result.GetBatteryInfo()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.LastChargedAt)
+		})
+	}
+}
+
+// TestEVVehicleStatusResponse_GetBatteryInfo_ChargeState tests that each
+// ChargeStatusSub value is decoded, with unknown values reported with their code.
+func TestEVVehicleStatusResponse_GetBatteryInfo_ChargeState(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		status       float64
+		want         ChargeState
+		wantCharging bool
+	}{
+		{name: "not charging", status: ChargeStatusNotCharging, want: ChargeStateNotCharging},
+		{name: "scheduled", status: ChargeStatusScheduled, want: ChargeStateScheduled},
+		{name: "charging", status: ChargeStatusCharging, want: ChargeStateCharging, wantCharging: true},
+		{name: "complete", status: ChargeStatusComplete, want: ChargeStateComplete},
+		{name: "interrupted", status: ChargeStatusInterrupted, want: ChargeStateInterrupted},
+		{name: "unknown", status: 3, want: ChargeState("unknown (3)")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp := &EVVehicleStatusResponse{
+				ResultData: []EVResultData{
+					{
+						PlusBInformation: PlusBInformation{
+							VehicleInfo: EVVehicleInfo{
+								ChargeInfo: ChargeInfo{ChargerConnectorFitting: ChargerConnected, ChargeStatusSub: tt.status},
+							},
+						},
+					},
+				},
+			}
+
+			got, err := resp.GetBatteryInfo()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.ChargeState)
+			assert.Equal(t, tt.wantCharging, got.Charging)
+		})
+	}
+}
+
+func TestVehicleStatusResponse_GetLocationInfo_HeadingSpeed(t *testing.T) {
+	t.Parallel()
+	heading := 135.0
+	speed := 0.0
+	tests := []struct {
+		name         string
+		positionInfo map[string]any
+		wantHeading  *float64
+		wantSpeed    *float64
+	}{
+		{
+			name: "heading and speed reported",
+			positionInfo: map[string]any{
+				"Latitude":            37.7749,
+				"Longitude":           122.4194,
+				"AcquisitionDatetime": "20231201120000",
+				"Heading":             135,
+				"Speed":               0,
+			},
+			wantHeading: &heading,
+			wantSpeed:   &speed,
+		},
+		{
+			name: "heading and speed absent",
+			positionInfo: map[string]any{
+				"Latitude":            37.7749,
+				"Longitude":           122.4194,
+				"AcquisitionDatetime": "20231201120000",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"alertInfos": []any{
+					map[string]any{"PositionInfo": tt.positionInfo},
+				},
+				"remoteInfos": []any{},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			location, err := result.GetLocationInfo()
+			require.NoError(t, err)
+			assert.InDelta(t, 37.7749, location.Latitude, 0.0001)
+			assert.Equal(t, tt.wantHeading, location.Heading)
+			assert.Equal(t, tt.wantSpeed, location.SpeedKmh)
+		})
+	}
+}
+
+func TestDoorStatus_Issues(t *testing.T) {
+	t.Parallel()
+	status := DoorStatus{
+		DriverOpen:      true,
+		PassengerLocked: true,
+		RearLeftLocked:  true,
+		TrunkOpen:       true,
+	}
+
+	assert.Equal(t, []DoorIssue{
+		{Name: "Driver", Open: true},
+		{Name: "Rear right"},
+		{Name: "Trunk", Open: true},
+	}, status.Issues())
+	assert.Equal(t, "Driver open, Rear right unlocked, Trunk open", status.Summary())
+	assert.Equal(t, "All locked", DoorStatus{AllLocked: true}.Summary())
+}
+
+func TestWindowStatus_OpenCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		status    WindowStatus
+		wantCount int
+		wantOpen  bool
+	}{
+		{
+			name:      "all closed",
+			status:    WindowStatus{},
+			wantCount: 0,
+			wantOpen:  false,
+		},
+		{
+			name:      "one open",
+			status:    WindowStatus{RearLeftPosition: 30},
+			wantCount: 1,
+			wantOpen:  true,
+		},
+		{
+			name: "all open",
+			status: WindowStatus{
+				DriverPosition:    100,
+				PassengerPosition: 50,
+				RearLeftPosition:  10,
+				RearRightPosition: 1,
+			},
+			wantCount: 4,
+			wantOpen:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantCount, tt.status.OpenCount())
+			assert.Equal(t, tt.wantOpen, tt.status.AnyOpen())
+		})
+	}
+}
+
+func TestHVACInfo_Mode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		hvacInfo HVACInfo
+		want     HVACMode
+	}{
+		{
+			name:     "interior below target is heating",
+			hvacInfo: HVACInfo{HVACOn: true, InteriorTempC: 18, TargetTempC: 22},
+			want:     HVACModeHeating,
+		},
+		{
+			name:     "interior above target is cooling",
+			hvacInfo: HVACInfo{HVACOn: true, InteriorTempC: 30, TargetTempC: 21},
+			want:     HVACModeCooling,
+		},
+		{
+			name:     "interior at target is idle",
+			hvacInfo: HVACInfo{HVACOn: true, InteriorTempC: 21, TargetTempC: 21},
+			want:     HVACModeIdle,
+		},
+		{
+			name:     "hvac off is idle",
+			hvacInfo: HVACInfo{InteriorTempC: 15, TargetTempC: 21},
+			want:     HVACModeIdle,
+		},
+		{
+			name:     "no target reported is idle",
+			hvacInfo: HVACInfo{HVACOn: true, InteriorTempC: 15},
+			want:     HVACModeIdle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tt.hvacInfo.Mode())
+		})
+	}
+}
+
+func TestVehicleStatusResponse_UsesLatestSnapshot(t *testing.T) {
+	t.Parallel()
+	resp := &VehicleStatusResponse{
+		AlertInfos: []AlertInfo{
+			{
+				OccurrenceDate: "20231201120000",
+				PositionInfo:   PositionInfo{Latitude: 1, Longitude: 2, AcquisitionDatetime: "20231201120000"},
+				Door:           DoorInfo{DrStatDrv: 1},
+				Pw:             WindowInfo{PwPosDrv: 50},
+				HazardLamp:     HazardLamp{HazardSw: 1},
+			},
+			{
+				OccurrenceDate: "20231201130000",
+				PositionInfo:   PositionInfo{Latitude: 3, Longitude: 4, AcquisitionDatetime: "20231201130000"},
+			},
+		},
+		RemoteInfos: []RemoteInfo{
+			{
+				OccurrenceDate:   "20231201120000",
+				ResidualFuel:     ResidualFuel{FuelSegmentDActl: 20, RemDrvDistDActlKm: 100},
+				DriveInformation: DriveInformation{OdoDispValue: 1000},
+				TPMSInformation:  TPMSInformation{FLTPrsDispPsi: 30},
+			},
+			{
+				OccurrenceDate:   "20231201130000",
+				ResidualFuel:     ResidualFuel{FuelSegmentDActl: 80, RemDrvDistDActlKm: 400},
+				DriveInformation: DriveInformation{OdoDispValue: 1010},
+				TPMSInformation:  TPMSInformation{FLTPrsDispPsi: 35},
+			},
+		},
+	}
+
+	location, err := resp.GetLocationInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 3.0, location.Latitude, 0.0001)
+	assert.Equal(t, "20231201130000", location.Timestamp)
+
+	doors, err := resp.GetDoorsInfo()
+	require.NoError(t, err)
+	assert.False(t, doors.DriverOpen)
+
+	windows, err := resp.GetWindowsInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, windows.DriverPosition, 0.0001)
+
+	hazards, err := resp.GetHazardInfo()
+	require.NoError(t, err)
+	assert.False(t, hazards)
+
+	fuel, err := resp.GetFuelInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 80.0, fuel.FuelLevel, 0.0001)
+
+	odometer, err := resp.GetOdometerInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 1010.0, odometer.OdometerKm, 0.0001)
+
+	tires, err := resp.GetTiresInfo()
+	require.NoError(t, err)
+	assert.InDelta(t, 35.0, tires.FrontLeftPsi, 0.0001)
+}
+
+func TestVehicleStatusResponse_GetTiresInfo_Temperatures(t *testing.T) {
+	t.Parallel()
+	fl, fr, rl, rr := 28.0, 27.5, 26.0, 26.5
+	tests := []struct {
+		name string
+		tpms map[string]any
+		want TireInfo
+	}{
+		{
+			name: "temperatures reported",
+			tpms: map[string]any{
+				"FLTPrsDispPsi":   32.5,
+				"FRTPrsDispPsi":   32.0,
+				"RLTPrsDispPsi":   31.5,
+				"RRTPrsDispPsi":   31.8,
+				"FLTTempDispDegC": 28.0,
+				"FRTTempDispDegC": 27.5,
+				"RLTTempDispDegC": 26.0,
+				"RRTTempDispDegC": 26.5,
+			},
+			want: TireInfo{
+				FrontLeftPsi:    32.5,
+				FrontRightPsi:   32.0,
+				RearLeftPsi:     31.5,
+				RearRightPsi:    31.8,
+				FrontLeftTempC:  &fl,
+				FrontRightTempC: &fr,
+				RearLeftTempC:   &rl,
+				RearRightTempC:  &rr,
+			},
+		},
+		{
+			name: "temperatures not reported",
+			tpms: map[string]any{
+				"FLTPrsDispPsi": 32.5,
+				"FRTPrsDispPsi": 32.0,
+				"RLTPrsDispPsi": 31.5,
+				"RRTPrsDispPsi": 31.8,
+			},
+			want: TireInfo{
+				FrontLeftPsi:  32.5,
+				FrontRightPsi: 32.0,
+				RearLeftPsi:   31.5,
+				RearRightPsi:  31.8,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"remoteInfos": []any{
+					map[string]any{"TPMSInformation": tt.tpms},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			got, err := result.GetTiresInfo()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.FrontLeftTempC != nil, got.HasTemperatures())
+		})
+	}
+}
+
+func TestLatestBy_KeepsFirstWithoutTimestamps(t *testing.T) {
+	t.Parallel()
+	infos := []RemoteInfo{
+		{DriveInformation: DriveInformation{OdoDispValue: 1}},
+		{DriveInformation: DriveInformation{OdoDispValue: 2}},
+	}
+
+	latest := latestBy(infos, func(ri RemoteInfo) string { return ri.OccurrenceDate })
+	assert.InDelta(t, 1.0, latest.DriveInformation.OdoDispValue, 0.0001)
+}
+
+func TestVehicleStatusResponse_GetOccurrenceDate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		response VehicleStatusResponse
+		want     string
+	}{
+		{
+			name: "remote info newer",
+			response: VehicleStatusResponse{
+				AlertInfos:  []AlertInfo{{OccurrenceDate: "20250115090000"}},
+				RemoteInfos: []RemoteInfo{{OccurrenceDate: "20250115120000"}},
+			},
+			want: "20250115120000",
+		},
+		{
+			name: "alert newer, from its position timestamp",
+			response: VehicleStatusResponse{
+				AlertInfos:  []AlertInfo{{PositionInfo: PositionInfo{AcquisitionDatetime: "20250115130000"}}},
+				RemoteInfos: []RemoteInfo{{OccurrenceDate: "20250115120000"}},
+			},
+			want: "20250115130000",
+		},
+		{
+			name:     "not reported",
+			response: VehicleStatusResponse{RemoteInfos: []RemoteInfo{{}}},
+			want:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.response.GetOccurrenceDate()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := (&VehicleStatusResponse{}).GetOccurrenceDate()
+	require.Error(t, err)
+}
+
+func TestChargeInfo_GetChargeRate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		json string
+		want ChargeRate
+	}{
+		{name: "AC with power", json: `{"ChargeStatusSub": 6, "ChargeType": 1, "ChargePowerKw": 6.6}`, want: ChargeRate{Type: "AC", PowerKW: 6.6}},
+		{name: "DC with power", json: `{"ChargeStatusSub": 6, "ChargeType": 2, "ChargePowerKw": 48}`, want: ChargeRate{Type: "DC", PowerKW: 48}},
+		{name: "unknown type", json: `{"ChargeStatusSub": 6, "ChargeType": 9, "ChargePowerKw": 7.2}`, want: ChargeRate{PowerKW: 7.2}},
+		{name: "not reported", json: `{"ChargeStatusSub": 6}`, want: ChargeRate{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var chargeInfo ChargeInfo
+			require.NoError(t, json.Unmarshal([]byte(tt.json), &chargeInfo))
+			assert.Equal(t, tt.want, chargeInfo.GetChargeRate())
+		})
+	}
+}
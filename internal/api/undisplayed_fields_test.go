@@ -528,3 +528,136 @@ func TestEVVehicleStatusWithVariedValues(t *testing.T) {
 	assert.InDelta(t, 24, targetTemp, 0.0001)
 
 }
+
+// TestTripFieldsInVehicleStatus verifies that trip meter fields in DriveInformation are parsed,
+// and that missing or -1 values are treated as not reported.
+func TestTripFieldsInVehicleStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		driveInformation map[string]any
+		wantTrip         *TripInfo
+	}{
+		{
+			name: "all trip fields",
+			driveInformation: map[string]any{
+				"OdoDispValue": 12345.6,
+				"Drv1Distnc":   152.3,
+				"Drv1DrvTm":    float64(125),
+				"Drv1AmntFuel": 9.8,
+				"Drv1AvlFuelG": 6.4,
+				"Drv1AvlFuelE": 18.2,
+			},
+			wantTrip: &TripInfo{
+				DistanceKm:       floatPtr(152.3),
+				DriveTimeMinutes: floatPtr(125),
+				FuelUsedLiters:   floatPtr(9.8),
+				AvgFuelEconomy:   floatPtr(6.4),
+				AvgEnergyEconomy: floatPtr(18.2),
+			},
+		},
+		{
+			name: "unavailable fields are -1",
+			driveInformation: map[string]any{
+				"OdoDispValue": 12345.6,
+				"Drv1Distnc":   152.3,
+				"Drv1DrvTm":    float64(-1),
+				"Drv1AmntFuel": float64(-1),
+				"Drv1AvlFuelG": 6.4,
+				"Drv1AvlFuelE": float64(-1),
+			},
+			wantTrip: &TripInfo{
+				DistanceKm:     floatPtr(152.3),
+				AvgFuelEconomy: floatPtr(6.4),
+			},
+		},
+		{
+			name: "all unavailable",
+			driveInformation: map[string]any{
+				"OdoDispValue": 12345.6,
+				"Drv1Distnc":   float64(-1),
+				"Drv1AvlFuelG": float64(-1),
+			},
+		},
+		{
+			name:             "no trip fields",
+			driveInformation: map[string]any{"OdoDispValue": 12345.6},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"remoteInfos": []any{
+					map[string]any{"DriveInformation": tt.driveInformation},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			odometerInfo, err := result.GetOdometerInfo()
+			require.NoError(t, err)
+			assert.InDelta(t, 12345.6, odometerInfo.OdometerKm, 0.0001)
+			assert.Equal(t, tt.wantTrip, odometerInfo.Trip)
+		})
+	}
+}
+
+// TestLowFuelWarningInVehicleStatus verifies that the vehicle's own low-fuel
+// warning lamp is parsed, and that it's left unset when not reported.
+func TestLowFuelWarningInVehicleStatus(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		residualFuel map[string]any
+		want         *bool
+	}{
+		{
+			name:         "warning on",
+			residualFuel: map[string]any{"FuelSegementDActl": 12.0, "RemDrvDistDActlKm": 70.0, "LowFuelWarning": float64(LowFuelWarningOn)},
+			want:         boolPtr(true),
+		},
+		{
+			name:         "warning off",
+			residualFuel: map[string]any{"FuelSegementDActl": 12.0, "RemDrvDistDActlKm": 70.0, "LowFuelWarning": 0.0},
+			want:         boolPtr(false),
+		},
+		{
+			name:         "not reported",
+			residualFuel: map[string]any{"FuelSegementDActl": 12.0, "RemDrvDistDActlKm": 70.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			responseData := map[string]any{
+				"resultCode": "200S00",
+				"remoteInfos": []any{
+					map[string]any{"ResidualFuel": tt.residualFuel},
+				},
+			}
+
+			server := createSuccessServer(t, "/"+EndpointGetVehicleStatus, responseData)
+			defer server.Close()
+
+			client := createTestClient(t, server.URL)
+
+			result, err := client.GetVehicleStatus(context.Background(), "INTERNAL123")
+			require.NoError(t, err)
+
+			fuelInfo, err := result.GetFuelInfo()
+			require.NoError(t, err)
+			assert.InDelta(t, 12.0, fuelInfo.FuelLevel, 0.0001)
+			assert.Equal(t, tt.want, fuelInfo.APILowFuel)
+		})
+	}
+}
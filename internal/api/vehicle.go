@@ -32,6 +32,47 @@ func (c *Client) GetVecBaseInfos(ctx context.Context) (*VecBaseInfosResponse, er
 	return &typed, nil
 }
 
+// GetTyped POSTs bodyParams to endpoint, as the vehicle endpoints expect, and
+// unmarshals the decrypted response into a T. It lets callers read fields this
+// package doesn't model yet into their own struct, without forking it. A
+// response whose resultCode isn't success fails with a ResultCodeError.
+func GetTyped[T any](ctx context.Context, c *Client, endpoint string, bodyParams map[string]any) (*T, error) {
+	responseBytes, err := c.APIRequestJSON(ctx, "POST", endpoint, nil, bodyParams, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ResultCode string `json:"resultCode"`
+	}
+	if err := json.Unmarshal(responseBytes, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if result.ResultCode != "" {
+		if err := checkResultCode(result.ResultCode, endpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	var typed T
+	if err := json.Unmarshal(responseBytes, &typed); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &typed, nil
+}
+
+// GetPrimaryVehicle returns the first vehicle on the account, with the internal
+// VIN needed for status and remote command calls.
+func (c *Client) GetPrimaryVehicle(ctx context.Context) (VehicleInfo, error) {
+	vecBaseInfos, err := c.GetVecBaseInfos(ctx)
+	if err != nil {
+		return VehicleInfo{}, err
+	}
+
+	return vecBaseInfos.GetPrimaryVehicle()
+}
+
 // buildVehicleStatusParams creates the standard body parameters for vehicle status requests.
 func buildVehicleStatusParams(internalVIN string) map[string]any {
 	return map[string]any{
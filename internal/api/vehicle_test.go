@@ -42,6 +42,60 @@ func TestGetVecBaseInfos(t *testing.T) {
 	assert.Lenf(t, result.VecBaseInfos, 1, "Expected 1 vehicle, got %d", len(result.VecBaseInfos))
 }
 
+// TestGetPrimaryVehicle tests getting the first vehicle on the account.
+func TestGetPrimaryVehicle(t *testing.T) {
+	t.Parallel()
+	responseData := map[string]any{
+		"resultCode": "200S00",
+		"vecBaseInfos": []map[string]any{
+			{
+				"vin":          "JM3KKEHC1R0123456",
+				"nickname":     "My Car",
+				"econnectType": 1,
+				"Vehicle": map[string]any{
+					"CvInformation":      map[string]any{"internalVin": "INTERNAL123"},
+					"vehicleInformation": `{"OtherInformation":{"carlineName":"CX-90 PHEV Premium Plus","modelCode":"KKEH","modelName":"CX-90 PHEV","modelYear":"2024"}}`,
+				},
+			},
+			{
+				"vin":     "JM3KKEHC1R0654321",
+				"Vehicle": map[string]any{"CvInformation": map[string]any{"internalVin": "INTERNAL456"}},
+			},
+		},
+	}
+
+	server := createSuccessServer(t, "/"+EndpointGetVecBaseInfos, responseData)
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+
+	vehicle, err := client.GetPrimaryVehicle(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, VehicleInfo{
+		InternalVIN:  "INTERNAL123",
+		VIN:          "JM3KKEHC1R0123456",
+		Nickname:     "My Car",
+		ModelName:    "CX-90 PHEV",
+		ModelYear:    "2024",
+		Carline:      "CX-90 PHEV Premium Plus",
+		ModelCode:    "KKEH",
+		Powertrain:   PowertrainPHEV,
+		EconnectType: 1,
+	}, vehicle)
+}
+
+// TestGetPrimaryVehicle_NoVehicles tests that an account without vehicles is an error.
+func TestGetPrimaryVehicle_NoVehicles(t *testing.T) {
+	t.Parallel()
+	server := createSuccessServer(t, "/"+EndpointGetVecBaseInfos, map[string]any{"resultCode": "200S00", "vecBaseInfos": []any{}})
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+
+	_, err := client.GetPrimaryVehicle(context.Background())
+	require.EqualError(t, err, "no vehicles found")
+}
+
 // TestGetVehicleStatus tests getting vehicle status.
 func TestGetVehicleStatus(t *testing.T) {
 	t.Parallel()
@@ -163,3 +217,46 @@ func TestGetEVVehicleStatus_Error(t *testing.T) {
 	expectedError := "failed to get EV vehicle status: result code 500E00"
 	assert.Equal(t, expectedError, err.Error())
 }
+
+// TestGetTyped tests unmarshaling a response into a caller's own struct.
+func TestGetTyped(t *testing.T) {
+	t.Parallel()
+	type nextServiceInfo struct {
+		ResultCode     string `json:"resultCode"`
+		NextServiceKm  int    `json:"nextServiceKm"`
+		OilLifePercent int    `json:"oilLifePercent"`
+		Dealer         struct {
+			Name string `json:"name"`
+		} `json:"dealer"`
+	}
+
+	responseData := map[string]any{
+		"resultCode":     "200S00",
+		"nextServiceKm":  4200,
+		"oilLifePercent": 37,
+		"dealer":         map[string]any{"name": "Bay Mazda"},
+	}
+	server := createSuccessServer(t, "/remoteServices/getNextService/v4", responseData)
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+
+	got, err := GetTyped[nextServiceInfo](context.Background(), client, "remoteServices/getNextService/v4", map[string]any{"internalvin": "INTERNAL123"})
+	require.NoError(t, err)
+	assert.Equal(t, 4200, got.NextServiceKm)
+	assert.Equal(t, 37, got.OilLifePercent)
+	assert.Equal(t, "Bay Mazda", got.Dealer.Name)
+}
+
+// TestGetTyped_Error tests that a failed result code is an error.
+func TestGetTyped_Error(t *testing.T) {
+	t.Parallel()
+	server := createErrorServer(t, "500E00", "Internal error")
+	defer server.Close()
+
+	client := createTestClient(t, server.URL)
+
+	_, err := GetTyped[map[string]any](context.Background(), client, EndpointGetVehicleStatus, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "result code 500E00")
+}
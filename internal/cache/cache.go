@@ -14,6 +14,7 @@ type TokenCache struct {
 	AccessTokenExpirationTs int64  `json:"access_token_expiration_ts"`
 	EncKey                  string `json:"enc_key"`
 	SignKey                 string `json:"sign_key"`
+	KeysFetchedAtTs         int64  `json:"keys_fetched_at_ts,omitempty"` // zero if unknown
 }
 
 // IsTokenValid checks if a token is present and not expired.
@@ -97,6 +98,19 @@ func SaveTo(cache *TokenCache, path string) error {
 	return nil
 }
 
+// RemoveFrom deletes the token cache at path, reporting whether there was one.
+func RemoveFrom(path string) (bool, error) {
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to remove cache file: %w", err)
+	}
+
+	return true, nil
+}
+
 // getCachePath returns the path to the token cache file.
 func getCachePath() (string, error) {
 	homeDir, err := os.UserHomeDir()
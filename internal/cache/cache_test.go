@@ -78,6 +78,7 @@ func TestSaveAndLoad(t *testing.T) {
 		AccessTokenExpirationTs: time.Now().Unix() + 3600,
 		EncKey:                  "test-enc-key-456",
 		SignKey:                 "test-sign-key-789",
+		KeysFetchedAtTs:         time.Now().Unix() - 60,
 	}
 
 	// Test SaveTo
@@ -99,6 +100,7 @@ func TestSaveAndLoad(t *testing.T) {
 	assert.Equal(t, testCache.AccessTokenExpirationTs, loadedCache.AccessTokenExpirationTs)
 	assert.Equal(t, testCache.EncKey, loadedCache.EncKey)
 	assert.Equal(t, testCache.SignKey, loadedCache.SignKey)
+	assert.Equal(t, testCache.KeysFetchedAtTs, loadedCache.KeysFetchedAtTs)
 }
 
 func TestLoad_NoCache(t *testing.T) {
@@ -426,3 +428,19 @@ func TestCachePersistence_EmptyHomeDir(t *testing.T) {
 	err := Save(cache)
 	require.Error(t, err, "Expected error when HOME is empty, got nil")
 }
+
+func TestRemoveFrom(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, SaveTo(&TokenCache{AccessToken: "test-token"}, path))
+
+	removed, err := RemoveFrom(path)
+	require.NoError(t, err)
+	assert.True(t, removed)
+	assert.NoFileExists(t, path)
+
+	// Removing a missing cache is not an error.
+	removed, err = RemoveFrom(path)
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
@@ -0,0 +1,24 @@
+package cache
+
+import "fmt"
+
+// AddressCache holds reverse-geocoded addresses keyed by AddressKey, so that a
+// parked vehicle is looked up only once.
+type AddressCache map[string]string
+
+// AddressKey is the cache key for a pair of coordinates. They are rounded to four
+// decimals (about 11 m), so GPS jitter doesn't cause a new lookup.
+func AddressKey(latitude, longitude float64) string {
+	return fmt.Sprintf("%.4f,%.4f", latitude, longitude)
+}
+
+// LoadAddressesFrom reads the address cache from the given path.
+// Returns an empty cache if the file doesn't exist yet.
+func LoadAddressesFrom(path string) (AddressCache, error) {
+	return loadJSONFile[AddressCache](path, "address cache")
+}
+
+// SaveAddressesTo writes the address cache to the given path.
+func SaveAddressesTo(addresses AddressCache, path string) error {
+	return saveJSONFile(addresses, path, "address cache")
+}
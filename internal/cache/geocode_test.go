@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressCache_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "geocode.json")
+
+	addresses := AddressCache{AddressKey(37.77493, -122.41942): "1 Market Street, San Francisco"}
+	require.NoError(t, SaveAddressesTo(addresses, path))
+
+	loaded, err := LoadAddressesFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, addresses, loaded)
+}
+
+func TestLoadAddressesFrom_NoFile(t *testing.T) {
+	t.Parallel()
+	addresses, err := LoadAddressesFrom(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, addresses)
+	assert.NotNil(t, addresses)
+}
+
+func TestAddressKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "37.7749,-122.4194", AddressKey(37.77493, -122.41942))
+	// Nearby fixes share a key.
+	assert.Equal(t, AddressKey(37.77491, -122.41939), AddressKey(37.77494, -122.41941))
+}
@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// loadJSONFile reads a JSON object stored alongside the token cache, such as the
+// status state. Returns an empty map if the file doesn't exist yet. name
+// describes the file in errors, e.g. "state".
+func loadJSONFile[M ~map[K]V, K comparable, V any](path, name string) (M, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return M{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to read %s file: %w", name, err)
+	}
+
+	values := M{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s file: %w", name, err)
+	}
+
+	return values, nil
+}
+
+// saveJSONFile writes v to path as indented JSON, readable only by the user,
+// creating the cache directory if needed.
+func saveJSONFile(v any, path, name string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s file: %w", name, err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s file: %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package cache
+
+import "time"
+
+// StatusSnapshot records the readings of the last status shown by
+// status --diff-previous, to compare the next one against. Readings the vehicle
+// didn't report are nil.
+type StatusSnapshot struct {
+	CheckedAt    time.Time `json:"checked_at"`
+	OdometerKm   *float64  `json:"odometer_km,omitempty"`
+	BatteryLevel *float64  `json:"battery_level,omitempty"`
+	FuelLevel    *float64  `json:"fuel_level,omitempty"`
+	Latitude     *float64  `json:"latitude,omitempty"`
+	Longitude    *float64  `json:"longitude,omitempty"`
+}
+
+// SnapshotStore holds the last StatusSnapshot for each vehicle, keyed by VIN.
+type SnapshotStore map[string]StatusSnapshot
+
+// LoadSnapshotsFrom reads the snapshot store from the given path.
+// Returns an empty store if the file doesn't exist yet.
+func LoadSnapshotsFrom(path string) (SnapshotStore, error) {
+	return loadJSONFile[SnapshotStore](path, "snapshot")
+}
+
+// SaveSnapshotsTo writes the snapshot store to the given path.
+func SaveSnapshotsTo(store SnapshotStore, path string) error {
+	return saveJSONFile(store, path, "snapshot")
+}
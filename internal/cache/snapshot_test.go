@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotStore_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "snapshots.json")
+	odometer, battery := 12345.6, 80.0
+
+	store := SnapshotStore{"JM3KKEHC1R0000001": {
+		CheckedAt:    time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC),
+		OdometerKm:   &odometer,
+		BatteryLevel: &battery,
+	}}
+	require.NoError(t, SaveSnapshotsTo(store, path))
+
+	loaded, err := LoadSnapshotsFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, store, loaded)
+}
+
+func TestLoadSnapshotsFrom_Errors(t *testing.T) {
+	t.Parallel()
+	store, err := LoadSnapshotsFrom(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.NotNil(t, store)
+	assert.Empty(t, store)
+
+	corrupt := filepath.Join(t.TempDir(), "snapshots.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0600))
+	_, err = LoadSnapshotsFrom(corrupt)
+	require.ErrorContains(t, err, "failed to parse snapshot file")
+}
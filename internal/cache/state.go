@@ -0,0 +1,21 @@
+package cache
+
+// StatusState records the freshness markers of the last vehicle status seen.
+type StatusState struct {
+	OccurrenceDate    string `json:"occurrence_date"`
+	PositionTimestamp string `json:"position_timestamp"`
+}
+
+// StateStore holds the last seen StatusState for each vehicle, keyed by VIN.
+type StateStore map[string]StatusState
+
+// LoadStateFrom reads the status state store from the given path.
+// Returns an empty store if the file doesn't exist yet.
+func LoadStateFrom(path string) (StateStore, error) {
+	return loadJSONFile[StateStore](path, "state")
+}
+
+// SaveStateTo writes the status state store to the given path.
+func SaveStateTo(store StateStore, path string) error {
+	return saveJSONFile(store, path, "state")
+}
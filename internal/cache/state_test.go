@@ -0,0 +1,46 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateStore_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	statePath := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	store := StateStore{
+		"JM3AAAAAAAAAA0001": {OccurrenceDate: "20250115120000", PositionTimestamp: "20250115115900"},
+		"JM3AAAAAAAAAA0002": {OccurrenceDate: "20250114080000"},
+	}
+	require.NoError(t, SaveStateTo(store, statePath))
+
+	loaded, err := LoadStateFrom(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, store, loaded)
+
+	info, err := os.Stat(statePath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestLoadStateFrom_NoFile(t *testing.T) {
+	t.Parallel()
+	store, err := LoadStateFrom(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Empty(t, store)
+	assert.NotNil(t, store)
+}
+
+func TestLoadStateFrom_InvalidJSON(t *testing.T) {
+	t.Parallel()
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(statePath, []byte("not json"), 0600))
+
+	_, err := LoadStateFrom(statePath)
+	require.Error(t, err)
+}
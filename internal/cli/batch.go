@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// batchCommands maps the commands allowed in mcs batch to their constructors.
+func batchCommands() map[string]func() *cobra.Command {
+	return map[string]func() *cobra.Command{
+		"status":  NewStatusCmd,
+		"events":  NewEventsCmd,
+		"refresh": NewRefreshCmd,
+		"lock":    NewLockCmd,
+		"unlock":  NewUnlockCmd,
+		"start":   NewStartCmd,
+		"stop":    NewStopCmd,
+		"charge":  NewChargeCmd,
+		"climate": NewClimateCmd,
+		"watch":   NewWatchCmd,
+	}
+}
+
+// NewBatchCmd creates the batch command.
+func NewBatchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "batch [commands]",
+		Short: "Run several commands with a single login",
+		Long: `Run several commands against one authenticated session, which is faster
+than running mcs once per command. Commands are separated by semicolons or
+newlines and read from the arguments, or from stdin when none are given.
+Lines starting with # are ignored.
+
+Each command is written as on the command line without the leading "mcs",
+e.g. "status battery --json". Arguments are split on whitespace; quoting is
+not supported. Global flags such as --profile apply to the whole batch and
+must be given before "batch".
+
+Commands run in order and the batch stops at the first failure.
+
+Allowed commands: ` + strings.Join(batchCommandNames(), ", ") + `.`,
+		Example: `  # Refresh the status, show the battery as JSON, then lock
+  mcs batch "status --refresh; status battery --json; lock"
+
+  # Read commands from a file
+  mcs batch < morning.txt`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := strings.Join(args, " ")
+			if len(args) == 0 {
+				data, err := io.ReadAll(cmd.InOrStdin())
+				if err != nil {
+					return fmt.Errorf("failed to read commands: %w", err)
+				}
+				input = string(data)
+			}
+			commands, err := parseBatch(input)
+			if err != nil {
+				return err
+			}
+
+			return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+				ctx = contextWithVehicleSession(ctx, &vehicleSession{client: client, vehicleInfo: vehicleInfo})
+
+				return runBatch(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), commands)
+			})
+		},
+		SilenceUsage: true,
+	}
+}
+
+// batchCommandNames returns the commands allowed in mcs batch, sorted.
+func batchCommandNames() []string {
+	names := make([]string, 0, len(batchCommands()))
+	for name := range batchCommands() {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// parseBatch splits batch input into commands and their arguments, rejecting
+// unknown commands before anything runs.
+func parseBatch(input string) ([][]string, error) {
+	commands := batchCommands()
+	var parsed [][]string
+	for line := range strings.FieldsFuncSeq(input, func(r rune) bool { return r == ';' || r == '\n' }) {
+		args := strings.Fields(line)
+		if len(args) == 0 || strings.HasPrefix(args[0], "#") {
+			continue
+		}
+		if _, ok := commands[args[0]]; !ok {
+			return nil, fmt.Errorf("unknown batch command %q (allowed: %s)", args[0], strings.Join(batchCommandNames(), ", "))
+		}
+		parsed = append(parsed, args)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no commands given")
+	}
+
+	return parsed, nil
+}
+
+// runBatch runs each command in order with ctx, stopping at the first failure.
+// Vehicle commands reuse the session attached to ctx instead of logging in.
+// Each runs under a fresh root named mcs, so that its command path, e.g.
+// "mcs charge start", matches the normal CLI in results and notifications.
+func runBatch(ctx context.Context, out, errOut io.Writer, commands [][]string) error {
+	for i, args := range commands {
+		root := &cobra.Command{Use: "mcs", SilenceErrors: true, SilenceUsage: true}
+		root.AddCommand(batchCommands()[args[0]]())
+		root.SetArgs(args)
+		root.SetOut(out)
+		root.SetErr(errOut)
+		if err := root.ExecuteContext(ctx); err != nil {
+			return fmt.Errorf("batch command %d (%s): %w", i+1, strings.Join(args, " "), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBatch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		input       string
+		want        [][]string
+		expectError string
+	}{
+		{
+			name:  "semicolons",
+			input: "status --refresh; status battery --json;lock",
+			want:  [][]string{{"status", "--refresh"}, {"status", "battery", "--json"}, {"lock"}},
+		},
+		{
+			name:  "newlines, blank lines, and comments",
+			input: "# morning routine\nstatus fuel\n\n  climate on  \n",
+			want:  [][]string{{"status", "fuel"}, {"climate", "on"}},
+		},
+		{
+			name:        "unknown command",
+			input:       "status; reauth",
+			expectError: `unknown batch command "reauth"`,
+		},
+		{
+			name:        "nested batch",
+			input:       "batch lock",
+			expectError: `unknown batch command "batch"`,
+		},
+		{
+			name:        "empty",
+			input:       " ;\n# nothing\n",
+			expectError: "no commands given",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseBatch(tt.input)
+			if tt.expectError != "" {
+				require.ErrorContains(t, err, tt.expectError)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// newBatchSessionContext returns a context whose vehicle commands use a client that
+// replays one vehicle status response per entry in statuses. Any other request,
+// including a login, fails.
+func newBatchSessionContext(t *testing.T, statuses ...*api.VehicleStatusResponse) context.Context {
+	t.Helper()
+	const encKey = "batchtestkey1234"
+
+	var fixture apitest.Fixture
+	for _, status := range statuses {
+		payload, err := json.Marshal(status)
+		require.NoError(t, err)
+		fixture.Interactions = append(fixture.Interactions, apitest.Interaction{
+			Method:  http.MethodPost,
+			Path:    "/prod/" + api.EndpointGetVehicleStatus,
+			Body:    `{"internaluserid":"__INTERNAL_ID__","internalvin":"INTERNAL123","limit":1,"offset":0,"vecinfotype":"0"}`,
+			Status:  http.StatusOK,
+			Payload: payload,
+		})
+	}
+
+	client, err := api.NewClient("test@example.com", "password", api.RegionMNAO)
+	require.NoError(t, err)
+	client.SetCachedCredentials("test-token", 9999999999, encKey, "batchtestsign123")
+	client.SetTransport(apitest.NewReplayer(fixture, func() string { return encKey }))
+
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{CacheFile: t.TempDir() + "/token.json"})
+
+	return contextWithVehicleSession(ctx, &vehicleSession{
+		client:      client,
+		vehicleInfo: VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "INTERNAL123", VIN: "JM3KKEHC1R0123456"}},
+	})
+}
+
+// TestRunBatch tests that a two-command batch shares one session.
+func TestRunBatch(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	ctx := newBatchSessionContext(t,
+		apitest.NewVehicleStatus().WithWindowPositions(0, 0, 40, 0).Build(),
+		apitest.NewVehicleStatus().Build(),
+	)
+
+	var out, errOut bytes.Buffer
+	err := runBatch(ctx, &out, &errOut, [][]string{{"status", "windows"}, {"status", "fuel", "--json"}})
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "WINDOWS: Rear left 40%")
+	assert.Contains(t, out.String(), `"fuel_level"`)
+}
+
+// TestRunBatch_StopsAtFirstFailure tests that later commands don't run after a failure.
+func TestRunBatch_StopsAtFirstFailure(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	ctx := newBatchSessionContext(t,
+		apitest.NewVehicleStatus().WithWindowPositions(0, 0, 40, 0).Build(),
+	)
+
+	var out, errOut bytes.Buffer
+	err := runBatch(ctx, &out, &errOut, [][]string{{"status", "windows", "--check"}, {"status", "fuel"}})
+	require.ErrorContains(t, err, "batch command 1 (status windows --check): 1 window(s) open")
+	assert.NotContains(t, out.String(), "FUEL")
+}
+
+// TestRunBatch_CommandPath tests that batch commands name themselves as they
+// would on the command line, e.g. "charge start" rather than "start".
+func TestRunBatch_CommandPath(t *testing.T) {
+	t.Parallel()
+	ctx := newBatchSessionContext(t)
+
+	var out, errOut bytes.Buffer
+	require.NoError(t, runBatch(ctx, &out, &errOut, [][]string{{"charge", "start", "--explain"}}))
+	assert.Contains(t, out.String(), "mcs charge start would make these API calls:")
+}
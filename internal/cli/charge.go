@@ -2,7 +2,9 @@ package cli
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
@@ -14,23 +16,39 @@ func NewChargeCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "charge",
 		Short: "Control vehicle charging",
-		Long:  `Control vehicle charging (start/stop).`,
+		Long:  `Control vehicle charging (start/stop) and show the charging schedule.`,
 		Example: `  # Start charging the vehicle battery
   mcs charge start
 
   # Stop charging the vehicle battery
-  mcs charge stop`,
+  mcs charge stop
+
+  # Show the configured charging schedule
+  mcs charge schedule
+
+  # Block until the battery is full, then shut down
+  mcs charge wait-for-full && shutdown`,
 	}
 
 	cmd.AddCommand(NewChargeStartCmd())
 	cmd.AddCommand(NewChargeStopCmd())
+	cmd.AddCommand(NewChargeScheduleCmd())
+	cmd.AddCommand(NewChargeWaitForFullCmd())
 
 	return cmd
 }
 
+// plugPollInterval is the time between status checks while waiting for the
+// charger or for a charge to finish. It is longer than DefaultPollInterval
+// because the wait can last hours.
+const plugPollInterval = 30 * time.Second
+
 // NewChargeStartCmd creates the charge start subcommand.
 func NewChargeStartCmd() *cobra.Command {
-	return buildConfirmableCommand(CommandSpec{
+	var waitForPlug bool
+	var plugTimeout int
+
+	cmd := buildConfirmableCommand(CommandSpec{
 		Use:   "start",
 		Short: "Start charging",
 		Long:  `Start charging the vehicle battery.`,
@@ -44,14 +62,48 @@ func NewChargeStartCmd() *cobra.Command {
   mcs charge start --confirm=false
 
   # Start charging and wait up to 60 seconds for confirmation
-  mcs charge start --confirm-wait 60`,
+  mcs charge start --confirm-wait 60
+
+  # Wait until the charger is plugged in, then start charging
+  mcs charge start --wait-for-plug --plug-timeout 7200`,
 		ConfirmFlagUsage: "wait for confirmation that charging has started",
+		Prepare: func(ctx context.Context, config *ConfirmableCommandConfig) error {
+			if waitForPlug && plugTimeout <= 0 {
+				return fmt.Errorf("--plug-timeout must be positive, got %d", plugTimeout)
+			}
+
+			return nil
+		},
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.ChargeStart(ctx, string(internalVIN))
+			Before: &BeforeStep{
+				Run: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN) error {
+					if !waitForPlug {
+						return nil
+					}
+
+					return waitForPluggedIn(ctx, out, &clientAdapter{Client: client}, internalVIN, time.Duration(plugTimeout)*time.Second, plugPollInterval)
+				},
+				Plan: func() []PlanStep {
+					if !waitForPlug {
+						return nil
+					}
+
+					return []PlanStep{pollStep(api.EndpointGetEVVehicleStatus, plugPollInterval, time.Duration(plugTimeout)*time.Second, "the charger is plugged in")}
+				},
 			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForCharging(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+			Skip: &SkipCheck{
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Check: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) (string, error) {
+					return skipIfCharging(ctx, &clientAdapter{Client: client}, internalVIN, true)
+				},
+			},
+			Actions: []ControlStep{{Endpoint: api.EndpointChargeStart}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					return waitForCharging(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
 			InitialDelay:  ConfirmationInitialDelay,
 			SuccessMsg:    "Charging started successfully",
@@ -61,6 +113,11 @@ func NewChargeStartCmd() *cobra.Command {
 			TimeoutSuffix: "confirmation timeout",
 		},
 	})
+
+	cmd.Flags().BoolVar(&waitForPlug, "wait-for-plug", false, "wait until the charger is plugged in before starting")
+	cmd.Flags().IntVar(&plugTimeout, "plug-timeout", 3600, "with --wait-for-plug, max seconds to wait for the charger")
+
+	return cmd
 }
 
 // NewChargeStopCmd creates the charge stop subcommand.
@@ -82,11 +139,19 @@ func NewChargeStopCmd() *cobra.Command {
   mcs charge stop --confirm-wait 60`,
 		ConfirmFlagUsage: "wait for confirmation that charging has stopped",
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.ChargeStop(ctx, string(internalVIN))
+			Skip: &SkipCheck{
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Check: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) (string, error) {
+					return skipIfCharging(ctx, &clientAdapter{Client: client}, internalVIN, false)
+				},
 			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForNotCharging(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+			Actions: []ControlStep{{Endpoint: api.EndpointChargeStop}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					return waitForNotCharging(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
 			InitialDelay:  ConfirmationInitialDelay,
 			SuccessMsg:    "Charging stopped successfully",
@@ -97,3 +162,252 @@ func NewChargeStopCmd() *cobra.Command {
 		},
 	})
 }
+
+// NewChargeScheduleCmd creates the charge schedule subcommand.
+func NewChargeScheduleCmd() *cobra.Command {
+	var jsonOutput, jsonCompact bool
+
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Show charging schedule",
+		Long:  `Show the scheduled charging windows configured on the vehicle.`,
+		Example: `  # Show the charging schedule
+  mcs charge schedule
+
+  # Example output:
+  # CHARGE SCHEDULE:
+  #   23:00 - 06:00 (enabled)
+
+  # Show the charging schedule in JSON format
+  mcs charge schedule --json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				evStatus, err := client.GetEVVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get EV status: %w", err)
+				}
+
+				windows, err := evStatus.GetChargeSchedule()
+				if err != nil {
+					return fmt.Errorf("failed to get charge schedule: %w", err)
+				}
+
+				output, err := formatChargeSchedule(windows, newOutputFormat(cmd.Context(), jsonOutput, jsonCompact))
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
+
+				return nil
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+
+	return cmd
+}
+
+// NewChargeWaitForFullCmd creates the charge wait-for-full subcommand.
+func NewChargeWaitForFullCmd() *cobra.Command {
+	var target int
+	var timeout int
+
+	cmd := &cobra.Command{
+		Use:   "wait-for-full",
+		Short: "Wait until charging completes",
+		Long: `Wait until the vehicle reports the charge is complete or the battery reaches
+--target percent, printing the battery level as it rises. Charging that stops on
+its own while the charger is still plugged in, as it does at the vehicle's charge
+limit, also counts as done. The status is checked every 30 seconds without waking
+the vehicle.
+
+Fails straight away if the charger is unplugged or charging is interrupted, and
+exits with code 3 if the target isn't reached within --timeout.`,
+		Example: `  # Wait for a full battery
+  mcs charge wait-for-full
+
+  # Wait until the battery reaches 80%, for up to 4 hours
+  mcs charge wait-for-full --target 80 --timeout 14400
+
+  # Shut down once charging is done
+  mcs charge wait-for-full && shutdown`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if target < 1 || target > 100 {
+				return fmt.Errorf("--target must be between 1 and 100, got %d", target)
+			}
+			if timeout <= 0 {
+				return fmt.Errorf("--timeout must be positive, got %d", timeout)
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				return waitForFullCharge(ctx, cmd.OutOrStdout(), &clientAdapter{Client: client}, internalVIN, float64(target),
+					time.Duration(timeout)*time.Second, plugPollInterval)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().IntVar(&target, "target", 100, "battery percent to wait for")
+	cmd.Flags().IntVar(&timeout, "timeout", 28800, "max seconds to wait before giving up")
+
+	return cmd
+}
+
+// waitForFullCharge polls the EV status until the charge is complete, the
+// battery reaches target percent, or charging that was seen running stops while
+// the charger is still plugged in. The last covers the vehicle's own charge
+// limit, which the status doesn't report, and vehicles that don't report
+// ChargeStatusComplete, which is unverified. Like waitForPluggedIn it doesn't
+// request a refresh. Each new battery level is reported on the info writer, e.g.
+// "Charging: 72% (~40m to full)". It returns an error straight away once the
+// charger is unplugged or charging is interrupted, and a timeoutError if the
+// target isn't reached in time.
+func waitForFullCharge(
+	ctx context.Context,
+	out io.Writer,
+	client vehicleStatusGetter,
+	internalVIN api.InternalVIN,
+	target float64,
+	timeout time.Duration,
+	pollInterval time.Duration,
+) error {
+	progress := infoWriter(ctx, out)
+	var batteryInfo api.BatteryInfo
+	lastLevel := -1.0
+	sawCharging, stopped := false, false
+	// failed is set when charging can't go on by itself, ending the wait early.
+	var failed error
+
+	checkFunc := func() (bool, error) {
+		evStatus, err := client.GetEVVehicleStatus(ctx, internalVIN)
+		if err != nil {
+			return false, err
+		}
+		batteryInfo, err = evStatus.GetBatteryInfo()
+		if err != nil {
+			return false, err
+		}
+
+		if batteryInfo.ChargeState == api.ChargeStateComplete || batteryInfo.BatteryLevel >= target {
+			return true, nil
+		}
+		switch {
+		case !batteryInfo.PluggedIn:
+			failed = fmt.Errorf("charger unplugged at %.0f%%, before reaching %.0f%%", batteryInfo.BatteryLevel, target)
+
+			return true, nil
+		case batteryInfo.ChargeState == api.ChargeStateInterrupted:
+			failed = fmt.Errorf("charging interrupted at %.0f%%, before reaching %.0f%%", batteryInfo.BatteryLevel, target)
+
+			return true, nil
+		}
+		if sawCharging && batteryInfo.PluggedIn && batteryInfo.ChargeState == api.ChargeStateNotCharging {
+			stopped = true
+
+			return true, nil
+		}
+		sawCharging = sawCharging || batteryInfo.Charging
+		if batteryInfo.BatteryLevel != lastLevel {
+			lastLevel = batteryInfo.BatteryLevel
+			_, _ = fmt.Fprint(progress, clearProgressLine)
+			_, _ = fmt.Fprintln(progress, formatChargeProgress(batteryInfo))
+		}
+
+		return false, nil
+	}
+
+	label := fmt.Sprintf("charge to %.0f%%", target)
+	result := pollUntilConditionWithProgress(ctx, progress, checkFunc, timeout, fixedInterval(pollInterval), label, "Waiting for "+label)
+	if result.err != nil {
+		return result.err
+	}
+	if !result.success {
+		return &timeoutError{message: fmt.Sprintf("battery not charged to %.0f%% before timeout", target)}
+	}
+	if failed != nil {
+		return failed
+	}
+
+	switch {
+	case batteryInfo.ChargeState == api.ChargeStateComplete:
+		_, _ = fmt.Fprintf(out, "Charge complete at %.0f%%\n", batteryInfo.BatteryLevel)
+	case stopped:
+		_, _ = fmt.Fprintf(out, "Charging stopped at %.0f%%\n", batteryInfo.BatteryLevel)
+	default:
+		_, _ = fmt.Fprintf(out, "Battery reached %.0f%%\n", batteryInfo.BatteryLevel)
+	}
+
+	return nil
+}
+
+// formatChargeProgress formats a wait-for-full progress line, e.g.
+// "Charging: 72% (~40m to full)" or "Not charging: 72% (plugged in)".
+func formatChargeProgress(batteryInfo api.BatteryInfo) string {
+	if !batteryInfo.Charging {
+		state := "unplugged"
+		if batteryInfo.PluggedIn {
+			state = "plugged in"
+		}
+
+		return fmt.Sprintf("Not charging: %.0f%% (%s)", batteryInfo.BatteryLevel, state)
+	}
+
+	line := fmt.Sprintf("Charging: %.0f%%", batteryInfo.BatteryLevel)
+	if chargeTime := formatChargeTime(batteryInfo.ChargeTimeACMin, batteryInfo.ChargeTimeQBCMin); chargeTime != "" {
+		line += " (" + chargeTime + ")"
+	}
+
+	return line
+}
+
+// formatScheduleTime converts an API HHmm time to HH:MM, returning the input unchanged if malformed.
+func formatScheduleTime(hhmm string) string {
+	if len(hhmm) != 4 {
+		return hhmm
+	}
+
+	return hhmm[:2] + ":" + hhmm[2:]
+}
+
+// chargeScheduleToMap converts charging windows to a map for JSON output.
+func chargeScheduleToMap(windows []api.ChargeWindow) map[string]any {
+	entries := make([]map[string]any, 0, len(windows))
+	for _, window := range windows {
+		entries = append(entries, map[string]any{
+			"start_time": formatScheduleTime(window.StartTime),
+			"end_time":   formatScheduleTime(window.EndTime),
+			"enabled":    window.Enabled,
+		})
+	}
+
+	return map[string]any{
+		"configured": len(windows) > 0,
+		"windows":    entries,
+	}
+}
+
+// formatChargeSchedule formats the charging schedule for display.
+func formatChargeSchedule(windows []api.ChargeWindow, format outputFormat) (string, error) {
+	if format.isJSON() {
+		return toJSON(chargeScheduleToMap(windows), format)
+	}
+
+	if len(windows) == 0 {
+		return "CHARGE SCHEDULE: No schedule configured", nil
+	}
+
+	lines := []string{"CHARGE SCHEDULE:"}
+	for _, window := range windows {
+		state := "disabled"
+		if window.Enabled {
+			state = "enabled"
+		}
+		lines = append(lines, fmt.Sprintf("  %s - %s (%s)",
+			formatScheduleTime(window.StartTime), formatScheduleTime(window.EndTime), state))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
@@ -1,6 +1,17 @@
 package cli
 
-import "testing"
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 // TestChargeCommand tests the charge command.
 func TestChargeCommand(t *testing.T) {
@@ -13,5 +24,230 @@ func TestChargeCommand(t *testing.T) {
 func TestChargeCommand_Subcommands(t *testing.T) {
 	t.Parallel()
 	cmd := NewChargeCmd()
-	assertSubcommandsExist(t, cmd, []string{"start", "stop"})
+	assertSubcommandsExist(t, cmd, []string{"start", "stop", "schedule", "wait-for-full"})
+}
+
+// TestChargeStart_PlugTimeout tests that --plug-timeout is checked before
+// logging in.
+func TestChargeStart_PlugTimeout(t *testing.T) {
+	t.Parallel()
+	cfg := testCLIConfig()
+	cfg.CacheFile = t.TempDir() + "/token.json"
+	rootCmd := NewRootCmd(cfg)
+	rootCmd.AddCommand(NewChargeCmd())
+	rootCmd.SetArgs([]string{"--config", t.TempDir() + "/missing.toml", "charge", "start", "--wait-for-plug", "--plug-timeout", "0"})
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	require.EqualError(t, rootCmd.Execute(), "--plug-timeout must be positive, got 0")
+}
+
+// TestFormatChargeSchedule tests charging schedule formatting.
+func TestFormatChargeSchedule(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		windows        []api.ChargeWindow
+		expectedOutput string
+	}{
+		{
+			name:           "no schedule configured",
+			windows:        []api.ChargeWindow{},
+			expectedOutput: "CHARGE SCHEDULE: No schedule configured",
+		},
+		{
+			name: "two windows",
+			windows: []api.ChargeWindow{
+				{StartTime: "2300", EndTime: "0600", Enabled: true},
+				{StartTime: "1200", EndTime: "1400", Enabled: false},
+			},
+			expectedOutput: "CHARGE SCHEDULE:\n  23:00 - 06:00 (enabled)\n  12:00 - 14:00 (disabled)",
+		},
+		{
+			name: "malformed time is shown as-is",
+			windows: []api.ChargeWindow{
+				{StartTime: "23", EndTime: "0600", Enabled: true},
+			},
+			expectedOutput: "CHARGE SCHEDULE:\n  23 - 06:00 (enabled)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := formatChargeSchedule(tt.windows, outputText)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, result)
+		})
+	}
+}
+
+// TestFormatChargeSchedule_JSON tests charging schedule JSON formatting.
+func TestFormatChargeSchedule_JSON(t *testing.T) {
+	t.Parallel()
+	result, err := formatChargeSchedule([]api.ChargeWindow{
+		{StartTime: "2300", EndTime: "0600", Enabled: true},
+	}, outputJSON)
+	require.NoError(t, err)
+
+	data := parseJSONToMap(t, result)
+	assertMapValue(t, data, "configured", true)
+
+	windows, ok := data["windows"].([]any)
+	require.True(t, ok)
+	require.Len(t, windows, 1)
+	window := windows[0].(map[string]any)
+	assertMapValue(t, window, "start_time", "23:00")
+	assertMapValue(t, window, "end_time", "06:00")
+	assertMapValue(t, window, "enabled", true)
+
+	empty, err := formatChargeSchedule([]api.ChargeWindow{}, outputJSON)
+	require.NoError(t, err)
+	assertMapValue(t, parseJSONToMap(t, empty), "configured", false)
+}
+
+// chargingStatus returns an EV status charging at level percent, or reporting a
+// complete charge.
+func chargingStatus(level float64, complete bool) *api.EVVehicleStatusResponse {
+	evStatus := apitest.NewEVVehicleStatus().WithCharging(!complete).Build()
+	chargeInfo := &evStatus.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo
+	chargeInfo.SmaphSOC = level
+	chargeInfo.MaxChargeMinuteAC = (100 - level) * 5
+	chargeInfo.MaxChargeMinuteQBC = 0
+	if complete {
+		chargeInfo.ChargeStatusSub = api.ChargeStatusComplete
+	}
+
+	return evStatus
+}
+
+// stoppedStatus returns an EV status with the charger plugged in but not charging
+// and the battery at level percent.
+func stoppedStatus(level float64) *api.EVVehicleStatusResponse {
+	evStatus := chargingStatus(level, false)
+	evStatus.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.ChargeStatusSub = api.ChargeStatusNotCharging
+
+	return evStatus
+}
+
+// unpluggedStatus returns an EV status with the charger unplugged and the
+// battery at level percent.
+func unpluggedStatus(level float64) *api.EVVehicleStatusResponse {
+	evStatus := stoppedStatus(level)
+	evStatus.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.ChargerConnectorFitting = api.ChargerDisconnected
+
+	return evStatus
+}
+
+// interruptedStatus returns an EV status with the charger plugged in, charging
+// interrupted, and the battery at level percent.
+func interruptedStatus(level float64) *api.EVVehicleStatusResponse {
+	evStatus := chargingStatus(level, false)
+	evStatus.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.ChargeStatusSub = api.ChargeStatusInterrupted
+
+	return evStatus
+}
+
+// TestWaitForFullCharge tests waiting for the battery to reach the target or
+// the charge to complete.
+func TestWaitForFullCharge(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		statuses     []*api.EVVehicleStatusResponse
+		target       float64
+		wantOutput   string
+		wantProgress []string
+		wantTimeout  bool
+		wantErr      string
+	}{
+		{
+			name:         "reaches target",
+			statuses:     []*api.EVVehicleStatusResponse{chargingStatus(64, false), chargingStatus(72, false), chargingStatus(72, false), chargingStatus(81, false)},
+			target:       80,
+			wantOutput:   "Battery reached 81%\n",
+			wantProgress: []string{"Charging: 64% (~3h to full)\n", "Charging: 72% (~2h 20m to full)\n"},
+		},
+		{
+			name:       "charge complete below target",
+			statuses:   []*api.EVVehicleStatusResponse{chargingStatus(90, false), chargingStatus(95, true)},
+			target:     100,
+			wantOutput: "Charge complete at 95%\n",
+		},
+		{
+			name:       "stops at the vehicle's charge limit",
+			statuses:   []*api.EVVehicleStatusResponse{chargingStatus(84, false), stoppedStatus(85)},
+			target:     100,
+			wantOutput: "Charging stopped at 85%\n",
+		},
+		{
+			name:     "unplugged",
+			statuses: []*api.EVVehicleStatusResponse{chargingStatus(60, false), unpluggedStatus(61)},
+			target:   100,
+			wantErr:  "charger unplugged at 61%, before reaching 100%",
+		},
+		{
+			name:     "interrupted",
+			statuses: []*api.EVVehicleStatusResponse{interruptedStatus(62)},
+			target:   80,
+			wantErr:  "charging interrupted at 62%, before reaching 80%",
+		},
+		{
+			name:        "times out",
+			statuses:    []*api.EVVehicleStatusResponse{chargingStatus(50, false)},
+			target:      100,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			mockClient := &mockClientForConfirm{
+				getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+					evStatus := tt.statuses[min(calls, len(tt.statuses)-1)]
+					calls++
+
+					return evStatus, nil
+				},
+				refreshVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) error {
+					t.Error("waiting for the charge should not refresh the vehicle")
+
+					return nil
+				},
+			}
+			var buf bytes.Buffer
+
+			err := waitForFullCharge(context.Background(), &buf, mockClient, api.InternalVIN("test-vin"), tt.target, 200*time.Millisecond, 10*time.Millisecond)
+
+			if tt.wantTimeout {
+				require.EqualError(t, err, "battery not charged to 100% before timeout")
+				assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+
+				return
+			}
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				assert.Equal(t, len(tt.statuses), calls, "should stop polling at once")
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.statuses), calls)
+			assert.True(t, strings.HasSuffix(buf.String(), tt.wantOutput), "output %q should end with %q", buf.String(), tt.wantOutput)
+			for _, line := range tt.wantProgress {
+				assert.Equal(t, 1, strings.Count(buf.String(), line), "output %q should report %q once", buf.String(), line)
+			}
+		})
+	}
+}
+
+// TestFormatChargeProgress tests the wait-for-full progress line.
+func TestFormatChargeProgress(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Charging: 72% (~2h 20m to full)", formatChargeProgress(api.BatteryInfo{BatteryLevel: 72, Charging: true, PluggedIn: true, ChargeTimeACMin: 140}))
+	assert.Equal(t, "Charging: 72%", formatChargeProgress(api.BatteryInfo{BatteryLevel: 72, Charging: true, PluggedIn: true}))
+	assert.Equal(t, "Not charging: 72% (plugged in)", formatChargeProgress(api.BatteryInfo{BatteryLevel: 72, PluggedIn: true}))
+	assert.Equal(t, "Not charging: 72% (unplugged)", formatChargeProgress(api.BatteryInfo{BatteryLevel: 72}))
 }
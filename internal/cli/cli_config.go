@@ -1,6 +1,13 @@
 package cli
 
-import "context"
+import (
+	"context"
+	"io"
+
+	"github.com/cv/mcs/internal/config"
+	"github.com/cv/mcs/internal/geocode"
+	"github.com/spf13/cobra"
+)
 
 // CLIConfig holds CLI configuration that was previously stored in package-level globals.
 // Using a struct allows tests to run in parallel without race conditions.
@@ -11,13 +18,109 @@ type CLIConfig struct {
 	// ConfigFile is the path to the config file, set via --config flag.
 	ConfigFile string
 
+	// Profile selects a named set of config and cache files, set via --profile flag.
+	// Empty means the default profile.
+	Profile string
+
 	// NoColor disables colored output, set via --no-color flag.
 	NoColor bool
 
+	// Color is when to color text output: "auto", "always", or "never", set via
+	// --color flag. See shouldColor.
+	Color string
+
+	// DistanceUnit is "km" or "mi", set via --distance-unit flag.
+	DistanceUnit string
+
+	// TempUnit is "c" or "f", set via --temp-unit flag.
+	TempUnit string
+
+	// Locale is a language tag such as "en-US" or "de-DE" selecting number
+	// separators in text output, set via --locale flag.
+	Locale string
+
+	// CACert is a PEM file of extra CA certificates to trust, set via --ca-cert
+	// flag. Used behind TLS-inspecting proxies.
+	CACert string
+
+	// Insecure disables TLS certificate verification, set via --insecure flag.
+	Insecure bool
+
+	// MinTLS is the oldest TLS version to accept, "1.2" or "1.3", set via
+	// --min-tls flag. Empty means TLS 1.2.
+	MinTLS string
+
+	// NoHTTP2 limits API requests to HTTP/1.1, set via --no-http2 flag. Some
+	// corporate proxies break HTTP/2.
+	NoHTTP2 bool
+
+	// UserAgent replaces the User-Agent of API requests, set via --user-agent
+	// flag. Empty means api.UserAgentBaseAPI.
+	UserAgent string
+
+	// Theme is "ascii" or "emoji", set via --theme flag.
+	Theme string
+
+	// NoPretty prints JSON output on a single line, set via --no-pretty or
+	// --pretty=false.
+	NoPretty bool
+
+	// MaskVIN shows only the last characters of VINs in output, set via
+	// --mask-vin flag.
+	MaskVIN bool
+
+	// Quiet suppresses progress messages, such as "Waiting for confirmation...",
+	// leaving only results and errors, set via --quiet flag.
+	Quiet bool
+
+	// Notify sends a desktop notification when a confirmable command or a
+	// status refresh finishes, set via --notify flag.
+	Notify bool
+
+	// Notifier shows desktop notifications for --notify. If nil, uses notify.Send.
+	// This is primarily used for testing to avoid showing real notifications.
+	Notifier func(ctx context.Context, title, body string) error
+
+	// PollInterval is the default seconds between mcs watch checks, from the
+	// config file. Zero means the built-in default.
+	PollInterval int
+
+	// GeocoderURL is the Nominatim-compatible server used by status location
+	// --address, from the config file. Empty means the public Nominatim server.
+	GeocoderURL string
+
+	// Geocoder looks up addresses for status location --address. If nil, uses
+	// the Nominatim server at GeocoderURL.
+	// This is primarily used for testing to avoid network requests.
+	Geocoder geocode.Geocoder
+
 	// CacheFile is the path to the token cache file.
-	// If empty, uses the default location (~/.cache/mcs/token.json).
+	// If empty, uses the profile's location (~/.cache/mcs/token.json by default).
 	// This is primarily used for testing to avoid setting HOME.
 	CacheFile string
+
+	// StateFile is the path to the status state file used by --only-if-changed.
+	// If empty, uses the profile's location (~/.cache/mcs/state.json by default).
+	StateFile string
+
+	// AddressCacheFile is the path to the reverse-geocoding cache used by status
+	// location --address. If empty, uses the profile's location
+	// (~/.cache/mcs/geocode.json by default).
+	AddressCacheFile string
+
+	// SnapshotFile is the path to the status snapshots used by status
+	// --diff-previous. If empty, uses the profile's location
+	// (~/.cache/mcs/snapshots.json by default).
+	SnapshotFile string
+
+	// TireFile is the path to the recommended tire pressures saved by status
+	// tires --compare-recommended-tire. If empty, uses the profile's location
+	// (~/.config/mcs/tires.json by default).
+	TireFile string
+
+	// PlacesFile is the path to the named places saved by location save. If
+	// empty, uses the profile's location (~/.config/mcs/places.json by default).
+	PlacesFile string
 }
 
 // cliConfigKey is the context key for CLIConfig.
@@ -37,3 +140,39 @@ func ConfigFromContext(ctx context.Context) *CLIConfig {
 func ContextWithConfig(ctx context.Context, cfg *CLIConfig) context.Context {
 	return context.WithValue(ctx, cliConfigKey{}, cfg)
 }
+
+// infoWriter returns where progress messages go: out, or nowhere with --quiet.
+func infoWriter(ctx context.Context, out io.Writer) io.Writer {
+	if cfg := ConfigFromContext(ctx); cfg != nil && cfg.Quiet {
+		return io.Discard
+	}
+
+	return out
+}
+
+// resolveSetting picks a setting's value: an explicitly set flag wins, then the
+// config file, then the built-in default. A zero config value means not set.
+func resolveSetting[T comparable](flagValue T, flagSet bool, configValue, builtin T) T {
+	var zero T
+	switch {
+	case flagSet:
+		return flagValue
+	case configValue != zero:
+		return configValue
+	default:
+		return builtin
+	}
+}
+
+// applyConfigDefaults fills in cfg from the config file's [defaults] for any
+// global flag that wasn't given on the command line.
+func applyConfigDefaults(cmd *cobra.Command, cfg *CLIConfig, defaults config.Defaults) {
+	flags := cmd.Flags()
+	cfg.DistanceUnit = resolveSetting(cfg.DistanceUnit, flags.Changed("distance-unit"), defaults.DistanceUnit, string(distanceKm))
+	cfg.TempUnit = resolveSetting(cfg.TempUnit, flags.Changed("temp-unit"), defaults.TempUnit, "c")
+	cfg.Locale = resolveSetting(cfg.Locale, flags.Changed("locale"), defaults.Locale, defaultLocale)
+	cfg.Theme = resolveSetting(cfg.Theme, flags.Changed("theme"), defaults.Theme, themeNameASCII)
+	cfg.NoColor = resolveSetting(cfg.NoColor, flags.Changed("no-color"), defaults.NoColor, false)
+	cfg.PollInterval = defaults.PollInterval
+	cfg.GeocoderURL = defaults.GeocoderURL
+}
@@ -1,28 +1,72 @@
 package cli
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/cv/mcs/internal/api"
 	"github.com/cv/mcs/internal/cache"
 	"github.com/cv/mcs/internal/config"
 )
 
-// createAPIClient creates an API client with cached credentials if available.
-func createAPIClient(ctx context.Context) (*api.Client, error) {
-	// Get CLI config from context.
+// resolvePaths returns the config, token cache, state, address cache, snapshot, tire, and
+// places file locations for this invocation. --profile picks the base locations; --config and
+// the CacheFile/StateFile/AddressCacheFile/SnapshotFile/TireFile/PlacesFile overrides take
+// precedence. For the default profile ConfigFile is left empty so that config.Load
+// searches the default location and tolerates a missing file.
+func resolvePaths(ctx context.Context) (config.Paths, error) {
 	cliCfg := ConfigFromContext(ctx)
-	configFile := ""
-	cacheFile := ""
-	if cliCfg != nil {
-		configFile = cliCfg.ConfigFile
-		cacheFile = cliCfg.CacheFile
+	if cliCfg == nil {
+		cliCfg = &CLIConfig{}
 	}
 
-	// Load configuration.
-	cfg, err := config.Load(configFile)
+	paths, err := config.ConfigPaths(cliCfg.Profile)
+	if err != nil {
+		return config.Paths{}, err
+	}
+
+	if cliCfg.Profile == "" || cliCfg.Profile == config.DefaultProfile {
+		paths.ConfigFile = ""
+	}
+	if cliCfg.ConfigFile != "" {
+		paths.ConfigFile = cliCfg.ConfigFile
+	}
+	if cliCfg.CacheFile != "" {
+		paths.TokenCache = cliCfg.CacheFile
+	}
+	if cliCfg.StateFile != "" {
+		paths.StateFile = cliCfg.StateFile
+	}
+	if cliCfg.AddressCacheFile != "" {
+		paths.AddressCache = cliCfg.AddressCacheFile
+	}
+	if cliCfg.SnapshotFile != "" {
+		paths.SnapshotFile = cliCfg.SnapshotFile
+	}
+	if cliCfg.TireFile != "" {
+		paths.TireFile = cliCfg.TireFile
+	}
+	if cliCfg.PlacesFile != "" {
+		paths.PlacesFile = cliCfg.PlacesFile
+	}
+
+	return paths, nil
+}
+
+// loadConfig loads and validates the configuration, honoring --config and --profile from the context.
+func loadConfig(ctx context.Context) (*config.Config, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load(paths.ConfigFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
@@ -31,19 +75,35 @@ func createAPIClient(ctx context.Context) (*api.Client, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	return cfg, nil
+}
+
+// createAPIClient creates an API client with cached credentials if available.
+func createAPIClient(ctx context.Context) (*api.Client, error) {
+	// Resolve per-profile file locations.
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load configuration.
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Create API client.
-	client, err := api.NewClient(cfg.Email, cfg.Password, cfg.Region)
+	opts, err := clientOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	client, err := api.NewClient(cfg.Email, cfg.Password, cfg.Region, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create API client: %w", err)
 	}
 
 	// Try to load cached credentials (ignore errors - client will authenticate normally).
-	var cachedCreds *cache.TokenCache
-	if cacheFile != "" {
-		cachedCreds, _ = cache.LoadFrom(cacheFile)
-	} else {
-		cachedCreds, _ = cache.Load()
-	}
+	cachedCreds, _ := cache.LoadFrom(paths.TokenCache)
 
 	// If we have valid cached credentials, use them.
 	if cachedCreds != nil && cachedCreds.IsValid() {
@@ -53,11 +113,89 @@ func createAPIClient(ctx context.Context) (*api.Client, error) {
 			cachedCreds.EncKey,
 			cachedCreds.SignKey,
 		)
+		if cachedCreds.KeysFetchedAtTs != 0 {
+			client.Keys.FetchedAt = time.Unix(cachedCreds.KeysFetchedAtTs, 0)
+		}
 	}
 
 	return client, nil
 }
 
+// clientOptions returns the API client options for --ca-cert, --insecure,
+// --min-tls, --no-http2, and --user-agent.
+func clientOptions(ctx context.Context) ([]api.ClientOption, error) {
+	cliCfg := ConfigFromContext(ctx)
+	if cliCfg == nil {
+		return nil, nil
+	}
+
+	var opts []api.ClientOption
+	if cliCfg.CACert != "" || cliCfg.Insecure {
+		tlsConfig, err := loadTLSConfig(cliCfg.CACert, cliCfg.Insecure)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, api.WithTLSConfig(tlsConfig))
+	}
+	if cliCfg.MinTLS != "" {
+		version, err := parseTLSVersion(cliCfg.MinTLS)
+		if err != nil {
+			return nil, err
+		}
+		if version != api.DefaultMinTLSVersion {
+			opts = append(opts, api.WithMinTLSVersion(version))
+		}
+	}
+	if cliCfg.NoHTTP2 {
+		opts = append(opts, api.WithoutHTTP2())
+	}
+	if cliCfg.UserAgent != "" && cliCfg.UserAgent != api.UserAgentBaseAPI {
+		opts = append(opts, api.WithUserAgent(cliCfg.UserAgent))
+	}
+
+	return opts, nil
+}
+
+// parseTLSVersion parses the --min-tls flag: "1.2" or "1.3". Older versions are
+// not offered, as they are no longer considered secure.
+func parseTLSVersion(version string) (uint16, error) {
+	switch version {
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("--min-tls must be 1.2 or 1.3, got %q", version)
+	}
+}
+
+// loadTLSConfig builds a TLS config that trusts the system CAs plus those in the
+// PEM file at caCertPath (if given), or skips verification when insecure is set.
+func loadTLSConfig(caCertPath string, insecure bool) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure,
+	}
+	if caCertPath == "" {
+		return tlsConfig, nil
+	}
+
+	caCert, err := os.ReadFile(caCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read --ca-cert: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("--ca-cert %s contains no PEM certificates", caCertPath)
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
 // saveClientCache saves the client's current credentials to cache.
 func saveClientCache(ctx context.Context, client *api.Client) {
 	accessToken, expirationTs, encKey, signKey := client.GetCredentials()
@@ -73,19 +211,14 @@ func saveClientCache(ctx context.Context, client *api.Client) {
 		EncKey:                  encKey,
 		SignKey:                 signKey,
 	}
-
-	// Get cache file from context.
-	cliCfg := ConfigFromContext(ctx)
-	cacheFile := ""
-	if cliCfg != nil {
-		cacheFile = cliCfg.CacheFile
+	if !client.Keys.FetchedAt.IsZero() {
+		tokenCache.KeysFetchedAtTs = client.Keys.FetchedAt.Unix()
 	}
 
-	var err error
-	if cacheFile != "" {
-		err = cache.SaveTo(tokenCache, cacheFile)
-	} else {
-		err = cache.Save(tokenCache)
+	// Get the profile's cache file from context.
+	paths, err := resolvePaths(ctx)
+	if err == nil {
+		err = cache.SaveTo(tokenCache, paths.TokenCache)
 	}
 
 	if err != nil {
@@ -95,44 +228,133 @@ func saveClientCache(ctx context.Context, client *api.Client) {
 	}
 }
 
-// VehicleInfo contains identification information about a vehicle.
+// VehicleInfo is the vehicle a command acts on, with the CLI's display settings.
 type VehicleInfo struct {
-	InternalVIN api.InternalVIN
-	VIN         string
-	Nickname    string
-	ModelName   string
-	ModelYear   string
+	api.VehicleInfo
+
+	// MaskVIN shows only the end of the VIN in output, set via --mask-vin flag.
+	MaskVIN bool
+}
+
+// maskedVINLength is how many trailing VIN characters --mask-vin shows.
+const maskedVINLength = 6
+
+// displayVIN returns the VIN as it should be printed: with --mask-vin, only its
+// last characters, e.g. "...123456".
+func (v VehicleInfo) displayVIN() string {
+	if !v.MaskVIN || len(v.VIN) <= maskedVINLength {
+		return v.VIN
+	}
+
+	return "..." + v.VIN[len(v.VIN)-maskedVINLength:]
+}
+
+// capabilities returns what the vehicle supports, from its model code and
+// powertrain. A vehicle whose model is unknown supports everything.
+func (v VehicleInfo) capabilities() api.VehicleCapabilities {
+	capabilities := api.Capabilities(v.ModelCode)
+	capabilities.RemoteEngineStart = capabilities.RemoteEngineStart && v.Powertrain.SupportsRemoteEngineStart()
+
+	return capabilities
+}
+
+// capabilityNames lists what the vehicle supports for JSON output, e.g.
+// ["remote_commands", "remote_engine", "charging"], so integrations don't have to
+// work it out from the model. The list is empty, not nil, when it supports nothing.
+func (v VehicleInfo) capabilityNames() []string {
+	capabilities := v.capabilities()
+	names := []string{}
+	for _, capability := range []struct {
+		name      string
+		supported bool
+	}{
+		{"remote_commands", true},
+		{"remote_engine", capabilities.RemoteEngineStart},
+		{"remote_refresh", v.Powertrain.SupportsRemoteRefresh()},
+		{"charging", v.Powertrain.IsElectrified()},
+		{"power_windows", capabilities.WindowStatus},
+		{"sunroof", capabilities.Sunroof},
+	} {
+		if capability.supported {
+			names = append(names, capability.name)
+		}
+	}
+
+	return names
+}
+
+// powertrainName is the powertrain for output, "unknown" when it wasn't classified.
+func (v VehicleInfo) powertrainName() string {
+	return string(cmp.Or(v.Powertrain, api.PowertrainUnknown))
+}
+
+// vehicleSession is an authenticated client and its vehicle, shared by the
+// commands run in one mcs batch so that they log in only once.
+type vehicleSession struct {
+	client      *api.Client
+	vehicleInfo VehicleInfo
+}
+
+// vehicleSessionKey is the context key for vehicleSession.
+type vehicleSessionKey struct{}
+
+// contextWithVehicleSession returns a new context whose vehicle commands reuse session.
+func contextWithVehicleSession(ctx context.Context, session *vehicleSession) context.Context {
+	return context.WithValue(ctx, vehicleSessionKey{}, session)
 }
 
 // setupVehicleClient is a shared helper that creates the API client and retrieves vehicle info.
 // It returns the authenticated client and full vehicle info, deferring cache save to the caller.
+// Inside mcs batch it returns the batch's shared session instead.
 func setupVehicleClient(ctx context.Context) (*api.Client, VehicleInfo, error) {
-	client, err := createAPIClient(ctx)
+	if session, ok := ctx.Value(vehicleSessionKey{}).(*vehicleSession); ok {
+		return session.client, session.vehicleInfo, nil
+	}
+
+	client, vecBaseInfos, err := loginAndListVehicles(ctx)
 	if err != nil {
 		return nil, VehicleInfo{}, err
 	}
 
-	vecBaseInfos, err := client.GetVecBaseInfos(ctx)
+	vehicle, err := vecBaseInfos.GetPrimaryVehicle()
 	if err != nil {
-		return nil, VehicleInfo{}, fmt.Errorf("failed to get vehicle info: %w", err)
+		return nil, VehicleInfo{}, err
 	}
 
-	internalVINStr, err := vecBaseInfos.GetInternalVIN()
+	return client, VehicleInfo{VehicleInfo: vehicle, MaskVIN: maskVINFromContext(ctx)}, nil
+}
+
+// loginAndListVehicles creates the API client and fetches the account's vehicles,
+// logging in again if cached credentials are rejected.
+func loginAndListVehicles(ctx context.Context) (*api.Client, *api.VecBaseInfosResponse, error) {
+	client, err := createAPIClient(ctx)
 	if err != nil {
-		return nil, VehicleInfo{}, err
+		return nil, nil, err
 	}
 
-	vin, nickname, modelName, modelYear, _ := vecBaseInfos.GetVehicleInfo()
+	var vecBaseInfos *api.VecBaseInfosResponse
+	err = retryOnInvalidCredential(ctx, client.SetPassword, func() (callErr error) {
+		vecBaseInfos, callErr = client.GetVecBaseInfos(ctx)
 
-	vehicleInfo := VehicleInfo{
-		InternalVIN: api.InternalVIN(internalVINStr),
-		VIN:         vin,
-		Nickname:    nickname,
-		ModelName:   modelName,
-		ModelYear:   modelYear,
+		return callErr
+	})
+	if err != nil {
+		// Rejected credentials are the whole story; don't bury them under "failed to get vehicle info".
+		if api.IsInvalidCredential(err) {
+			return nil, nil, err
+		}
+
+		return nil, nil, fmt.Errorf("failed to get vehicle info: %w", err)
 	}
 
-	return client, vehicleInfo, nil
+	return client, vecBaseInfos, nil
+}
+
+// maskVINFromContext reports whether --mask-vin was given.
+func maskVINFromContext(ctx context.Context) bool {
+	cfg := ConfigFromContext(ctx)
+
+	return cfg != nil && cfg.MaskVIN
 }
 
 // withVehicleClient handles the common CLI setup: create client, get VIN, execute command, save cache.
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cv/mcs/internal/api"
 	"github.com/cv/mcs/internal/cache"
+	"github.com/cv/mcs/internal/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -237,6 +238,61 @@ region = "MME"
 	assert.NotNil(t, client)
 }
 
+// TestProfiles_DoNotShareTokenCache tests that two profiles keep separate token caches.
+// It relies on the HOME-based profile locations, so it cannot run in parallel.
+func TestProfiles_DoNotShareTokenCache(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("MCS_EMAIL", "")
+	t.Setenv("MCS_PASSWORD", "")
+	t.Setenv("MCS_REGION", "")
+
+	for _, profile := range []string{"personal", "work"} {
+		paths, err := config.ConfigPaths(profile)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Dir(paths.ConfigFile), 0700))
+		configContent := "email = \"" + profile + "@example.com\"\npassword = \"pw\"\nregion = \"MNAO\"\n"
+		require.NoError(t, os.WriteFile(paths.ConfigFile, []byte(configContent), 0600))
+	}
+
+	personalCtx := ContextWithConfig(context.Background(), &CLIConfig{Profile: "personal"})
+	workCtx := ContextWithConfig(context.Background(), &CLIConfig{Profile: "work"})
+
+	// Log in as the personal profile and cache its token.
+	personalClient, err := createAPIClient(personalCtx)
+	require.NoError(t, err)
+	personalClient.SetCachedCredentials("personal-token", time.Now().Unix()+3600, "enc", "sign")
+	saveClientCache(personalCtx, personalClient)
+
+	// The work profile must not pick up the personal token.
+	workClient, err := createAPIClient(workCtx)
+	require.NoError(t, err)
+	workToken, _, _, _ := workClient.GetCredentials()
+	assert.Empty(t, workToken, "Expected work profile to have no cached token")
+
+	// The personal profile still sees its own token.
+	personalClient, err = createAPIClient(personalCtx)
+	require.NoError(t, err)
+	personalToken, _, _, _ := personalClient.GetCredentials()
+	assert.Equal(t, "personal-token", personalToken)
+
+	// And the default profile's cache is untouched.
+	defaultPaths, err := config.ConfigPaths("")
+	require.NoError(t, err)
+	defaultCache, err := cache.LoadFrom(defaultPaths.TokenCache)
+	require.NoError(t, err)
+	assert.Nil(t, defaultCache)
+}
+
+// TestLoadConfig_InvalidProfile tests that unsafe profile names are rejected.
+func TestLoadConfig_InvalidProfile(t *testing.T) {
+	t.Parallel()
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{Profile: "../escape"})
+
+	_, err := loadConfig(ctx)
+	require.ErrorContains(t, err, "invalid profile")
+}
+
 // TestCreateAPIClient_MissingCredentials tests error when credentials are missing.
 // This test specifically verifies env var behavior, so it cannot run in parallel.
 func TestCreateAPIClient_MissingCredentials(t *testing.T) {
@@ -258,11 +314,13 @@ func TestCreateAPIClient_MissingCredentials(t *testing.T) {
 func TestVehicleInfo_InternalVINType(t *testing.T) {
 	t.Parallel()
 	vehicleInfo := VehicleInfo{
-		InternalVIN: api.InternalVIN("test-vin-123"),
-		VIN:         "JM3XXXXXXXXXX1234",
-		Nickname:    "Test Vehicle",
-		ModelName:   "CX-90",
-		ModelYear:   "2024",
+		VehicleInfo: api.VehicleInfo{
+			InternalVIN: api.InternalVIN("test-vin-123"),
+			VIN:         "JM3XXXXXXXXXX1234",
+			Nickname:    "Test Vehicle",
+			ModelName:   "CX-90",
+			ModelYear:   "2024",
+		},
 	}
 
 	// Verify InternalVIN type.
@@ -2,7 +2,10 @@ package cli
 
 import (
 	"context"
+	"encoding/pem"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -277,11 +280,13 @@ func TestVehicleInfo_StructFields(t *testing.T) {
 	t.Parallel()
 	// Test that VehicleInfo struct has correct field types
 	info := VehicleInfo{
-		InternalVIN: api.InternalVIN("test123"),
-		VIN:         "JM3KKEHC1R0123456",
-		Nickname:    "Test Car",
-		ModelName:   "CX-90",
-		ModelYear:   "2024",
+		VehicleInfo: api.VehicleInfo{
+			InternalVIN: api.InternalVIN("test123"),
+			VIN:         "JM3KKEHC1R0123456",
+			Nickname:    "Test Car",
+			ModelName:   "CX-90",
+			ModelYear:   "2024",
+		},
 	}
 
 	// Verify InternalVIN is api.InternalVIN type
@@ -341,3 +346,99 @@ region = "MNAO"
 		// Other errors are expected (API connection, etc.)
 	}
 }
+
+// TestLoadTLSConfig tests trusting an extra CA from --ca-cert and skipping verification with --insecure.
+func TestLoadTLSConfig(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	caCertPath := filepath.Join(dir, "proxy-ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertPath, caCert, 0600))
+
+	t.Run("ca cert is trusted", func(t *testing.T) {
+		t.Parallel()
+		tlsConfig, err := loadTLSConfig(caCertPath, false)
+		require.NoError(t, err)
+		assert.False(t, tlsConfig.InsecureSkipVerify)
+
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		resp, err := httpClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	})
+
+	t.Run("insecure", func(t *testing.T) {
+		t.Parallel()
+		tlsConfig, err := loadTLSConfig("", true)
+		require.NoError(t, err)
+		assert.True(t, tlsConfig.InsecureSkipVerify)
+		assert.Nil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadTLSConfig(filepath.Join(dir, "missing.pem"), false)
+		require.ErrorContains(t, err, "failed to read --ca-cert")
+	})
+
+	t.Run("no certificates", func(t *testing.T) {
+		t.Parallel()
+		notPEM := filepath.Join(dir, "not-a-cert.pem")
+		require.NoError(t, os.WriteFile(notPEM, []byte("hello"), 0600))
+		_, err := loadTLSConfig(notPEM, false)
+		require.ErrorContains(t, err, "contains no PEM certificates")
+	})
+}
+
+// TestClientOptions tests that transport options are only added for flags that change the defaults.
+func TestClientOptions(t *testing.T) {
+	t.Parallel()
+	opts, err := clientOptions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{}))
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{Insecure: true}))
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+
+	_, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{CACert: filepath.Join(t.TempDir(), "missing.pem")}))
+	require.Error(t, err)
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{MinTLS: "1.2"}))
+	require.NoError(t, err)
+	assert.Empty(t, opts, "TLS 1.2 is already the default")
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{MinTLS: "1.3", NoHTTP2: true}))
+	require.NoError(t, err)
+	assert.Len(t, opts, 2)
+
+	_, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{MinTLS: "1.0"}))
+	require.EqualError(t, err, `--min-tls must be 1.2 or 1.3, got "1.0"`)
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{UserAgent: api.UserAgentBaseAPI}))
+	require.NoError(t, err)
+	assert.Empty(t, opts, "the default user agent needs no option")
+
+	opts, err = clientOptions(ContextWithConfig(context.Background(), &CLIConfig{UserAgent: "MyMazda-Android/9.1.0"}))
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+}
+
+func TestVehicleInfo_DisplayVIN(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "JM3KKEHC1R0123456", VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}.displayVIN())
+	assert.Equal(t, "...123456", VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}, MaskVIN: true}.displayVIN())
+	assert.Equal(t, "123456", VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "123456"}, MaskVIN: true}.displayVIN())
+	assert.Empty(t, VehicleInfo{MaskVIN: true}.displayVIN())
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/config"
 	"github.com/spf13/cobra"
 )
 
@@ -35,7 +39,9 @@ func NewClimateCmd() *cobra.Command {
 
 // newClimateOnCmd creates the climate on subcommand.
 func newClimateOnCmd() *cobra.Command {
-	return buildConfirmableCommand(CommandSpec{
+	var preset string
+
+	onCmd := buildConfirmableCommand(CommandSpec{
 		Use:   "on",
 		Short: "Turn climate on",
 		Long:  `Turn the vehicle HVAC system on.`,
@@ -49,14 +55,31 @@ func newClimateOnCmd() *cobra.Command {
   mcs climate on --confirm=false
 
   # Turn climate on and wait up to 60 seconds for confirmation
-  mcs climate on --confirm-wait 60`,
+  mcs climate on --confirm-wait 60
+
+  # Apply the [climate_presets.winter] settings from the config file, then turn climate on
+  mcs climate on --preset winter`,
 		ConfirmFlagUsage: "wait for confirmation that climate has turned on",
+		Prepare: func(ctx context.Context, config *ConfirmableCommandConfig) error {
+			if preset == "" {
+				return nil
+			}
+			presetConfig, err := climatePresetConfig(ctx, preset)
+			if err != nil {
+				return err
+			}
+			*config = presetConfig
+
+			return nil
+		},
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.HVACOn(ctx, string(internalVIN))
-			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForHvacOn(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+			Actions: []ControlStep{{Endpoint: api.EndpointHVACOn}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					return waitForHvacOn(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
 			InitialDelay:  ConfirmationInitialDelay,
 			SuccessMsg:    "Climate turned on successfully",
@@ -66,11 +89,83 @@ func newClimateOnCmd() *cobra.Command {
 			TimeoutSuffix: "confirmation timeout",
 		},
 	})
+
+	onCmd.Flags().StringVar(&preset, "preset", "", "apply the named [climate_presets] settings from the config file")
+
+	return onCmd
+}
+
+// climatePresetConfig returns the confirmable command that sends the settings of
+// the named preset, turns climate on, and waits for the settings to be confirmed.
+func climatePresetConfig(ctx context.Context, name string) (ConfirmableCommandConfig, error) {
+	settings, err := loadClimatePreset(ctx, name)
+	if err != nil {
+		return ConfirmableCommandConfig{}, err
+	}
+	displayUnit, err := temperatureUnitFromContext(ctx)
+	if err != nil {
+		return ConfirmableCommandConfig{}, err
+	}
+
+	cmdConfig := climateSettingsConfig(settings, true)
+	cmdConfig.SuccessMsg = fmt.Sprintf("Climate turned on with preset %s: %s", name, settings.describe(displayUnit))
+
+	return cmdConfig, nil
+}
+
+// loadClimatePreset returns the settings of the named [climate_presets] table in
+// the config file. A preset without a unit uses the --temp-unit default.
+func loadClimatePreset(ctx context.Context, name string) (climateSettings, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return climateSettings{}, err
+	}
+	presets, err := config.LoadClimatePresets(paths.ConfigFile)
+	if err != nil {
+		return climateSettings{}, err
+	}
+
+	preset, ok := presets[strings.ToLower(name)]
+	if !ok {
+		if len(presets) == 0 {
+			return climateSettings{}, fmt.Errorf("unknown climate preset %q: the config file has no [climate_presets.<name>] tables", name)
+		}
+		names := slices.Sorted(maps.Keys(presets))
+
+		return climateSettings{}, fmt.Errorf("unknown climate preset %q (defined: %s)", name, strings.Join(names, ", "))
+	}
+	if preset.Temp == 0 {
+		return climateSettings{}, fmt.Errorf("climate preset %q has no temp", name)
+	}
+
+	unit, err := temperatureUnitFromContext(ctx)
+	if err != nil {
+		return climateSettings{}, err
+	}
+	if preset.Unit != "" {
+		if unit, err = api.ParseTemperatureUnit(preset.Unit); err != nil {
+			return climateSettings{}, fmt.Errorf("climate preset %q: %w", name, err)
+		}
+	}
+
+	settings := climateSettings{
+		temperature:    preset.Temp,
+		unit:           unit,
+		frontDefroster: preset.FrontDefrost,
+		rearDefroster:  preset.RearDefrost,
+	}
+	if err := settings.validate(); err != nil {
+		return climateSettings{}, fmt.Errorf("climate preset %q: %w", name, err)
+	}
+
+	return settings, nil
 }
 
 // newClimateOffCmd creates the climate off subcommand.
 func newClimateOffCmd() *cobra.Command {
-	return buildConfirmableCommand(CommandSpec{
+	var confirmDefrosters bool
+
+	offCmd := buildConfirmableCommand(CommandSpec{
 		Use:   "off",
 		Short: "Turn climate off",
 		Long:  `Turn the vehicle HVAC system off.`,
@@ -84,14 +179,23 @@ func newClimateOffCmd() *cobra.Command {
   mcs climate off --confirm=false
 
   # Turn climate off and wait up to 60 seconds for confirmation
-  mcs climate off --confirm-wait 60`,
+  mcs climate off --confirm-wait 60
+
+  # Also wait for the defrosters to turn off, warning if one stays on
+  mcs climate off --confirm-defrosters`,
 		ConfirmFlagUsage: "wait for confirmation that climate has turned off",
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.HVACOff(ctx, string(internalVIN))
-			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForHvacOff(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+			Actions: []ControlStep{{Endpoint: api.EndpointHVACOff}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetEVVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					if confirmDefrosters {
+						return waitForHvacAndDefrostersOff(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+					}
+
+					return waitForHvacOff(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
 			InitialDelay:  ConfirmationInitialDelay,
 			SuccessMsg:    "Climate turned off successfully",
@@ -101,6 +205,9 @@ func newClimateOffCmd() *cobra.Command {
 			TimeoutSuffix: "confirmation timeout",
 		},
 	})
+	offCmd.Flags().BoolVar(&confirmDefrosters, "confirm-defrosters", false, "with --confirm, also wait for the defrosters to turn off")
+
+	return offCmd
 }
 
 // newClimateSetCmd creates the climate set subcommand.
@@ -109,10 +216,8 @@ func newClimateSetCmd() *cobra.Command {
 	var tempUnit string
 	var frontDefroster bool
 	var rearDefroster bool
-	var confirm bool
-	var confirmWait int
 
-	setCmd := &cobra.Command{
+	setCmd := buildConfirmableCommand(CommandSpec{
 		Use:   "set",
 		Short: "Set climate temperature and defroster settings",
 		Long:  `Set the vehicle HVAC temperature and defroster settings.`,
@@ -135,62 +240,136 @@ func newClimateSetCmd() *cobra.Command {
   mcs climate set --temp 22 --confirm-wait 60
 
   # Expected output on success:
-  # Climate set to 22.0°C`,
-		RunE: func(cmd *cobra.Command, args []string) error {
+  # Climate set to 22.0°C
+
+  # Show the API calls without sending anything
+  mcs climate set --temp 22 --explain`,
+		ConfirmFlagUsage: "wait for confirmation that settings have been applied",
+		Prepare: func(ctx context.Context, config *ConfirmableCommandConfig) error {
 			unit, err := api.ParseTemperatureUnit(tempUnit)
 			if err != nil {
 				return err
 			}
+			settings := climateSettings{
+				temperature:    temperature,
+				unit:           unit,
+				frontDefroster: frontDefroster,
+				rearDefroster:  rearDefroster,
+			}
+			if err := settings.validate(); err != nil {
+				return fmt.Errorf("--%w", err)
+			}
+			*config = climateSettingsConfig(settings, false)
 
-			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				// Build success message
-				msg := fmt.Sprintf("Climate set to %.1f%s", temperature, unit.String())
-				if frontDefroster {
-					msg += " with front defroster on"
-				}
-				if rearDefroster {
-					if frontDefroster {
-						msg += " and rear defroster on"
-					} else {
-						msg += " with rear defroster on"
-					}
-				}
-
-				// Convert temperature to Celsius for comparison (API returns Celsius)
-				targetTempC := temperature
-				if unit == api.Fahrenheit {
-					targetTempC = (temperature - 32) * 5 / 9
-				}
-
-				config := ConfirmableCommandConfig{
-					ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-						return client.SetHVACSetting(ctx, string(internalVIN), temperature, unit, frontDefroster, rearDefroster)
-					},
-					WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-						return waitForHvacSettings(ctx, out, &clientAdapter{Client: client}, internalVIN, targetTempC, frontDefroster, rearDefroster, timeout, pollInterval)
-					},
-					InitialDelay:  ConfirmationInitialDelay,
-					SuccessMsg:    msg,
-					WaitingMsg:    "Climate set command sent, waiting for confirmation...",
-					ActionName:    "set HVAC settings",
-					ConfirmName:   "HVAC settings",
-					TimeoutSuffix: "confirmation timeout",
-				}
-
-				return executeConfirmableCommand(ctx, cmd.OutOrStdout(), client, internalVIN, config, confirm, confirmWait)
-			})
+			return nil
 		},
-		SilenceUsage: true,
-	}
+		// Prepare builds the rest from the flags.
+		Config: ConfirmableCommandConfig{
+			InitialDelay: ConfirmationInitialDelay,
+		},
+	})
 
 	setCmd.Flags().Float64Var(&temperature, "temp", 0, "temperature to set (required)")
 	setCmd.Flags().StringVar(&tempUnit, "unit", "c", "temperature unit: 'c' for Celsius, 'f' for Fahrenheit")
 	setCmd.Flags().BoolVar(&frontDefroster, "front-defrost", false, "enable front defroster")
 	setCmd.Flags().BoolVar(&rearDefroster, "rear-defrost", false, "enable rear defroster")
-	setCmd.Flags().BoolVar(&confirm, "confirm", true, "wait for confirmation that settings have been applied")
-	setCmd.Flags().IntVar(&confirmWait, "confirm-wait", 90, "max seconds to wait for confirmation")
 
 	_ = setCmd.MarkFlagRequired("temp")
 
 	return setCmd
 }
+
+// climateSettings are the HVAC settings sent by climate set and climate on --preset.
+type climateSettings struct {
+	temperature    float64
+	unit           api.TemperatureUnit
+	frontDefroster bool
+	rearDefroster  bool
+}
+
+// Range of climate temperatures accepted by climate set and climate on --preset.
+const (
+	minClimateTempC = 15.0
+	maxClimateTempC = 32.0
+)
+
+// validate rejects temperatures outside the climate control's range, so that a
+// typo such as 220 or a Fahrenheit value with the wrong unit fails before sending.
+func (s climateSettings) validate() error {
+	minTemp, maxTemp := minClimateTempC, maxClimateTempC
+	if s.unit == api.Fahrenheit {
+		minTemp, maxTemp = minClimateTempC*9/5+32, maxClimateTempC*9/5+32
+	}
+	if s.temperature < minTemp || s.temperature > maxTemp {
+		return fmt.Errorf("temp must be between %g and %g%s, got %g", minTemp, maxTemp, s.unit, s.temperature)
+	}
+
+	return nil
+}
+
+// targetTempC returns the temperature in Celsius, the unit the API reports.
+func (s climateSettings) targetTempC() float64 {
+	if s.unit == api.Fahrenheit {
+		return (s.temperature - 32) * 5 / 9
+	}
+
+	return s.temperature
+}
+
+// describe returns the settings for display with the temperature in displayUnit,
+// e.g. "22.0C with front defroster on".
+func (s climateSettings) describe(displayUnit api.TemperatureUnit) string {
+	temperature := s.temperature
+	switch {
+	case s.unit == api.Fahrenheit && displayUnit != api.Fahrenheit:
+		temperature = s.targetTempC()
+	case s.unit != api.Fahrenheit && displayUnit == api.Fahrenheit:
+		temperature = s.temperature*9/5 + 32
+	}
+
+	msg := fmt.Sprintf("%.1f%s", temperature, displayUnit.String())
+	if s.frontDefroster {
+		msg += " with front defroster on"
+	}
+	if s.rearDefroster {
+		if s.frontDefroster {
+			msg += " and rear defroster on"
+		} else {
+			msg += " with rear defroster on"
+		}
+	}
+
+	return msg
+}
+
+// climateSettingsConfig returns the confirmable command that sends settings and
+// waits for the vehicle to report them. With turnOn, climate is turned on after
+// the settings are sent.
+func climateSettingsConfig(settings climateSettings, turnOn bool) ConfirmableCommandConfig {
+	cmdConfig := ConfirmableCommandConfig{
+		Actions: []ControlStep{{
+			Endpoint: api.EndpointUpdateHVACSetting,
+			Params:   api.HVACSettingParams(settings.temperature, settings.unit, settings.frontDefroster, settings.rearDefroster),
+		}},
+		Confirm: &ConfirmStep{
+			Refresh:  api.EndpointRefreshVehicleStatus,
+			Endpoint: api.EndpointGetEVVehicleStatus,
+			Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+				return waitForHvacSettings(ctx, out, &clientAdapter{Client: client}, internalVIN, settings.targetTempC(), settings.frontDefroster, settings.rearDefroster, timeout, pollInterval)
+			},
+		},
+		InitialDelay:  ConfirmationInitialDelay,
+		SuccessMsg:    "Climate set to " + settings.describe(settings.unit),
+		WaitingMsg:    "Climate set command sent, waiting for confirmation...",
+		ActionName:    "set HVAC settings",
+		ConfirmName:   "HVAC settings",
+		TimeoutSuffix: "confirmation timeout",
+	}
+	if turnOn {
+		cmdConfig.Actions = append(cmdConfig.Actions, ControlStep{Endpoint: api.EndpointHVACOn})
+		cmdConfig.WaitingMsg = "Climate on command sent, waiting for confirmation..."
+		cmdConfig.ActionName = "turn HVAC on with preset"
+	}
+
+	return cmdConfig
+}
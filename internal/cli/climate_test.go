@@ -1,8 +1,14 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,6 +31,8 @@ func TestClimateCommand_Subcommands(t *testing.T) {
 	t.Run("set", func(t *testing.T) {
 		assertSubcommandExists(t, cmd, "set", false)
 	})
+
+	assertFlagExists(t, findSubcommand(cmd, "off"), FlagAssertion{Name: "confirm-defrosters", DefaultValue: "false"})
 }
 
 // TestClimateCommand_SetSubcommand_Flags tests climate set subcommand flags.
@@ -41,3 +49,133 @@ func TestClimateCommand_SetSubcommand_Flags(t *testing.T) {
 	assertFlagExists(t, setCmd, FlagAssertion{Name: "front-defrost"})
 	assertFlagExists(t, setCmd, FlagAssertion{Name: "rear-defrost"})
 }
+
+// TestClimateCommand_OnSubcommand_Flags tests climate on subcommand flags.
+func TestClimateCommand_OnSubcommand_Flags(t *testing.T) {
+	t.Parallel()
+	onCmd := findSubcommand(NewClimateCmd(), "on")
+	require.NotNil(t, onCmd, "Expected on subcommand to exist")
+
+	assertFlagExists(t, onCmd, FlagAssertion{Name: "preset"})
+	assertFlagExists(t, onCmd, FlagAssertion{Name: "confirm", DefaultValue: "true"})
+}
+
+// TestLoadClimatePreset tests resolving climate presets from the config file.
+func TestLoadClimatePreset(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+[climate_presets.winter]
+temp = 72
+unit = "f"
+front_defrost = true
+rear_defrost = true
+
+[climate_presets.summer]
+temp = 68
+
+[climate_presets.sauna]
+temp = 40
+`), 0600))
+
+	tests := []struct {
+		name        string
+		preset      string
+		tempUnit    string
+		want        climateSettings
+		wantDescr   string
+		expectError string
+	}{
+		{
+			name:      "preset with its own unit",
+			preset:    "winter",
+			tempUnit:  "c",
+			want:      climateSettings{temperature: 72, unit: api.Fahrenheit, frontDefroster: true, rearDefroster: true},
+			wantDescr: "22.2C with front defroster on and rear defroster on",
+		},
+		{
+			name:      "preset names are case-insensitive",
+			preset:    "Winter",
+			tempUnit:  "f",
+			want:      climateSettings{temperature: 72, unit: api.Fahrenheit, frontDefroster: true, rearDefroster: true},
+			wantDescr: "72.0F with front defroster on and rear defroster on",
+		},
+		{
+			name:      "preset without a unit uses --temp-unit",
+			preset:    "summer",
+			tempUnit:  "f",
+			want:      climateSettings{temperature: 68, unit: api.Fahrenheit},
+			wantDescr: "68.0F",
+		},
+		{
+			name:        "temperature out of range",
+			preset:      "sauna",
+			tempUnit:    "c",
+			expectError: `climate preset "sauna": temp must be between 15 and 32C, got 40`,
+		},
+		{
+			name:        "unknown preset",
+			preset:      "spring",
+			expectError: `unknown climate preset "spring" (defined: sauna, summer, winter)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := ContextWithConfig(context.Background(), &CLIConfig{ConfigFile: configPath, TempUnit: tt.tempUnit})
+
+			settings, err := loadClimatePreset(ctx, tt.preset)
+			if tt.expectError != "" {
+				require.EqualError(t, err, tt.expectError)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, settings)
+
+			displayUnit, err := temperatureUnitFromContext(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantDescr, settings.describe(displayUnit))
+		})
+	}
+}
+
+// TestClimateOnPreset_Explain tests that climate on --preset goes through the
+// shared confirmable command, so that --explain shows the settings being sent.
+func TestClimateOnPreset_Explain(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("[climate_presets.winter]\ntemp = 22\n"), 0600))
+	cfg := testCLIConfig()
+	cfg.CacheFile = filepath.Join(t.TempDir(), "token.json")
+	rootCmd := NewRootCmd(cfg)
+	rootCmd.AddCommand(NewClimateCmd())
+	var out bytes.Buffer
+	rootCmd.SetArgs([]string{"--config", configPath, "climate", "on", "--preset", "winter", "--explain", "--confirm=false"})
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, rootCmd.Execute())
+
+	assert.Contains(t, out.String(), "  5. POST remoteServices/updateHVACSetting/v4\n  6. POST remoteServices/hvacOn/v4\n")
+}
+
+// TestClimateSettings_Validate tests the temperature range in both units.
+func TestClimateSettings_Validate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, climateSettings{temperature: 22, unit: api.Celsius}.validate())
+	require.NoError(t, climateSettings{temperature: 72, unit: api.Fahrenheit}.validate())
+	require.EqualError(t, climateSettings{temperature: 72, unit: api.Celsius}.validate(), "temp must be between 15 and 32C, got 72")
+	require.EqualError(t, climateSettings{temperature: 22, unit: api.Fahrenheit}.validate(), "temp must be between 59 and 89.6F, got 22")
+}
+
+// TestLoadClimatePreset_NoPresets tests the error when no presets are defined.
+func TestLoadClimatePreset_NoPresets(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`email = "test@example.com"`), 0600))
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{ConfigFile: configPath})
+
+	_, err := loadClimatePreset(ctx, "winter")
+	require.ErrorContains(t, err, "the config file has no [climate_presets.<name>] tables")
+}
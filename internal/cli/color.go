@@ -6,6 +6,8 @@ import (
 	"os"
 	"strings"
 	"sync"
+
+	"github.com/spf13/cobra"
 )
 
 // ANSI color codes.
@@ -17,13 +19,67 @@ const (
 	colorBold   = "\033[1m"
 )
 
-// colorEnabled tracks whether color output is enabled
-// Disabled by default if NO_COLOR env var is set (https://no-color.org/)
+// colorEnabled tracks whether color output is enabled. The root command sets it
+// once per run from shouldColor.
 var (
-	colorEnabled = os.Getenv("NO_COLOR") == ""
+	colorEnabled = true
 	colorMu      sync.RWMutex
 )
 
+// Values of the --color flag.
+const (
+	colorModeAuto   = "auto"
+	colorModeAlways = "always"
+	colorModeNever  = "never"
+)
+
+// validateColorMode rejects --color values other than auto, always, and never.
+func validateColorMode(mode string) error {
+	switch mode {
+	case "", colorModeAuto, colorModeAlways, colorModeNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid --color %q: must be %s, %s, or %s", mode, colorModeAuto, colorModeAlways, colorModeNever)
+	}
+}
+
+// shouldColor decides whether cmd colors its output. --color always and never
+// are final. With --color auto, color is off for --no-color (or no_color in
+// the config file), when the NO_COLOR environment variable is set
+// (https://no-color.org/), for JSON and table output, and when the output isn't
+// a terminal.
+func shouldColor(cmd *cobra.Command) bool {
+	cfg := ConfigFromContext(cmd.Context())
+	if cfg == nil {
+		cfg = &CLIConfig{}
+	}
+
+	switch cfg.Color {
+	case colorModeAlways:
+		return true
+	case colorModeNever:
+		return false
+	}
+	if cfg.NoColor || os.Getenv("NO_COLOR") != "" || isPlainOutput(cmd) {
+		return false
+	}
+
+	return IsTTY(cmd.OutOrStdout())
+}
+
+// isPlainOutput reports whether cmd was asked for JSON or table output, which
+// must not contain color codes.
+func isPlainOutput(cmd *cobra.Command) bool {
+	for _, name := range []string{"json", "json-compact"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Value.String() == "true" {
+			return true
+		}
+	}
+	flag := cmd.Flags().Lookup("output")
+
+	return flag != nil && flag.Value.String() == outputModeTable
+}
+
 // SetColorEnabled sets whether color output is enabled.
 func SetColorEnabled(enabled bool) {
 	colorMu.Lock()
@@ -109,11 +165,13 @@ func ColorPressure(pressure float64, targetPSI float64) string {
 	}
 }
 
-// ProgressBar creates a simple ASCII progress bar
+// ProgressBar creates a simple ASCII progress bar of width segments, or
+// defaultBarWidth if width isn't positive, colored by level: red below 20%,
+// yellow below 50%, green otherwise.
 // Example: [████████░░] 80%.
 func ProgressBar(percent float64, width int) string {
 	if width <= 0 {
-		width = 10
+		width = defaultBarWidth
 	}
 
 	// Clamp percent to 0-100 range
@@ -132,13 +190,19 @@ func ProgressBar(percent float64, width int) string {
 	// Add color based on level
 	var coloredBar string
 	switch {
-	case percent >= 80:
-		coloredBar = Green(bar)
-	case percent >= 30:
+	case percent < 20:
+		coloredBar = Red(bar)
+	case percent < 50:
 		coloredBar = Yellow(bar)
 	default:
-		coloredBar = Red(bar)
+		coloredBar = Green(bar)
 	}
 
 	return fmt.Sprintf("%s %.0f%%", coloredBar, percent)
 }
+
+// defaultBarWidth is the number of segments in a level bar unless --bar-width is set.
+const defaultBarWidth = 10
+
+// maxBarWidth caps --bar-width so the status line still fits a terminal.
+const maxBarWidth = 50
@@ -1,10 +1,16 @@
 package cli
 
 import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
 	"sync"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // colorTestMutex serializes color tests that modify global colorEnabled state.
@@ -115,6 +121,41 @@ func TestProgressBar_WithColors(t *testing.T) {
 	}
 }
 
+// TestProgressBar_Thresholds tests the level colors and widths of the bar.
+func TestProgressBar_Thresholds(t *testing.T) {
+	t.Parallel()
+	colorTestMutex.Lock()
+	defer colorTestMutex.Unlock()
+
+	oldColorEnabled := IsColorEnabled()
+	defer SetColorEnabled(oldColorEnabled)
+
+	tests := []struct {
+		name          string
+		level         float64
+		width         int
+		expectedPlain string
+		expectedColor string
+	}{
+		{name: "low is red", level: 19, width: 10, expectedPlain: "[█░░░░░░░░░] 19%", expectedColor: colorRed},
+		{name: "threshold 20 is yellow", level: 20, width: 10, expectedPlain: "[██░░░░░░░░] 20%", expectedColor: colorYellow},
+		{name: "medium is yellow", level: 49, width: 10, expectedPlain: "[████░░░░░░] 49%", expectedColor: colorYellow},
+		{name: "threshold 50 is green", level: 50, width: 10, expectedPlain: "[█████░░░░░] 50%", expectedColor: colorGreen},
+		{name: "wide bar", level: 66, width: 20, expectedPlain: "[█████████████░░░░░░░] 66%", expectedColor: colorGreen},
+		{name: "zero width uses default", level: 100, width: 0, expectedPlain: "[██████████] 100%", expectedColor: colorGreen},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetColorEnabled(false)
+			assert.Equal(t, tt.expectedPlain, ProgressBar(tt.level, tt.width))
+
+			SetColorEnabled(true)
+			assert.True(t, strings.HasPrefix(ProgressBar(tt.level, tt.width), tt.expectedColor))
+		})
+	}
+}
+
 func TestColorize(t *testing.T) {
 	t.Parallel()
 	colorTestMutex.Lock()
@@ -253,3 +294,82 @@ func TestColorPressure_WithColors(t *testing.T) {
 		})
 	}
 }
+
+// colorProbe returns a command with the --json and --output flags, parsed from
+// args, whose output is a character device so that it passes for a terminal.
+func colorProbe(t *testing.T, cfg *CLIConfig, args ...string) *cobra.Command {
+	t.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = devNull.Close() })
+
+	var jsonOutput, jsonCompact bool
+	var output string
+	cmd := &cobra.Command{Use: "probe"}
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	addOutputFlag(cmd, &output)
+	require.NoError(t, cmd.ParseFlags(args))
+	cmd.SetOut(devNull)
+	cmd.SetContext(ContextWithConfig(context.Background(), cfg))
+
+	return cmd
+}
+
+// TestShouldColor tests the color decision. It can't run in parallel, as it sets
+// NO_COLOR.
+func TestShouldColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor string // NO_COLOR environment variable
+		cfg     CLIConfig
+		args    []string
+		want    bool
+	}{
+		{name: "auto on a terminal", cfg: CLIConfig{Color: colorModeAuto}, want: true},
+		{name: "unset mode is auto", want: true},
+		{name: "NO_COLOR with auto", noColor: "1", cfg: CLIConfig{Color: colorModeAuto}, want: false},
+		{name: "NO_COLOR with always", noColor: "1", cfg: CLIConfig{Color: colorModeAlways}, want: true},
+		{name: "never", cfg: CLIConfig{Color: colorModeNever}, want: false},
+		{name: "--no-color", cfg: CLIConfig{NoColor: true}, want: false},
+		{name: "--json", args: []string{"--json"}, want: false},
+		{name: "--json-compact", args: []string{"--json-compact"}, want: false},
+		{name: "--output table", args: []string{"--output", "table"}, want: false},
+		{name: "--output text", args: []string{"--output", "text"}, want: true},
+		{name: "always with --json", cfg: CLIConfig{Color: colorModeAlways}, args: []string{"--json"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			assert.Equal(t, tt.want, shouldColor(colorProbe(t, &tt.cfg, tt.args...)))
+		})
+	}
+
+	t.Run("not a terminal", func(t *testing.T) {
+		t.Setenv("NO_COLOR", "")
+		cmd := colorProbe(t, &CLIConfig{Color: colorModeAuto})
+		cmd.SetOut(&bytes.Buffer{})
+		assert.False(t, shouldColor(cmd))
+	})
+}
+
+// TestRootCmd_Color tests that the root command rejects bad --color values.
+func TestRootCmd_Color(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args    []string
+		wantErr string
+	}{
+		{args: []string{"--color", "sometimes"}, wantErr: `invalid --color "sometimes": must be auto, always, or never`},
+		{args: []string{"--color", "always", "--no-color"}, wantErr: "none of the others can be"},
+	}
+
+	for _, tt := range tests {
+		rootCmd := NewRootCmd(testCLIConfig())
+		rootCmd.AddCommand(&cobra.Command{Use: "probe", Run: func(cmd *cobra.Command, args []string) {}})
+		rootCmd.SetArgs(append(tt.args, "probe"))
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		require.ErrorContains(t, rootCmd.Execute(), tt.wantErr)
+	}
+}
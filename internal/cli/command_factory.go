@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/cv/mcs/internal/api"
 	"github.com/spf13/cobra"
@@ -19,6 +21,15 @@ type CommandSpec struct {
 	ConfirmFlagUsage   string // e.g., "wait for confirmation that doors are locked"
 	ConfirmWaitDefault int    // Default timeout in seconds (use 90 if not specified)
 
+	// Supported, if set, fails the command before anything is sent when the
+	// vehicle doesn't support it.
+	Supported func(vehicleInfo VehicleInfo) error
+
+	// Prepare, if set, adjusts a copy of Config from the command's own flags
+	// before the shared flags are applied, e.g. for climate on --preset. Its
+	// error fails the command before logging in.
+	Prepare func(ctx context.Context, config *ConfirmableCommandConfig) error
+
 	// Command configuration
 	Config ConfirmableCommandConfig
 }
@@ -28,6 +39,12 @@ type CommandSpec struct {
 func buildConfirmableCommand(spec CommandSpec) *cobra.Command {
 	var confirm bool
 	var confirmWait int
+	var initialDelay int
+	var retries int
+	var force bool
+	var jsonOutput bool
+	var jsonCompact bool
+	var explain bool
 
 	// Set default confirm wait if not specified
 	if spec.ConfirmWaitDefault == 0 {
@@ -40,8 +57,44 @@ func buildConfirmableCommand(spec CommandSpec) *cobra.Command {
 		Long:    spec.Long,
 		Example: spec.Example,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return executeConfirmableCommand(ctx, cmd.OutOrStdout(), client, internalVIN, spec.Config, confirm, confirmWait)
+			if err := validateWaitSeconds("confirm-wait", confirmWait); err != nil {
+				return err
+			}
+			delaySeconds, err := resolveInitialDelay(initialDelay, confirmWait, cmd.Flags().Changed("initial-delay"), confirm)
+			if err != nil {
+				return err
+			}
+			if err := validateRetry(retries, spec.Config.UnsafeToResend, force); err != nil {
+				return err
+			}
+
+			config := spec.Config
+			if spec.Prepare != nil {
+				if err := spec.Prepare(cmd.Context(), &config); err != nil {
+					return err
+				}
+			}
+			config.InitialDelay = time.Duration(delaySeconds) * time.Second
+
+			if explain {
+				printPlan(cmd.OutOrStdout(), confirmablePlan(config, confirmOptions{
+					confirm:     confirm,
+					confirmWait: confirmWait,
+					retries:     retries,
+					force:       force,
+					action:      commandAction(cmd),
+				}))
+
+				return nil
+			}
+
+			return runConfirmableCommand(cmd, spec.Supported, config, confirmOptions{
+				confirm:     confirm,
+				confirmWait: confirmWait,
+				retries:     retries,
+				force:       force,
+				format:      newOutputFormat(cmd.Context(), jsonOutput, jsonCompact),
+				action:      commandAction(cmd),
 			})
 		},
 		SilenceUsage: true,
@@ -49,6 +102,45 @@ func buildConfirmableCommand(spec CommandSpec) *cobra.Command {
 
 	cmd.Flags().BoolVar(&confirm, "confirm", true, spec.ConfirmFlagUsage)
 	cmd.Flags().IntVar(&confirmWait, "confirm-wait", spec.ConfirmWaitDefault, "max seconds to wait for confirmation")
+	addInitialDelayFlag(cmd, &initialDelay, spec.Config.InitialDelay)
+	cmd.Flags().IntVar(&retries, "retry", 0, "re-send the command up to N times if it isn't confirmed")
+	switch {
+	case spec.Config.UnsafeToResend && spec.Config.Skip != nil:
+		cmd.Flags().BoolVar(&force, "force", false, "send even if the vehicle is already in the requested state, and allow --retry to re-send this command")
+	case spec.Config.UnsafeToResend:
+		cmd.Flags().BoolVar(&force, "force", false, "allow --retry to re-send this command")
+	case spec.Config.Skip != nil:
+		cmd.Flags().BoolVar(&force, "force", false, "send even if the vehicle is already in the requested state")
+	}
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().BoolVar(&explain, "explain", false, "print the API calls the command would make instead of running it")
 
 	return cmd
 }
+
+// runConfirmableCommand runs config on the selected vehicle. Every confirmable
+// command goes through it, so that the vehicle's support for the command is
+// checked before anything is sent. supported may be nil.
+func runConfirmableCommand(cmd *cobra.Command, supported func(VehicleInfo) error, config ConfirmableCommandConfig, opts confirmOptions) error {
+	return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+		if supported != nil {
+			if err := supported(vehicleInfo); err != nil {
+				return err
+			}
+		}
+		opts.vin = vehicleInfo.displayVIN()
+
+		return executeConfirmableCommand(ctx, cmd.OutOrStdout(), client, vehicleInfo.InternalVIN, config, opts)
+	})
+}
+
+// addInitialDelayFlag registers --initial-delay on a confirmable command, defaulting
+// to the command's InitialDelay.
+func addInitialDelayFlag(cmd *cobra.Command, seconds *int, defaultDelay time.Duration) {
+	cmd.Flags().IntVar(seconds, "initial-delay", int(defaultDelay.Seconds()), "seconds to wait after sending before polling for confirmation (0 polls immediately)")
+}
+
+// commandAction returns the command path without the root name, e.g. "charge start".
+func commandAction(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
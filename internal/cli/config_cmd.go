@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cv/mcs/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// NewConfigCmd creates the config command.
+func NewConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage the config file",
+		Long:  `Manage the config file holding account settings and defaults for flags.`,
+		Example: `  # Write a commented config file to ~/.config/mcs/config.toml
+  mcs config init`,
+	}
+
+	cmd.AddCommand(newConfigInitCmd())
+
+	return cmd
+}
+
+// newConfigInitCmd creates the config init subcommand.
+func newConfigInitCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Write a commented config file template",
+		Long: `Write a commented config file template with account settings and a
+[defaults] table. Flags given on the command line override [defaults].
+
+The file is written to --config if given, otherwise to the --profile's config
+location (~/.config/mcs/config.toml by default). An existing file is kept
+unless --force is given.`,
+		Example: `  # Create ~/.config/mcs/config.toml
+  mcs config init
+
+  # Create a config for the "work" profile
+  mcs --profile work config init`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path, err := configInitPath(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := config.WriteTemplate(path, force); err != nil {
+				if errors.Is(err, config.ErrConfigExists) {
+					return fmt.Errorf("%w (use --force to overwrite)", err)
+				}
+
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Wrote %s\n", path)
+
+			return nil
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "overwrite an existing config file")
+
+	return cmd
+}
+
+// configInitPath returns where config init writes the template.
+func configInitPath(ctx context.Context) (string, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return "", err
+	}
+	if paths.ConfigFile != "" {
+		return paths.ConfigFile, nil
+	}
+
+	return config.DefaultConfigPath()
+}
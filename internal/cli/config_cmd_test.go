@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResolveSetting tests flag-over-config-over-default precedence.
+func TestResolveSetting(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "f", resolveSetting("f", true, "c", "c"))
+	assert.Equal(t, "mi", resolveSetting("km", false, "mi", "km"))
+	assert.Equal(t, "km", resolveSetting("km", false, "", "km"))
+	assert.Equal(t, 30, resolveSetting(60, false, 30, 60))
+	assert.Equal(t, 10, resolveSetting(10, true, 30, 60))
+}
+
+// TestApplyConfigDefaults tests that config defaults only fill in flags that weren't given.
+func TestApplyConfigDefaults(t *testing.T) {
+	t.Parallel()
+	cfg := &CLIConfig{}
+	rootCmd := NewRootCmd(cfg)
+	require.NoError(t, rootCmd.ParseFlags([]string{"--temp-unit", "c"}))
+
+	applyConfigDefaults(rootCmd, cfg, config.Defaults{
+		DistanceUnit: "mi",
+		TempUnit:     "f",
+		Locale:       "de-DE",
+		PollInterval: 30,
+	})
+
+	assert.Equal(t, "mi", cfg.DistanceUnit, "config overrides built-in default")
+	assert.Equal(t, "c", cfg.TempUnit, "flag overrides config")
+	assert.Equal(t, "de-DE", cfg.Locale)
+	assert.Equal(t, themeNameASCII, cfg.Theme, "built-in default when neither is set")
+	assert.False(t, cfg.NoColor)
+	assert.Equal(t, 30, cfg.PollInterval)
+}
+
+// TestConfigInit tests writing the config template to --config.
+func TestConfigInit(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{ConfigFile: configPath})
+
+	cmd := newConfigInitCmd()
+	cmd.SetContext(ctx)
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, cmd.RunE(cmd, nil))
+	assert.Equal(t, "Wrote "+configPath+"\n", out.String())
+
+	content, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, config.Template, string(content))
+
+	err = cmd.RunE(cmd, nil)
+	require.ErrorIs(t, err, config.ErrConfigExists)
+	assert.Contains(t, err.Error(), "--force")
+}
@@ -2,8 +2,10 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
@@ -15,6 +17,9 @@ type confirmationResult struct {
 	err     error
 }
 
+// clearProgressLine blanks the in-place progress line written while polling.
+const clearProgressLine = "\r                                        \r"
+
 // pollUntilCondition polls a condition function until it returns true or times out.
 // It returns a result indicating success or timeout, and any error encountered.
 func pollUntilCondition(
@@ -24,12 +29,32 @@ func pollUntilCondition(
 	timeout time.Duration,
 	pollInterval time.Duration,
 	actionName string,
+) confirmationResult {
+	return pollUntilConditionWithProgress(ctx, out, checkFunc, timeout, fixedInterval(pollInterval), actionName, "Waiting for confirmation")
+}
+
+// fixedInterval returns a poll interval function that always returns interval.
+func fixedInterval(interval time.Duration) func() time.Duration {
+	return func() time.Duration { return interval }
+}
+
+// pollUntilConditionWithProgress is pollUntilCondition with a custom progress line label
+// (e.g. "Waiting for battery>=80"). nextInterval is called before each wait, so it
+// can vary the interval between polls.
+func pollUntilConditionWithProgress(
+	ctx context.Context,
+	out io.Writer,
+	checkFunc func() (bool, error),
+	timeout time.Duration,
+	nextInterval func() time.Duration,
+	actionName string,
+	progressLabel string,
 ) confirmationResult {
 	// Create a context with timeout
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ticker := time.NewTicker(pollInterval)
+	ticker := time.NewTicker(nextInterval())
 	defer ticker.Stop()
 
 	startTime := time.Now()
@@ -38,6 +63,7 @@ func pollUntilCondition(
 	if met, err := checkFunc(); err != nil {
 		// Treat errors as "condition not yet met" - retry instead of failing immediately
 		// This handles transient errors like nil HVAC info or temporary API issues
+		ticker.Reset(nextPollInterval(out, err, nextInterval()))
 	} else if met {
 		return confirmationResult{success: true, err: nil}
 	}
@@ -54,26 +80,29 @@ func pollUntilCondition(
 			if elapsedSec > lastPrintedSecond {
 				lastPrintedSecond = elapsedSec
 				// Use \r to update in place, then clear to end of line
-				_, _ = fmt.Fprintf(out, "\rWaiting for confirmation... (%ds/%ds)   ",
-					elapsedSec, int(timeout.Seconds()))
+				_, _ = fmt.Fprintf(out, "\r%s... (%ds/%ds)   ",
+					progressLabel, elapsedSec, int(timeout.Seconds()))
 			}
 
 			met, err := checkFunc()
 			if err != nil {
 				// Treat errors as "condition not yet met" - continue polling
 				// This allows recovery from transient errors
+				ticker.Reset(nextPollInterval(out, err, nextInterval()))
+
 				continue
 			}
+			ticker.Reset(nextInterval())
 			if met {
 				// Clear the progress line and move to new line
-				_, _ = fmt.Fprint(out, "\r                                        \r")
+				_, _ = fmt.Fprint(out, clearProgressLine)
 
 				return confirmationResult{success: true, err: nil}
 			}
 
 		case <-timeoutCtx.Done():
 			// Clear the progress line and move to new line
-			_, _ = fmt.Fprint(out, "\r                                        \r")
+			_, _ = fmt.Fprint(out, clearProgressLine)
 			if timeoutCtx.Err() == context.DeadlineExceeded {
 				_, _ = fmt.Fprintf(out, "Warning: %s not confirmed within timeout period\n", actionName)
 
@@ -85,6 +114,20 @@ func pollUntilCondition(
 	}
 }
 
+// nextPollInterval returns how long to wait before the next check after a failed one.
+// Rate-limited checks back off for the server's suggested cooldown; other errors keep
+// the normal interval.
+func nextPollInterval(out io.Writer, err error, pollInterval time.Duration) time.Duration {
+	var rateErr *api.RateLimitedError
+	if !errors.As(err, &rateErr) || rateErr.RetryAfter <= pollInterval {
+		return pollInterval
+	}
+
+	_, _ = fmt.Fprintf(out, "\rRate limited, backing off for %ds...   ", int(rateErr.RetryAfter.Seconds()))
+
+	return rateErr.RetryAfter
+}
+
 // vehicleStatusGetter is an interface for getting vehicle status
 // This allows for easier testing by mocking the API client.
 type vehicleStatusGetter interface {
@@ -319,8 +362,31 @@ func waitForNotCharging(
 	return waitForCondition(ctx, out, client, internalVIN, true, conditionChecker, timeout, pollInterval, "charging stop")
 }
 
+// skipIfCharging returns a message saying the command is unnecessary when the
+// vehicle's charging state already matches charging, or "" otherwise.
+func skipIfCharging(ctx context.Context, client vehicleStatusGetter, internalVIN api.InternalVIN, charging bool) (string, error) {
+	evStatus, err := client.GetEVVehicleStatus(ctx, internalVIN)
+	if err != nil {
+		return "", err
+	}
+	batteryInfo, err := evStatus.GetBatteryInfo()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case batteryInfo.Charging != charging:
+		return "", nil
+	case charging:
+		return "Already charging; charge start not sent (use --force to send it anyway)", nil
+	default:
+		return "Not charging; charge stop not sent (use --force to send it anyway)", nil
+	}
+}
+
 // ConfirmationInitialDelay is the time to wait before polling for command confirmation.
-// Commands take time to propagate to the server before status is updated.
+// Commands take time to propagate to the server before status is updated. It is
+// the default of each confirmable command's --initial-delay flag.
 const ConfirmationInitialDelay = 20 * time.Second
 
 // waitForHvacOn polls the vehicle status until HVAC is on or timeout occurs.
@@ -375,6 +441,55 @@ func waitForHvacOff(
 	return waitForCondition(ctx, out, client, internalVIN, true, conditionChecker, timeout, pollInterval, "HVAC off")
 }
 
+// waitForHvacAndDefrostersOff polls the vehicle status until HVAC and both
+// defrosters are off or timeout occurs. Some vehicles leave a defroster running
+// after HVAC turns off; if that's still the case at the timeout, it warns and
+// reports success, since HVAC itself did turn off.
+func waitForHvacAndDefrostersOff(
+	ctx context.Context,
+	out io.Writer,
+	client vehicleStatusGetter,
+	internalVIN api.InternalVIN,
+	timeout time.Duration,
+	pollInterval time.Duration,
+) confirmationResult {
+	var last *api.HVACInfo
+	conditionChecker := func(status any) (bool, error) {
+		evStatus, ok := status.(*api.EVVehicleStatusResponse)
+		if !ok {
+			return false, fmt.Errorf("unexpected status type: %T", status)
+		}
+
+		hvacInfo, err := evStatus.GetHvacInfo()
+		if err != nil {
+			return false, err
+		}
+		last = &hvacInfo
+
+		return !hvacInfo.HVACOn && !hvacInfo.FrontDefrosterOn() && !hvacInfo.RearDefrosterOn(), nil
+	}
+
+	result := waitForCondition(ctx, out, client, internalVIN, true, conditionChecker, timeout, pollInterval, "HVAC and defrosters off")
+	if result.success || result.err != nil || last == nil || last.HVACOn {
+		return result
+	}
+
+	var running []string
+	if last.FrontDefrosterOn() {
+		running = append(running, "front")
+	}
+	if last.RearDefrosterOn() {
+		running = append(running, "rear")
+	}
+	noun := "defroster is"
+	if len(running) > 1 {
+		noun = "defrosters are"
+	}
+	_, _ = fmt.Fprintf(out, "Warning: HVAC is off but the %s %s still on\n", strings.Join(running, " and "), noun)
+
+	return confirmationResult{success: true}
+}
+
 // waitForHvacSettings polls the vehicle status until HVAC settings match the requested values or timeout occurs.
 func waitForHvacSettings(
 	ctx context.Context,
@@ -403,9 +518,10 @@ func waitForHvacSettings(
 		tempMatch := hvacInfo.TargetTempC >= targetTemp-tempTolerance &&
 			hvacInfo.TargetTempC <= targetTemp+tempTolerance
 
-		// Check defroster settings.
-		defrostersMatch := hvacInfo.FrontDefroster == frontDefroster &&
-			hvacInfo.RearDefroster == rearDefroster
+		// Check defroster settings. Vehicles that don't report a defroster
+		// can't have turned it on.
+		defrostersMatch := hvacInfo.FrontDefrosterOn() == frontDefroster &&
+			hvacInfo.RearDefrosterOn() == rearDefroster
 
 		return tempMatch && defrostersMatch, nil
 	}
@@ -413,17 +529,148 @@ func waitForHvacSettings(
 	return waitForCondition(ctx, out, client, internalVIN, true, conditionChecker, timeout, pollInterval, "HVAC settings")
 }
 
+// waitForPluggedIn polls the EV status until the charger is connected. Unlike
+// confirmation polling it doesn't request a refresh, since the wait can be long
+// and each refresh wakes the vehicle. It returns a timeoutError if the charger
+// isn't connected in time.
+func waitForPluggedIn(
+	ctx context.Context,
+	out io.Writer,
+	client vehicleStatusGetter,
+	internalVIN api.InternalVIN,
+	timeout time.Duration,
+	pollInterval time.Duration,
+) error {
+	checkFunc := func() (bool, error) {
+		evStatus, err := client.GetEVVehicleStatus(ctx, internalVIN)
+		if err != nil {
+			return false, err
+		}
+		batteryInfo, err := evStatus.GetBatteryInfo()
+		if err != nil {
+			return false, err
+		}
+
+		return batteryInfo.PluggedIn, nil
+	}
+
+	result := pollUntilConditionWithProgress(ctx, out, checkFunc, timeout, fixedInterval(pollInterval), "charger connection", "Waiting for charger connection")
+	if result.err != nil {
+		return result.err
+	}
+	if !result.success {
+		return &timeoutError{message: "charger not connected before timeout"}
+	}
+	_, _ = fmt.Fprintln(out, "Charger connected")
+
+	return nil
+}
+
 // DefaultPollInterval is the default time between status checks during confirmation polling.
 const DefaultPollInterval = 5 * time.Second
 
+// Bounds for --confirm-wait and --refresh-wait, in seconds.
+const (
+	MinWaitSeconds = 10
+	MaxWaitSeconds = 600
+)
+
+// validateWaitSeconds rejects wait flag values outside [MinWaitSeconds, MaxWaitSeconds],
+// so a typo like --confirm-wait 9000 fails fast instead of hanging for hours.
+func validateWaitSeconds(flagName string, seconds int) error {
+	if seconds < MinWaitSeconds || seconds > MaxWaitSeconds {
+		return fmt.Errorf("--%s must be between %d and %d seconds, got %d", flagName, MinWaitSeconds, MaxWaitSeconds, seconds)
+	}
+
+	return nil
+}
+
+// Minimum seconds between polls in loops such as status --watch and mcs watch.
+// A read only returns what the server last heard from the vehicle, but a
+// refresh wakes the vehicle's telematics unit and Mazda throttles accounts
+// that request them too often, so loops that refresh every poll go slower.
+const (
+	MinPollSeconds        = 10
+	MinRefreshPollSeconds = 600
+)
+
+// clampPollInterval raises a polling interval below the minimum for the loop to
+// that minimum, warning on warn. refresh is whether every poll requests a refresh.
+func clampPollInterval(warn io.Writer, flagName string, seconds int, refresh bool) int {
+	minimum, reason := MinPollSeconds, "between status checks"
+	if refresh {
+		minimum, reason = MinRefreshPollSeconds, "between refreshes, to stay within Mazda's rate limits"
+	}
+	if seconds >= minimum {
+		return seconds
+	}
+	_, _ = fmt.Fprintf(warn, "Warning: --%s %d is below the minimum of %d seconds %s; using %d\n", flagName, seconds, minimum, reason, minimum)
+
+	return minimum
+}
+
+// validateInitialDelay rejects --initial-delay values that are negative or leave
+// no time to poll within --confirm-wait.
+func validateInitialDelay(seconds, confirmWait int) error {
+	if seconds < 0 || seconds >= confirmWait {
+		return fmt.Errorf("--initial-delay must be at least 0 and less than --confirm-wait (%d seconds), got %d", confirmWait, seconds)
+	}
+
+	return nil
+}
+
+// resolveInitialDelay returns the initial delay to use. An --initial-delay the
+// user set is validated when confirmation is on; the default is shortened to
+// half of a short --confirm-wait instead, so that e.g. --confirm-wait 15 works
+// without also passing --initial-delay.
+func resolveInitialDelay(seconds, confirmWait int, explicit, confirm bool) (int, error) {
+	if explicit {
+		if !confirm {
+			return seconds, nil
+		}
+
+		return seconds, validateInitialDelay(seconds, confirmWait)
+	}
+
+	return min(seconds, confirmWait/2), nil
+}
+
+// MaxRetries is the most re-sends allowed by --retry. Every re-send is another
+// remote command and counts against the API's rate limits.
+const MaxRetries = 5
+
+// validateRetry rejects out-of-range --retry values, and re-sending commands that
+// are unsafe to repeat unless --force is given.
+func validateRetry(retries int, unsafeToResend, force bool) error {
+	if retries < 0 || retries > MaxRetries {
+		return fmt.Errorf("--retry must be between 0 and %d, got %d", MaxRetries, retries)
+	}
+	if retries > 0 && unsafeToResend && !force {
+		return errors.New("--retry re-sends this command if it isn't confirmed; pass --force to allow it")
+	}
+
+	return nil
+}
+
 // ConfirmableCommandConfig holds the configuration for a confirmable command.
+// Running the command and --explain both go through Before, Skip, Actions and
+// Confirm, so the plan lists the endpoints the command actually calls.
 type ConfirmableCommandConfig struct {
-	// ActionFunc performs the API action (e.g., lock doors, start engine)
-	ActionFunc func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error
+	// Before, if set, runs once before the first action (e.g. waiting for the
+	// charger to be plugged in). Its error aborts the command.
+	Before *BeforeStep
 
-	// WaitFunc waits for confirmation that the action completed
+	// Skip, if set, checks before the action whether the vehicle is already in
+	// the requested state. A non-empty message skips the action and is reported
+	// instead. --force bypasses the check; if the check fails, the action is sent.
+	Skip *SkipCheck
+
+	// Actions are the control commands sent, in order (e.g. lock doors).
+	Actions []ControlStep
+
+	// Confirm waits for confirmation that the actions completed.
 	// If nil, confirmation is skipped
-	WaitFunc func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult
+	Confirm *ConfirmStep
 
 	// InitialDelay is the time to wait before starting confirmation polling.
 	// Some commands (like HVAC) need time to propagate before status is updated.
@@ -432,6 +679,10 @@ type ConfirmableCommandConfig struct {
 	// PollInterval is the time between status checks. If zero, DefaultPollInterval is used.
 	PollInterval time.Duration
 
+	// UnsafeToResend marks commands that --retry may only re-send with --force,
+	// because repeating them has consequences (e.g. unlocking a car that did unlock).
+	UnsafeToResend bool
+
 	// Messages
 	SuccessMsg    string // Message to show on success (e.g., "Doors locked successfully")
 	WaitingMsg    string // Message to show while waiting (e.g., "Lock command sent, waiting for confirmation...")
@@ -440,6 +691,46 @@ type ConfirmableCommandConfig struct {
 	TimeoutSuffix string // Suffix for timeout message (e.g., "confirmation timeout")
 }
 
+// BeforeStep runs before a confirmable command's actions.
+type BeforeStep struct {
+	// Run performs the step, writing progress to out.
+	Run func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN) error
+
+	// Plan lists the calls Run makes given the command's flags, for --explain.
+	Plan func() []PlanStep
+}
+
+// SkipCheck reads the vehicle's state to decide whether to skip a command.
+type SkipCheck struct {
+	Endpoint string // status endpoint Check reads
+	Check    func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) (string, error)
+}
+
+// ControlStep is one control command a confirmable command sends.
+type ControlStep struct {
+	Endpoint string         // control endpoint, e.g. api.EndpointDoorLock
+	Params   map[string]any // additional body parameters, e.g. api.HVACSettingParams
+
+	// send, if set, replaces the request; for tests.
+	send func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error
+}
+
+// run sends the control command.
+func (s ControlStep) run(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+	if s.send != nil {
+		return s.send(ctx, client, internalVIN)
+	}
+
+	return client.SendControl(ctx, s.Endpoint, string(internalVIN), s.Params)
+}
+
+// ConfirmStep polls the vehicle's status until a command is confirmed.
+type ConfirmStep struct {
+	Refresh  string // refresh request Wait sends before polling; empty if none
+	Endpoint string // status endpoint Wait polls
+	Wait     func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult
+}
+
 // buildTimeoutMessage constructs the timeout message from waiting message and suffix.
 func buildTimeoutMessage(waitingMsg, timeoutSuffix string) string {
 	// Extract the command part from waiting message
@@ -465,55 +756,152 @@ func applyInitialDelay(ctx context.Context, delay time.Duration, actionName stri
 	}
 }
 
+// Confirmation outcomes reported in JSON output.
+const (
+	confirmOutcomeSent      = "sent"    // --confirm=false: the command was accepted but not awaited
+	confirmOutcomeSkipped   = "skipped" // already in the requested state; nothing was sent
+	confirmOutcomeConfirmed = "confirmed"
+	confirmOutcomeTimeout   = "timeout"
+	confirmOutcomeError     = "error"
+)
+
+// confirmOptions controls confirmation polling and how the outcome is reported.
+type confirmOptions struct {
+	confirm     bool
+	confirmWait int
+	retries     int          // re-sends after a confirmation timeout
+	force       bool         // send even if Skip says it's unnecessary
+	format      outputFormat // JSON replaces all text output with one result object
+	action      string       // command name reported in JSON, e.g. "lock" or "charge start"
+	vin         string       // reported in JSON
+}
+
+// confirmationToMap builds the JSON result for a confirmable command.
+func confirmationToMap(opts confirmOptions, outcome string, elapsed time.Duration, err error) map[string]any {
+	data := map[string]any{
+		"action":     opts.action,
+		"vin":        opts.vin,
+		"status":     outcome,
+		"confirmed":  outcome == confirmOutcomeConfirmed,
+		"elapsed_ms": elapsed.Milliseconds(),
+	}
+	if err != nil {
+		data["error"] = err.Error()
+		if codes := errorCodes(err); codes != "" {
+			data["error_code"] = codes
+		}
+	}
+
+	return data
+}
+
+// reportConfirmation prints the outcome of a confirmable command: msg in text mode,
+// or a JSON result object. A non-nil err is returned after reporting it. With
+// --notify, outcomes that were waited for are also shown as a desktop notification.
+func reportConfirmation(ctx context.Context, out io.Writer, opts confirmOptions, outcome, msg string, startTime time.Time, err error) error {
+	if outcome != confirmOutcomeSent && outcome != confirmOutcomeSkipped {
+		notifyDone(ctx, "mcs "+opts.action, confirmationSummary(outcome, time.Since(startTime), err))
+	}
+
+	if !opts.format.isJSON() {
+		if msg != "" {
+			_, _ = fmt.Fprintln(out, msg)
+		}
+
+		return err
+	}
+
+	output, jsonErr := toJSON(confirmationToMap(opts, outcome, time.Since(startTime), err), opts.format)
+	if jsonErr != nil {
+		return jsonErr
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	return err
+}
+
 // executeConfirmableCommand executes a confirmable command with the given configuration.
+// In JSON mode, progress and waiting lines are suppressed and only the result object is printed;
+// with --quiet, they are suppressed and only the result line is printed.
 func executeConfirmableCommand(
 	ctx context.Context,
 	out io.Writer,
 	client *api.Client,
 	internalVIN api.InternalVIN,
 	config ConfirmableCommandConfig,
-	confirm bool,
-	confirmWait int,
+	opts confirmOptions,
 ) error {
-	// Execute the action
-	if err := config.ActionFunc(ctx, client, internalVIN); err != nil {
-		return fmt.Errorf("failed to %s: %w", config.ActionName, err)
-	}
-
-	// If confirmation disabled, return immediately
-	if !confirm || config.WaitFunc == nil {
-		_, _ = fmt.Fprintln(out, config.SuccessMsg)
-
-		return nil
-	}
-
-	// Wait for confirmation
-	_, _ = fmt.Fprintln(out, config.WaitingMsg)
-
-	timeout := time.Duration(confirmWait) * time.Second
-
-	// Apply initial delay if configured
-	if err := applyInitialDelay(ctx, config.InitialDelay, config.ActionName); err != nil {
-		return err
+	startTime := time.Now()
+	progress := infoWriter(ctx, out)
+	if opts.format.isJSON() {
+		progress = io.Discard
 	}
-	timeout -= config.InitialDelay
 
 	pollInterval := config.PollInterval
 	if pollInterval == 0 {
 		pollInterval = DefaultPollInterval
 	}
 
-	result := config.WaitFunc(ctx, out, client, internalVIN, timeout, pollInterval)
+	if config.Before != nil {
+		if err := config.Before.Run(ctx, progress, client, internalVIN); err != nil {
+			outcome := confirmOutcomeError
+			var timeoutErr *timeoutError
+			if errors.As(err, &timeoutErr) {
+				outcome = confirmOutcomeTimeout
+			}
 
-	if result.err != nil {
-		return fmt.Errorf("failed to confirm %s: %w", config.ConfirmName, result.err)
+			return reportConfirmation(ctx, out, opts, outcome, "", startTime, err)
+		}
 	}
 
-	if result.success {
-		_, _ = fmt.Fprintln(out, config.SuccessMsg)
-	} else {
-		_, _ = fmt.Fprintln(out, buildTimeoutMessage(config.WaitingMsg, config.TimeoutSuffix))
+	if config.Skip != nil && !opts.force {
+		msg, err := config.Skip.Check(ctx, client, internalVIN)
+		if err != nil {
+			_, _ = fmt.Fprintf(progress, "Warning: couldn't check current state, sending anyway: %v\n", err)
+		} else if msg != "" {
+			return reportConfirmation(ctx, out, opts, confirmOutcomeSkipped, msg, startTime, nil)
+		}
 	}
 
-	return nil
+	for attempt := 0; ; attempt++ {
+		// Send the actions
+		for _, action := range config.Actions {
+			if err := action.run(ctx, client, internalVIN); err != nil {
+				return reportConfirmation(ctx, out, opts, confirmOutcomeError, "", startTime, fmt.Errorf("failed to %s: %w", config.ActionName, err))
+			}
+		}
+
+		// If confirmation disabled, return immediately
+		if !opts.confirm || config.Confirm == nil {
+			return reportConfirmation(ctx, out, opts, confirmOutcomeSent, config.SuccessMsg, startTime, nil)
+		}
+
+		// Wait for confirmation
+		_, _ = fmt.Fprintln(progress, config.WaitingMsg)
+
+		// Apply initial delay if configured
+		if err := applyInitialDelay(ctx, config.InitialDelay, config.ActionName); err != nil {
+			return reportConfirmation(ctx, out, opts, confirmOutcomeError, "", startTime, err)
+		}
+		timeout := time.Duration(opts.confirmWait)*time.Second - config.InitialDelay
+
+		result := config.Confirm.Wait(ctx, progress, client, internalVIN, timeout, pollInterval)
+
+		if result.err != nil {
+			return reportConfirmation(ctx, out, opts, confirmOutcomeError, "", startTime, fmt.Errorf("failed to confirm %s: %w", config.ConfirmName, result.err))
+		}
+
+		if result.success {
+			return reportConfirmation(ctx, out, opts, confirmOutcomeConfirmed, config.SuccessMsg, startTime, nil)
+		}
+
+		if attempt >= opts.retries {
+			timeoutErr := &timeoutError{message: buildTimeoutMessage(config.WaitingMsg, config.TimeoutSuffix)}
+
+			return reportConfirmation(ctx, out, opts, confirmOutcomeTimeout, "", startTime, timeoutErr)
+		}
+
+		_, _ = fmt.Fprintf(progress, "Warning: not confirmed, re-sending (retry %d/%d); each re-send counts against API rate limits\n",
+			attempt+1, opts.retries)
+	}
 }
@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,7 +41,7 @@ func setupMockForWaitCondition(tt testWaitForConditionCase, calls *int) *mockCli
 				hvacOn := tt.statusValues[idx].(bool)
 				*calls++
 
-				return NewMockEVVehicleStatus().WithHVAC(hvacOn).Build(), nil
+				return apitest.NewEVVehicleStatus().WithHVAC(hvacOn).Build(), nil
 			},
 		}
 	}
@@ -52,7 +55,7 @@ func setupMockForWaitCondition(tt testWaitForConditionCase, calls *int) *mockCli
 			doorStatus := tt.statusValues[idx].(api.DoorStatus)
 			*calls++
 
-			return NewMockVehicleStatus().WithDoorStatus(doorStatus).Build(), nil
+			return apitest.NewVehicleStatus().WithDoorStatus(doorStatus).Build(), nil
 		},
 	}
 }
@@ -352,6 +355,51 @@ func TestPollUntilCondition(t *testing.T) {
 	}
 }
 
+func TestNextPollInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		err        error
+		want       time.Duration
+		wantOutput string
+	}{
+		{name: "generic error keeps interval", err: errors.New("boom"), want: 5 * time.Second},
+		{name: "rate limited backs off", err: api.NewRateLimitedError(90 * time.Second), want: 90 * time.Second, wantOutput: "Rate limited, backing off for 90s"},
+		{name: "short cooldown keeps interval", err: api.NewRateLimitedError(time.Second), want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			assert.Equal(t, tt.want, nextPollInterval(&buf, tt.err, 5*time.Second))
+			if tt.wantOutput == "" {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.Contains(t, buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestPollUntilCondition_BacksOffWhenRateLimited(t *testing.T) {
+	t.Parallel()
+	calls := 0
+	checkFunc := func() (bool, error) {
+		calls++
+
+		return false, api.NewRateLimitedError(time.Hour)
+	}
+
+	var buf bytes.Buffer
+	result := pollUntilCondition(context.Background(), &buf, checkFunc, testTimeout, 10*time.Millisecond, "test")
+
+	assert.False(t, result.success)
+	require.NoError(t, result.err)
+	assert.Equal(t, 1, calls, "Expected no further checks during the rate-limit cooldown")
+	assert.Contains(t, buf.String(), "Rate limited, backing off")
+}
+
 // testDoorStatusSequence is a test helper for door status confirmation tests.
 type testDoorStatusSequence struct {
 	name        string
@@ -376,7 +424,7 @@ func runDoorStatusTest(t *testing.T, tt testDoorStatusSequence, waitFunc func(co
 			status := tt.doorStatus[calls]
 			calls++
 
-			return NewMockVehicleStatus().WithDoorStatus(status).Build(), nil
+			return apitest.NewVehicleStatus().WithDoorStatus(status).Build(), nil
 		},
 	}
 
@@ -572,7 +620,7 @@ func TestWaitForEngineRunning(t *testing.T) {
 				t,
 				tt,
 				func(hvacOn bool) *api.EVVehicleStatusResponse {
-					return NewMockEVVehicleStatus().WithHVAC(hvacOn).Build()
+					return apitest.NewEVVehicleStatus().WithHVAC(hvacOn).Build()
 				},
 				waitForEngineRunning,
 				"Expected engine to be running but it wasn't",
@@ -612,7 +660,7 @@ func TestWaitForEngineStopped(t *testing.T) {
 				t,
 				tt,
 				func(hvacOn bool) *api.EVVehicleStatusResponse {
-					return NewMockEVVehicleStatus().WithHVAC(hvacOn).Build()
+					return apitest.NewEVVehicleStatus().WithHVAC(hvacOn).Build()
 				},
 				waitForEngineStopped,
 				"Expected engine to be stopped but it wasn't",
@@ -685,7 +733,7 @@ func TestWaitForCharging(t *testing.T) {
 				t,
 				tt,
 				func(charging bool) *api.EVVehicleStatusResponse {
-					return NewMockEVVehicleStatus().WithCharging(charging).Build()
+					return apitest.NewEVVehicleStatus().WithCharging(charging).Build()
 				},
 				waitForCharging,
 				"Expected charging to be started but it wasn't",
@@ -725,7 +773,7 @@ func TestWaitForNotCharging(t *testing.T) {
 				t,
 				tt,
 				func(charging bool) *api.EVVehicleStatusResponse {
-					return NewMockEVVehicleStatus().WithCharging(charging).Build()
+					return apitest.NewEVVehicleStatus().WithCharging(charging).Build()
 				},
 				waitForNotCharging,
 				"Expected charging to be stopped but it wasn't",
@@ -759,12 +807,12 @@ func setupMockForHvacOn(tt testWaitForHvacOnCase, calls *int) *mockClientForConf
 			*calls++
 
 			if shouldBeNil {
-				return NewMockEVVehicleStatus().WithoutHVAC().Build(), nil
+				return apitest.NewEVVehicleStatus().WithoutHVAC().Build(), nil
 			}
 
 			hvacOn := tt.hvacStatus[callIdx]
 
-			return NewMockEVVehicleStatus().WithHVACSettings(hvacOn, 22.0, false, false).Build(), nil
+			return apitest.NewEVVehicleStatus().WithHVACSettings(hvacOn, 22.0, false, false).Build(), nil
 		},
 	}
 }
@@ -894,7 +942,7 @@ func TestWaitForHvacOff(t *testing.T) {
 				t,
 				tt,
 				func(hvacOn bool) *api.EVVehicleStatusResponse {
-					return NewMockEVVehicleStatus().WithHVACSettings(hvacOn, 22.0, false, false).Build()
+					return apitest.NewEVVehicleStatus().WithHVACSettings(hvacOn, 22.0, false, false).Build()
 				},
 				waitForHvacOff,
 				"Expected HVAC to be off but it wasn't",
@@ -903,6 +951,82 @@ func TestWaitForHvacOff(t *testing.T) {
 	}
 }
 
+// TestWaitForHvacAndDefrostersOff tests waiting for HVAC and both defrosters to turn off.
+func TestWaitForHvacAndDefrostersOff(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		hvacResponses []hvacSettings
+		expectMet     bool
+		expectWarning string
+	}{
+		{
+			name: "all off after one check",
+			hvacResponses: []hvacSettings{
+				{hvacOn: true, frontDefrost: true},
+				{hvacOn: false},
+			},
+			expectMet: true,
+		},
+		{
+			name: "waits for the defroster after HVAC turns off",
+			hvacResponses: []hvacSettings{
+				{hvacOn: false, rearDefrost: true},
+				{hvacOn: false},
+			},
+			expectMet: true,
+		},
+		{
+			name: "defroster stays on",
+			hvacResponses: []hvacSettings{
+				{hvacOn: false, rearDefrost: true},
+			},
+			expectMet:     true,
+			expectWarning: "Warning: HVAC is off but the rear defroster is still on",
+		},
+		{
+			name: "both defrosters stay on",
+			hvacResponses: []hvacSettings{
+				{hvacOn: false, frontDefrost: true, rearDefrost: true},
+			},
+			expectMet:     true,
+			expectWarning: "Warning: HVAC is off but the front and rear defrosters are still on",
+		},
+		{
+			name: "HVAC stays on",
+			hvacResponses: []hvacSettings{
+				{hvacOn: true, frontDefrost: true},
+			},
+			expectMet: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			calls := 0
+			mockClient := &mockClientForConfirm{
+				getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+					settings := tt.hvacResponses[min(calls, len(tt.hvacResponses)-1)]
+					calls++
+
+					return apitest.NewEVVehicleStatus().WithHVACSettings(settings.hvacOn, 22.0, settings.frontDefrost, settings.rearDefrost).Build(), nil
+				},
+			}
+
+			result := waitForHvacAndDefrostersOff(context.Background(), &buf, mockClient, api.InternalVIN("test-vin"), 5*testTimeout, testTimeout)
+			require.NoError(t, result.err)
+			assert.Equal(t, tt.expectMet, result.success)
+			if tt.expectWarning != "" {
+				assert.Contains(t, buf.String(), tt.expectWarning)
+			} else {
+				assert.NotContains(t, buf.String(), "still on")
+			}
+		})
+	}
+}
+
 // TestWaitForHvacSettings tests the HVAC settings confirmation logic.
 func TestWaitForHvacSettings(t *testing.T) {
 	t.Parallel()
@@ -979,7 +1103,7 @@ func TestWaitForHvacSettings(t *testing.T) {
 					settings := tt.hvacResponses[calls]
 					calls++
 
-					return NewMockEVVehicleStatus().WithHVACSettings(
+					return apitest.NewEVVehicleStatus().WithHVACSettings(
 						settings.hvacOn,
 						settings.temp,
 						settings.frontDefrost,
@@ -1034,6 +1158,26 @@ type hvacSettings struct {
 	rearDefrost  bool
 }
 
+// testActions returns the actions of a test command, which call send instead of the API.
+func testActions(send func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error) []ControlStep {
+	return []ControlStep{{Endpoint: api.EndpointDoorLock, send: send}}
+}
+
+// testConfirm returns a confirmation step that waits with wait.
+func testConfirm(wait func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult) *ConfirmStep {
+	return &ConfirmStep{Refresh: api.EndpointRefreshVehicleStatus, Endpoint: api.EndpointGetVehicleStatus, Wait: wait}
+}
+
+// testSkip returns a skip check that checks with check.
+func testSkip(check func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) (string, error)) *SkipCheck {
+	return &SkipCheck{Endpoint: api.EndpointGetVehicleStatus, Check: check}
+}
+
+// testBefore returns a before step that runs run.
+func testBefore(run func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN) error) *BeforeStep {
+	return &BeforeStep{Run: run}
+}
+
 // TestExecuteConfirmableCommand tests the executeConfirmableCommand helper.
 func TestExecuteConfirmableCommand(t *testing.T) {
 	t.Parallel()
@@ -1050,9 +1194,9 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 		{
 			name: "success without confirmation",
 			config: ConfirmableCommandConfig{
-				ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 					return nil
-				},
+				}),
 				SuccessMsg:    "Command executed successfully",
 				WaitingMsg:    "Command sent, waiting for confirmation...",
 				ActionName:    "execute command",
@@ -1068,12 +1212,12 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 		{
 			name: "success with confirmation",
 			config: ConfirmableCommandConfig{
-				ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 					return nil
-				},
-				WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+				}),
+				Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
 					return confirmationResult{success: true, err: nil}
-				},
+				}),
 				SuccessMsg:    "Command executed successfully",
 				WaitingMsg:    "Command sent, waiting for confirmation...",
 				ActionName:    "execute command",
@@ -1090,12 +1234,12 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 		{
 			name: "timeout during confirmation",
 			config: ConfirmableCommandConfig{
-				ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 					return nil
-				},
-				WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+				}),
+				Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
 					return confirmationResult{success: false, err: nil}
-				},
+				}),
 				SuccessMsg:    "Command executed successfully",
 				WaitingMsg:    "Command sent, waiting for confirmation...",
 				ActionName:    "execute command",
@@ -1106,15 +1250,15 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 			confirmWait:    90,
 			actionError:    nil,
 			waitResult:     confirmationResult{success: false, err: nil},
-			expectError:    false,
-			expectedOutput: "Command sent, waiting for confirmation...\nCommand sent (confirmation timeout)\n",
+			expectError:    true,
+			expectedOutput: "Command sent, waiting for confirmation...\n",
 		},
 		{
 			name: "action fails",
 			config: ConfirmableCommandConfig{
-				ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 					return errors.New("action failed")
-				},
+				}),
 				SuccessMsg:    "Command executed successfully",
 				WaitingMsg:    "Command sent, waiting for confirmation...",
 				ActionName:    "execute command",
@@ -1130,12 +1274,12 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 		{
 			name: "confirmation fails with error",
 			config: ConfirmableCommandConfig{
-				ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 					return nil
-				},
-				WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+				}),
+				Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
 					return confirmationResult{success: false, err: errors.New("confirmation error")}
-				},
+				}),
 				SuccessMsg:    "Command executed successfully",
 				WaitingMsg:    "Command sent, waiting for confirmation...",
 				ActionName:    "execute command",
@@ -1162,8 +1306,7 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 				nil, // client not used in these tests
 				api.InternalVIN("test-vin"),
 				tt.config,
-				tt.confirm,
-				tt.confirmWait,
+				confirmOptions{confirm: tt.confirm, confirmWait: tt.confirmWait},
 			)
 
 			if tt.expectError {
@@ -1180,6 +1323,480 @@ func TestExecuteConfirmableCommand(t *testing.T) {
 	}
 }
 
+// TestExecuteConfirmableCommand_JSON tests that --json reports the outcome as a single
+// object and suppresses progress output.
+func TestExecuteConfirmableCommand_JSON(t *testing.T) {
+	t.Parallel()
+	waitReturning := func(result confirmationResult) func(context.Context, io.Writer, *api.Client, api.InternalVIN, time.Duration, time.Duration) confirmationResult {
+		return func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+			_, _ = fmt.Fprint(out, "Waiting for confirmation... (5s/90s)")
+
+			return result
+		}
+	}
+	succeed := func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error { return nil }
+
+	tests := []struct {
+		name          string
+		actionFunc    func(context.Context, *api.Client, api.InternalVIN) error
+		waitResult    *confirmationResult
+		confirm       bool
+		wantStatus    string
+		wantConfirmed bool
+		wantErr       string
+		wantErrCode   string
+	}{
+		{
+			name:          "confirmed",
+			actionFunc:    succeed,
+			waitResult:    &confirmationResult{success: true},
+			confirm:       true,
+			wantStatus:    "confirmed",
+			wantConfirmed: true,
+		},
+		{
+			name:       "timed out",
+			actionFunc: succeed,
+			waitResult: &confirmationResult{success: false},
+			confirm:    true,
+			wantStatus: "timeout",
+			wantErr:    "Lock command sent (confirmation timeout)",
+		},
+		{
+			name:       "not waited for",
+			actionFunc: succeed,
+			confirm:    false,
+			wantStatus: "sent",
+		},
+		{
+			name: "action failed",
+			actionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				return errors.New("vehicle offline")
+			},
+			confirm:    true,
+			wantStatus: "error",
+			wantErr:    "failed to lock doors: vehicle offline",
+		},
+		{
+			name: "action rejected by the server",
+			actionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				return api.NewRequestInProgressError()
+			},
+			confirm:     true,
+			wantStatus:  "error",
+			wantErr:     "failed to lock doors: Request already in progress, please wait and try again",
+			wantErrCode: "920000/400S01",
+		},
+		{
+			name:       "confirmation failed",
+			actionFunc: succeed,
+			waitResult: &confirmationResult{err: errors.New("context canceled")},
+			confirm:    true,
+			wantStatus: "error",
+			wantErr:    "failed to confirm lock status: context canceled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := ConfirmableCommandConfig{
+				Actions:       testActions(tt.actionFunc),
+				SuccessMsg:    "Doors locked successfully",
+				WaitingMsg:    "Lock command sent, waiting for confirmation...",
+				ActionName:    "lock doors",
+				ConfirmName:   "lock status",
+				TimeoutSuffix: "confirmation timeout",
+			}
+			if tt.waitResult != nil {
+				config.Confirm = testConfirm(waitReturning(*tt.waitResult))
+			}
+			var buf bytes.Buffer
+
+			err := executeConfirmableCommand(context.Background(), &buf, nil, api.InternalVIN("test-vin"), config, confirmOptions{
+				confirm:     tt.confirm,
+				confirmWait: 90,
+				format:      outputJSONCompact,
+				action:      "lock",
+				vin:         "JM3KKEHA1R1234567",
+			})
+
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.NotContains(t, buf.String(), "Waiting")
+			assert.Equal(t, 1, strings.Count(buf.String(), "\n"), "expected a single JSON line")
+
+			data := parseJSONToMap(t, buf.String())
+			assertMapValue(t, data, "action", "lock")
+			assertMapValue(t, data, "vin", "JM3KKEHA1R1234567")
+			assertMapValue(t, data, "status", tt.wantStatus)
+			assertMapValue(t, data, "confirmed", tt.wantConfirmed)
+			assert.Contains(t, data, "elapsed_ms")
+			if tt.wantErr != "" {
+				assertMapValue(t, data, "error", tt.wantErr)
+			} else {
+				assert.NotContains(t, data, "error")
+			}
+			if tt.wantErrCode != "" {
+				assertMapValue(t, data, "error_code", tt.wantErrCode)
+			} else {
+				assert.NotContains(t, data, "error_code")
+			}
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_Retry tests that --retry re-sends the command after a
+// confirmation timeout.
+func TestExecuteConfirmableCommand_Retry(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		retries       int
+		confirmOnWait int // 1-based wait that confirms; 0 never confirms
+		wantSends     int
+		wantTimeout   bool
+	}{
+		{
+			name:          "confirmed on second attempt",
+			retries:       2,
+			confirmOnWait: 2,
+			wantSends:     2,
+		},
+		{
+			name:        "retries exhausted",
+			retries:     2,
+			wantSends:   3,
+			wantTimeout: true,
+		},
+		{
+			name:        "no retries by default",
+			wantSends:   1,
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sends, waits := 0, 0
+			config := ConfirmableCommandConfig{
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+					sends++
+
+					return nil
+				}),
+				Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					waits++
+
+					return confirmationResult{success: waits == tt.confirmOnWait}
+				}),
+				SuccessMsg:    "Doors locked successfully",
+				WaitingMsg:    "Lock command sent, waiting for confirmation...",
+				ActionName:    "lock doors",
+				ConfirmName:   "lock status",
+				TimeoutSuffix: "confirmation timeout",
+			}
+			var buf bytes.Buffer
+
+			err := executeConfirmableCommand(context.Background(), &buf, nil, api.InternalVIN("test-vin"), config, confirmOptions{
+				confirm:     true,
+				confirmWait: 90,
+				retries:     tt.retries,
+			})
+
+			if tt.wantTimeout {
+				require.EqualError(t, err, "Lock command sent (confirmation timeout)")
+				assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+			} else {
+				require.NoError(t, err)
+				assert.True(t, strings.HasSuffix(buf.String(), "Doors locked successfully\n"), "output: %q", buf.String())
+			}
+			assert.Equal(t, tt.wantSends, sends)
+			assert.Equal(t, tt.wantSends-1, strings.Count(buf.String(), "re-sending"))
+			if tt.wantSends > 1 {
+				assert.Contains(t, buf.String(), "rate limits")
+			}
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_ExitCodes tests that command failures map to the documented exit codes.
+func TestExecuteConfirmableCommand_ExitCodes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		actionErr error
+		waitFunc  func(context.Context, io.Writer, *api.Client, api.InternalVIN, time.Duration, time.Duration) confirmationResult
+		want      int
+	}{
+		{name: "request in progress", actionErr: api.NewRequestInProgressError(), want: ExitCodeVehicleUnavailable},
+		{name: "engine start limit", actionErr: api.NewEngineStartLimitError(), want: ExitCodeVehicleUnavailable},
+		{name: "rate limited", actionErr: api.NewRateLimitedError(0), want: ExitCodeRateLimited},
+		{name: "invalid credentials", actionErr: api.NewInvalidCredentialError(), want: ExitCodeAuthFailed},
+		{name: "other API error", actionErr: api.NewAPIError(0, "", "boom"), want: ExitCodeError},
+		{
+			name: "not confirmed",
+			waitFunc: func(context.Context, io.Writer, *api.Client, api.InternalVIN, time.Duration, time.Duration) confirmationResult {
+				return confirmationResult{success: false}
+			},
+			want: ExitCodeTimeout,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			config := ConfirmableCommandConfig{
+				Actions: testActions(func(context.Context, *api.Client, api.InternalVIN) error {
+					return tt.actionErr
+				}),
+				Confirm:       testConfirm(tt.waitFunc),
+				SuccessMsg:    "Doors locked successfully",
+				WaitingMsg:    "Lock command sent, waiting for confirmation...",
+				ActionName:    "lock doors",
+				ConfirmName:   "lock status",
+				TimeoutSuffix: "confirmation timeout",
+			}
+
+			err := executeConfirmableCommand(context.Background(), io.Discard, nil, api.InternalVIN("test-vin"), config,
+				confirmOptions{confirm: true, confirmWait: 90})
+
+			require.Error(t, err)
+			assert.Equal(t, tt.want, ExitCode(err))
+		})
+	}
+}
+
+// TestValidateRetry tests --retry bounds and the --force requirement.
+func TestValidateRetry(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateRetry(0, true, false))
+	require.NoError(t, validateRetry(2, false, false))
+	require.NoError(t, validateRetry(MaxRetries, true, true))
+	require.ErrorContains(t, validateRetry(-1, false, false), "--retry must be between")
+	require.ErrorContains(t, validateRetry(MaxRetries+1, false, false), "--retry must be between")
+	require.ErrorContains(t, validateRetry(1, true, false), "--force")
+}
+
+func TestValidateInitialDelay(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateInitialDelay(0, 90))
+	require.NoError(t, validateInitialDelay(20, 90))
+	require.NoError(t, validateInitialDelay(89, 90))
+	require.EqualError(t, validateInitialDelay(-1, 90), "--initial-delay must be at least 0 and less than --confirm-wait (90 seconds), got -1")
+	require.ErrorContains(t, validateInitialDelay(90, 90), "less than --confirm-wait")
+}
+
+// TestResolveInitialDelay tests that only an --initial-delay the user set is
+// validated, and that the default is shortened to fit a short --confirm-wait.
+func TestResolveInitialDelay(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		seconds     int
+		confirmWait int
+		explicit    bool
+		confirm     bool
+		want        int
+		wantErr     string
+	}{
+		{name: "default fits", seconds: 20, confirmWait: 90, confirm: true, want: 20},
+		{name: "default shortened for a short wait", seconds: 20, confirmWait: 15, confirm: true, want: 7},
+		{name: "default without confirmation", seconds: 20, confirmWait: 10, want: 5},
+		{name: "explicit within the wait", seconds: 12, confirmWait: 15, explicit: true, confirm: true, want: 12},
+		{name: "explicit past the wait", seconds: 15, confirmWait: 15, explicit: true, confirm: true, wantErr: "less than --confirm-wait"},
+		{name: "explicit without confirmation", seconds: 30, confirmWait: 10, explicit: true, want: 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := resolveInitialDelay(tt.seconds, tt.confirmWait, tt.explicit, tt.confirm)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_InitialDelay tests that polling starts after the
+// initial delay, which is taken out of the confirmation timeout.
+func TestExecuteConfirmableCommand_InitialDelay(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		delay time.Duration
+	}{
+		{name: "poll immediately", delay: 0},
+		{name: "delay", delay: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var sentAt time.Time
+			var waited, gotTimeout time.Duration
+			config := ConfirmableCommandConfig{
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+					sentAt = time.Now()
+
+					return nil
+				}),
+				Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					waited, gotTimeout = time.Since(sentAt), timeout
+
+					return confirmationResult{success: true}
+				}),
+				InitialDelay: tt.delay,
+				SuccessMsg:   "Doors locked successfully",
+				ActionName:   "lock doors",
+			}
+
+			err := executeConfirmableCommand(context.Background(), io.Discard, nil, api.InternalVIN("test-vin"), config, confirmOptions{confirm: true, confirmWait: 90, action: "lock"})
+			require.NoError(t, err)
+			assert.GreaterOrEqual(t, waited, tt.delay)
+			assert.Equal(t, 90*time.Second-tt.delay, gotTimeout)
+		})
+	}
+}
+
+// TestWaitForPluggedIn tests waiting for the charger to be connected.
+func TestWaitForPluggedIn(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		pluggedIn   []bool
+		wantTimeout bool
+	}{
+		{name: "already plugged in", pluggedIn: []bool{true}},
+		{name: "plugged in after two checks", pluggedIn: []bool{false, false, true}},
+		{name: "never plugged in", pluggedIn: []bool{false}, wantTimeout: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			mockClient := &mockClientForConfirm{
+				getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+					pluggedIn := tt.pluggedIn[min(calls, len(tt.pluggedIn)-1)]
+					calls++
+
+					return apitest.NewEVVehicleStatus().WithPluggedIn(pluggedIn).Build(), nil
+				},
+				refreshVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) error {
+					t.Error("waiting for the charger should not refresh the vehicle")
+
+					return nil
+				},
+			}
+			var buf bytes.Buffer
+
+			err := waitForPluggedIn(context.Background(), &buf, mockClient, api.InternalVIN("test-vin"), 200*time.Millisecond, 10*time.Millisecond)
+
+			if tt.wantTimeout {
+				require.EqualError(t, err, "charger not connected before timeout")
+				assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.pluggedIn), calls)
+			assert.Contains(t, buf.String(), "Charger connected")
+			if len(tt.pluggedIn) > 1 {
+				assert.Contains(t, buf.String(), "Waiting for charger connection")
+			}
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_ActionsInOrder tests that every action is sent,
+// in order, and that a failed action stops the ones after it.
+func TestExecuteConfirmableCommand_ActionsInOrder(t *testing.T) {
+	t.Parallel()
+	var sent []string
+	action := func(endpoint string, err error) ControlStep {
+		return ControlStep{Endpoint: endpoint, send: func(context.Context, *api.Client, api.InternalVIN) error {
+			sent = append(sent, endpoint)
+
+			return err
+		}}
+	}
+	config := ConfirmableCommandConfig{
+		Actions: []ControlStep{
+			action(api.EndpointUpdateHVACSetting, nil),
+			action(api.EndpointHVACOn, errors.New("boom")),
+			action(api.EndpointDoorLock, nil),
+		},
+		ActionName: "turn HVAC on with preset",
+	}
+
+	err := executeConfirmableCommand(context.Background(), io.Discard, nil, api.InternalVIN("test-vin"), config, confirmOptions{})
+	require.EqualError(t, err, "failed to turn HVAC on with preset: boom")
+	assert.Equal(t, []string{api.EndpointUpdateHVACSetting, api.EndpointHVACOn}, sent)
+}
+
+// TestExecuteConfirmableCommand_BeforeAction tests that the Before step runs before the action
+// and that its failure stops the command from being sent.
+func TestExecuteConfirmableCommand_BeforeAction(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		beforeErr error
+		wantSent  bool
+		wantCode  int
+	}{
+		{name: "runs before action", wantSent: true},
+		{name: "timeout skips action", beforeErr: &timeoutError{message: "charger not connected before timeout"}, wantCode: ExitCodeTimeout},
+		{name: "error skips action", beforeErr: errors.New("boom"), wantCode: ExitCodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var order []string
+			config := ConfirmableCommandConfig{
+				Before: testBefore(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN) error {
+					order = append(order, "before")
+
+					return tt.beforeErr
+				}),
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+					order = append(order, "action")
+
+					return nil
+				}),
+				SuccessMsg:    "Charging started successfully",
+				WaitingMsg:    "Charge start command sent, waiting for confirmation...",
+				ActionName:    "start charging",
+				ConfirmName:   "charging status",
+				TimeoutSuffix: "confirmation timeout",
+			}
+
+			err := executeConfirmableCommand(context.Background(), io.Discard, nil, api.InternalVIN("test-vin"), config,
+				confirmOptions{confirm: false})
+
+			if !tt.wantSent {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, ExitCode(err))
+				assert.Equal(t, []string{"before"}, order)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"before", "action"}, order)
+		})
+	}
+}
+
 // TestWaitForConditionRefreshesStatus tests that confirmation polling calls RefreshVehicleStatus
 // before starting to poll. This ensures we get fresh data from the vehicle, not stale cached data.
 func TestWaitForConditionRefreshesStatus(t *testing.T) {
@@ -1189,7 +1806,7 @@ func TestWaitForConditionRefreshesStatus(t *testing.T) {
 
 	mockClient := &mockClientForConfirm{
 		getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
-			return NewMockEVVehicleStatus().WithHVAC(true).Build(), nil
+			return apitest.NewEVVehicleStatus().WithHVAC(true).Build(), nil
 		},
 		refreshVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) error {
 			return nil
@@ -1330,7 +1947,7 @@ func TestClientAdapter(t *testing.T) {
 		mockAPIClient.getVehicleStatusFunc = func(ctx context.Context, vin string) (*api.VehicleStatusResponse, error) {
 			assert.Equal(t, string(testVIN), vin)
 
-			return NewMockVehicleStatus().Build(), nil
+			return apitest.NewVehicleStatus().Build(), nil
 		}
 
 		adapter := &testClientAdapter{mockAPIClient}
@@ -1349,7 +1966,7 @@ func TestClientAdapter(t *testing.T) {
 		mockAPIClient.getEVVehicleStatusFunc = func(ctx context.Context, vin string) (*api.EVVehicleStatusResponse, error) {
 			assert.Equal(t, string(testVIN), vin)
 
-			return NewMockEVVehicleStatus().Build(), nil
+			return apitest.NewEVVehicleStatus().Build(), nil
 		}
 
 		adapter := &testClientAdapter{mockAPIClient}
@@ -1471,3 +2088,237 @@ func (m *mockAPIClientForAdapter) RefreshVehicleStatus(ctx context.Context, vin
 
 	return errors.New("not implemented")
 }
+
+// TestValidateWaitSeconds tests the bounds on wait flag values.
+func TestValidateWaitSeconds(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		seconds int
+		wantErr bool
+	}{
+		{"negative", -1, true},
+		{"zero", 0, true},
+		{"below minimum", MinWaitSeconds - 1, true},
+		{"minimum", MinWaitSeconds, false},
+		{"default", 90, false},
+		{"maximum", MaxWaitSeconds, false},
+		{"above maximum", MaxWaitSeconds + 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateWaitSeconds("confirm-wait", tt.seconds)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "--confirm-wait")
+
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// TestClampPollInterval tests that intervals below the minimum are raised to it
+// with a warning, and that refreshing loops have a higher minimum.
+func TestClampPollInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		seconds     int
+		refresh     bool
+		want        int
+		wantWarning string
+	}{
+		{name: "read-only minimum", seconds: MinPollSeconds, want: MinPollSeconds},
+		{name: "read-only below minimum", seconds: 5, want: MinPollSeconds, wantWarning: "Warning: --interval 5 is below the minimum of 10 seconds between status checks; using 10\n"},
+		{name: "read-only zero", seconds: 0, want: MinPollSeconds, wantWarning: "Warning: --interval 0 is below the minimum of 10 seconds between status checks; using 10\n"},
+		{name: "read-only tight interval allowed", seconds: 30, want: 30},
+		{name: "refresh below minimum", seconds: 60, refresh: true, want: MinRefreshPollSeconds, wantWarning: "Warning: --interval 60 is below the minimum of 600 seconds between refreshes, to stay within Mazda's rate limits; using 600\n"},
+		{name: "refresh minimum", seconds: MinRefreshPollSeconds, refresh: true, want: MinRefreshPollSeconds},
+		{name: "refresh above minimum", seconds: 900, refresh: true, want: 900},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var warn bytes.Buffer
+			assert.Equal(t, tt.want, clampPollInterval(&warn, "interval", tt.seconds, tt.refresh))
+			assert.Equal(t, tt.wantWarning, warn.String())
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_Notify tests that --notify reports the outcome of
+// commands that were waited for.
+// TestExecuteConfirmableCommand_Quiet tests that --quiet prints only the result line.
+func TestExecuteConfirmableCommand_Quiet(t *testing.T) {
+	t.Parallel()
+	config := ConfirmableCommandConfig{
+		Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+			return nil
+		}),
+		Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+			_, _ = fmt.Fprintln(out, "Waiting for confirmation... (10s/90s)")
+
+			return confirmationResult{success: true}
+		}),
+		SuccessMsg: "Doors locked successfully",
+		WaitingMsg: "Lock command sent, waiting for confirmation...",
+		ActionName: "lock doors",
+	}
+
+	tests := []struct {
+		name  string
+		quiet bool
+		want  string
+	}{
+		{
+			name: "progress shown by default",
+			want: "Lock command sent, waiting for confirmation...\nWaiting for confirmation... (10s/90s)\nDoors locked successfully\n",
+		},
+		{name: "quiet", quiet: true, want: "Doors locked successfully\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := ContextWithConfig(context.Background(), &CLIConfig{Quiet: tt.quiet})
+			var buf bytes.Buffer
+
+			err := executeConfirmableCommand(ctx, &buf, nil, api.InternalVIN("test-vin"), config, confirmOptions{confirm: true, confirmWait: 90, action: "lock"})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
+
+func TestExecuteConfirmableCommand_Notify(t *testing.T) {
+	t.Parallel()
+	config := ConfirmableCommandConfig{
+		Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+			return nil
+		}),
+		Confirm: testConfirm(func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+			return confirmationResult{success: true}
+		}),
+		SuccessMsg: "Doors locked successfully",
+		ActionName: "lock doors",
+	}
+
+	tests := []struct {
+		name    string
+		confirm bool
+		want    []string
+	}{
+		{name: "confirmed", confirm: true, want: []string{"mcs lock: Confirmed after 0s"}},
+		{name: "not waited for", confirm: false, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var notifications []string
+			ctx := ContextWithConfig(context.Background(), &CLIConfig{
+				Notify: true,
+				Notifier: func(ctx context.Context, title, body string) error {
+					notifications = append(notifications, title+": "+body)
+
+					return nil
+				},
+			})
+
+			var buf bytes.Buffer
+			err := executeConfirmableCommand(ctx, &buf, nil, api.InternalVIN("test-vin"), config, confirmOptions{
+				confirm:     tt.confirm,
+				confirmWait: 90,
+				action:      "lock",
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, notifications)
+		})
+	}
+}
+
+func TestConfirmationSummary(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "Confirmed after 12s", confirmationSummary(confirmOutcomeConfirmed, 12300*time.Millisecond, nil))
+	assert.Equal(t, "Timed out after 1m30s", confirmationSummary(confirmOutcomeTimeout, 90*time.Second, errors.New("timeout")))
+	assert.Equal(t, "Failed: vehicle offline", confirmationSummary(confirmOutcomeError, time.Second, errors.New("vehicle offline")))
+}
+
+func TestSkipIfCharging(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		charging bool
+		want     bool
+		wantMsg  string
+	}{
+		{name: "start while charging", charging: true, want: true, wantMsg: "Already charging; charge start not sent (use --force to send it anyway)"},
+		{name: "start while not charging", charging: false, want: true},
+		{name: "stop while not charging", charging: false, want: false, wantMsg: "Not charging; charge stop not sent (use --force to send it anyway)"},
+		{name: "stop while charging", charging: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &mockClientForConfirm{
+				getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+					return apitest.NewEVVehicleStatus().WithCharging(tt.charging).Build(), nil
+				},
+			}
+
+			msg, err := skipIfCharging(context.Background(), client, api.InternalVIN("test-vin"), tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMsg, msg)
+		})
+	}
+}
+
+// TestExecuteConfirmableCommand_Skip tests that an unnecessary command isn't sent
+// unless forced, and that a failed check doesn't stop it.
+func TestExecuteConfirmableCommand_Skip(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		skipMsg    string
+		skipErr    error
+		force      bool
+		wantSent   bool
+		wantOutput string
+	}{
+		{name: "already in state", skipMsg: "Already charging", wantOutput: "Already charging\n"},
+		{name: "already in state with force", skipMsg: "Already charging", force: true, wantSent: true, wantOutput: "Charging started\n"},
+		{name: "not in state", wantSent: true, wantOutput: "Charging started\n"},
+		{name: "check failed", skipErr: errors.New("vehicle offline"), wantSent: true, wantOutput: "Warning: couldn't check current state, sending anyway: vehicle offline\nCharging started\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var sent bool
+			config := ConfirmableCommandConfig{
+				Skip: testSkip(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) (string, error) {
+					return tt.skipMsg, tt.skipErr
+				}),
+				Actions: testActions(func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+					sent = true
+
+					return nil
+				}),
+				SuccessMsg: "Charging started",
+				ActionName: "start charging",
+			}
+
+			var buf bytes.Buffer
+			err := executeConfirmableCommand(context.Background(), &buf, nil, api.InternalVIN("test-vin"), config, confirmOptions{force: tt.force})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantSent, sent)
+			assert.Equal(t, tt.wantOutput, buf.String())
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// doctorStepTimeout bounds each diagnostic step so a hung endpoint can't stall the whole run.
+const doctorStepTimeout = 30 * time.Second
+
+// doctorClient is the subset of api.Client exercised by the doctor command.
+type doctorClient interface {
+	GetEncryptionKeys(ctx context.Context) error
+	Login(ctx context.Context) error
+	GetVecBaseInfos(ctx context.Context) (*api.VecBaseInfosResponse, error)
+}
+
+// doctorStep is a single diagnostic check. run returns a short detail for the success line.
+type doctorStep struct {
+	name string
+	run  func(ctx context.Context) (string, error)
+}
+
+// NewDoctorCmd creates the doctor command.
+func NewDoctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Check credentials, region, and connectivity",
+		Long: `Run the full authentication path step by step and report what works.
+
+Checks that the config is valid, the region's API is reachable, encryption keys
+can be fetched, login succeeds, and the account has vehicles. Each step is
+time-limited. The doctor never reads or writes the token cache, so it is safe
+to run repeatedly.`,
+		Example: `  # Diagnose setup problems
+  mcs doctor
+
+  # Example output:
+  # ✓ config (0ms): region MNAO, user you@example.com
+  # ✓ encryption keys (412ms): region MNAO reachable
+  # ✗ login (380ms): incorrect email or password`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			out := cmd.OutOrStdout()
+
+			start := time.Now()
+			cfg, err := loadConfig(ctx)
+			if err != nil {
+				printDoctorResult(out, "config", time.Since(start), "", err)
+
+				return errors.New("doctor found problems")
+			}
+			printDoctorResult(out, "config", time.Since(start), fmt.Sprintf("region %s, user %s", cfg.Region, cfg.Email), nil)
+
+			opts, err := clientOptions(ctx)
+			if err != nil {
+				return err
+			}
+			client, err := api.NewClient(cfg.Email, cfg.Password, cfg.Region, opts...)
+			if err != nil {
+				return fmt.Errorf("failed to create API client: %w", err)
+			}
+
+			return runDoctor(ctx, out, client, cfg.Region.String(), doctorStepTimeout)
+		},
+		SilenceUsage: true,
+	}
+}
+
+// runDoctor runs the connectivity checks in order, stopping at the first failure
+// since each step depends on the previous one.
+func runDoctor(ctx context.Context, out io.Writer, client doctorClient, region string, stepTimeout time.Duration) error {
+	steps := []doctorStep{
+		{
+			name: "encryption keys",
+			run: func(ctx context.Context) (string, error) {
+				if err := client.GetEncryptionKeys(ctx); err != nil {
+					return "", fmt.Errorf("region %s unreachable or rejected request: %w", region, err)
+				}
+
+				return fmt.Sprintf("region %s reachable", region), nil
+			},
+		},
+		{
+			name: "login",
+			run: func(ctx context.Context) (string, error) {
+				if err := client.Login(ctx); err != nil {
+					return "", err
+				}
+
+				return "credentials accepted", nil
+			},
+		},
+		{
+			name: "vehicles",
+			run: func(ctx context.Context) (string, error) {
+				vecBaseInfos, err := client.GetVecBaseInfos(ctx)
+				if err != nil {
+					return "", err
+				}
+				if len(vecBaseInfos.VecBaseInfos) == 0 {
+					return "", errors.New("no vehicles registered to this account")
+				}
+
+				return fmt.Sprintf("%d vehicle(s) found", len(vecBaseInfos.VecBaseInfos)), nil
+			},
+		},
+	}
+
+	for _, step := range steps {
+		stepCtx, cancel := context.WithTimeout(ctx, stepTimeout)
+		start := time.Now()
+		detail, err := step.run(stepCtx)
+		elapsed := time.Since(start)
+		if err == nil && stepCtx.Err() != nil {
+			err = stepCtx.Err()
+		}
+		cancel()
+
+		printDoctorResult(out, step.name, elapsed, detail, err)
+		if err != nil {
+			return errors.New("doctor found problems")
+		}
+	}
+
+	_, _ = fmt.Fprintln(out, Green("All checks passed"))
+
+	return nil
+}
+
+// printDoctorResult prints a single pass/fail line with timing.
+func printDoctorResult(out io.Writer, name string, elapsed time.Duration, detail string, err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(out, "%s %s (%dms): %v\n", Red("✗"), name, elapsed.Milliseconds(), err)
+
+		return
+	}
+	_, _ = fmt.Fprintf(out, "%s %s (%dms): %s\n", Green("✓"), name, elapsed.Milliseconds(), detail)
+}
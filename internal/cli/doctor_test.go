@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDoctorClient implements doctorClient for testing.
+type mockDoctorClient struct {
+	keysErr      error
+	loginErr     error
+	loginBlocks  bool
+	vehicles     *api.VecBaseInfosResponse
+	vehiclesErr  error
+	vehicleCalls int
+}
+
+func (m *mockDoctorClient) GetEncryptionKeys(_ context.Context) error {
+	return m.keysErr
+}
+
+func (m *mockDoctorClient) Login(ctx context.Context) error {
+	if m.loginBlocks {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	return m.loginErr
+}
+
+func (m *mockDoctorClient) GetVecBaseInfos(_ context.Context) (*api.VecBaseInfosResponse, error) {
+	m.vehicleCalls++
+
+	return m.vehicles, m.vehiclesErr
+}
+
+// TestDoctorCommand tests the doctor command structure.
+func TestDoctorCommand(t *testing.T) {
+	t.Parallel()
+	cmd := NewDoctorCmd()
+	assertCommandBasics(t, cmd, "doctor")
+	assertNoArgsCommand(t, cmd)
+}
+
+// TestRunDoctor tests step reporting and early exit on failure.
+func TestRunDoctor(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+
+	oneVehicle := &api.VecBaseInfosResponse{VecBaseInfos: []api.VecBaseInfo{{VIN: "JM3XXXXXXXXXX1234"}}}
+
+	tests := []struct {
+		name             string
+		client           *mockDoctorClient
+		expectError      bool
+		expectedOutput   []string
+		unexpectedOutput []string
+		vehicleCalls     int
+	}{
+		{
+			name:        "all checks pass",
+			client:      &mockDoctorClient{vehicles: oneVehicle},
+			expectError: false,
+			expectedOutput: []string{
+				"✓ encryption keys",
+				"region MNAO reachable",
+				"✓ login",
+				"✓ vehicles",
+				"1 vehicle(s) found",
+				"All checks passed",
+			},
+			vehicleCalls: 1,
+		},
+		{
+			name:             "encryption keys fail",
+			client:           &mockDoctorClient{keysErr: errors.New("connection refused")},
+			expectError:      true,
+			expectedOutput:   []string{"✗ encryption keys", "region MNAO unreachable", "connection refused"},
+			unexpectedOutput: []string{"login"},
+		},
+		{
+			name:             "login fails",
+			client:           &mockDoctorClient{loginErr: errors.New("incorrect email or password")},
+			expectError:      true,
+			expectedOutput:   []string{"✓ encryption keys", "✗ login", "incorrect email or password"},
+			unexpectedOutput: []string{"vehicles", "All checks passed"},
+		},
+		{
+			name:           "login times out",
+			client:         &mockDoctorClient{loginBlocks: true},
+			expectError:    true,
+			expectedOutput: []string{"✗ login", "context deadline exceeded"},
+		},
+		{
+			name:           "no vehicles",
+			client:         &mockDoctorClient{vehicles: &api.VecBaseInfosResponse{}},
+			expectError:    true,
+			expectedOutput: []string{"✗ vehicles", "no vehicles registered"},
+			vehicleCalls:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runDoctor(context.Background(), &out, tt.client, "MNAO", 50*time.Millisecond)
+			if tt.expectError {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, expected := range tt.expectedOutput {
+				assert.Contains(t, out.String(), expected)
+			}
+			for _, unexpected := range tt.unexpectedOutput {
+				assert.NotContains(t, out.String(), unexpected)
+			}
+			assert.Equal(t, tt.vehicleCalls, tt.client.vehicleCalls)
+		})
+	}
+}
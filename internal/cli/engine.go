@@ -1,7 +1,8 @@
 package cli
 
 import (
-	"context"
+	"fmt"
+	"strings"
 
 	"github.com/cv/mcs/internal/api"
 	"github.com/spf13/cobra"
@@ -25,18 +26,18 @@ func NewStartCmd() *cobra.Command {
   # Start engine and wait up to 60 seconds for confirmation
   mcs start --confirm-wait 60`,
 		ConfirmFlagUsage: "wait for confirmation that engine is running",
+		Supported:        checkEngineStartSupported,
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.EngineStart(ctx, string(internalVIN))
-			},
-			// WaitFunc: nil - No reliable API field for engine status
+			Actions: []ControlStep{{Endpoint: api.EndpointEngineStart}},
+			// Confirm: nil - No reliable API field for engine status
 			// Previously used HVAC status as proxy, which was incorrect
-			WaitFunc:      nil,
-			SuccessMsg:    "Engine start command sent",
-			WaitingMsg:    "Start command sent, waiting for confirmation...",
-			ActionName:    "start engine",
-			ConfirmName:   "engine status",
-			TimeoutSuffix: "confirmation timeout",
+			Confirm:        nil,
+			UnsafeToResend: true,
+			SuccessMsg:     "Engine start command sent",
+			WaitingMsg:     "Start command sent, waiting for confirmation...",
+			ActionName:     "start engine",
+			ConfirmName:    "engine status",
+			TimeoutSuffix:  "confirmation timeout",
 		},
 	})
 }
@@ -60,12 +61,10 @@ func NewStopCmd() *cobra.Command {
   mcs stop --confirm-wait 60`,
 		ConfirmFlagUsage: "wait for confirmation that engine is stopped",
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.EngineStop(ctx, string(internalVIN))
-			},
-			// WaitFunc: nil - No reliable API field for engine status
+			Actions: []ControlStep{{Endpoint: api.EndpointEngineStop}},
+			// Confirm: nil - No reliable API field for engine status
 			// Previously used HVAC status as proxy, which was incorrect
-			WaitFunc:      nil,
+			Confirm:       nil,
 			SuccessMsg:    "Engine stop command sent",
 			WaitingMsg:    "Stop command sent, waiting for confirmation...",
 			ActionName:    "stop engine",
@@ -74,3 +73,31 @@ func NewStopCmd() *cobra.Command {
 		},
 	})
 }
+
+// checkEngineStartSupported fails for vehicles that can't start their engine
+// remotely, such as battery electric vehicles.
+func checkEngineStartSupported(vehicleInfo VehicleInfo) error {
+	if !vehicleInfo.capabilities().RemoteEngineStart {
+		return fmt.Errorf("remote engine start is not supported on this vehicle (%s)", vehicleDescription(vehicleInfo))
+	}
+
+	return nil
+}
+
+// vehicleDescription names a vehicle's model in messages, e.g. "CX-90 PHEV, model code KKEH".
+func vehicleDescription(vehicleInfo VehicleInfo) string {
+	parts := []string{}
+	if vehicleInfo.ModelName != "" {
+		parts = append(parts, vehicleInfo.ModelName)
+	} else if vehicleInfo.Powertrain != "" {
+		parts = append(parts, string(vehicleInfo.Powertrain))
+	}
+	if vehicleInfo.ModelCode != "" {
+		parts = append(parts, "model code "+vehicleInfo.ModelCode)
+	}
+	if len(parts) == 0 {
+		return "unknown model"
+	}
+
+	return strings.Join(parts, ", ")
+}
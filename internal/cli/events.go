@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// noAlertsMessage is printed when no alert snapshot reports anything.
+const noAlertsMessage = "no recent alerts"
+
+// NewEventsCmd creates the events command.
+func NewEventsCmd() *cobra.Command {
+	var jsonOutput, jsonCompact bool
+	var since time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "List recent vehicle alerts",
+		Long: `List the alerts in the vehicle's recent status snapshots, newest first:
+open doors, trunk, hood, and fuel lid, open windows, and hazard lights.
+
+The API doesn't expose a separate alert log, so only the snapshots included in
+the current vehicle status are shown. Prints "no recent alerts" when none of
+them report anything.`,
+		Example: `  # List recent alerts
+  mcs events
+
+  # Only alerts from the last 24 hours
+  mcs events --since 24h
+
+  # Example output:
+  # 2024-03-15 14:30:45 (2 min ago)  Open: trunk
+  # 2024-03-15 14:30:45 (2 min ago)  Hazard lights on`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if since < 0 {
+				return fmt.Errorf("--since must not be negative, got %s", since)
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+
+				return runEvents(cmd.OutOrStdout(), vehicleStatus, newOutputFormat(cmd.Context(), jsonOutput, jsonCompact), since, time.Now())
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().DurationVar(&since, "since", 0, "only show alerts newer than this (e.g. 30m, 24h); 0 shows all")
+
+	return cmd
+}
+
+// runEvents prints the alert events in vehicleStatus. A non-zero since drops
+// events older than now minus since, and events without a usable timestamp.
+func runEvents(out io.Writer, vehicleStatus *api.VehicleStatusResponse, format outputFormat, since time.Duration, now time.Time) error {
+	events := filterAlertEvents(vehicleStatus.GetAlertEvents(), since, now)
+
+	output, err := formatAlertEvents(events, format)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	return nil
+}
+
+// filterAlertEvents keeps the events that occurred within since of now. A zero
+// since keeps everything.
+func filterAlertEvents(events []api.AlertEvent, since time.Duration, now time.Time) []api.AlertEvent {
+	if since == 0 {
+		return events
+	}
+
+	cutoff := now.Add(-since)
+	filtered := []api.AlertEvent{}
+	for _, event := range events {
+		if occurred, ok := parseAPITimestamp(event.OccurrenceDate); ok && !occurred.Before(cutoff) {
+			filtered = append(filtered, event)
+		}
+	}
+
+	return filtered
+}
+
+// formatAlertEvents formats alert events for display, one per line.
+func formatAlertEvents(events []api.AlertEvent, format outputFormat) (string, error) {
+	if format.isJSON() {
+		items := make([]map[string]any, 0, len(events))
+		for _, event := range events {
+			item := map[string]any{
+				"type":      string(event.Type),
+				"timestamp": formatTimestampRFC3339(event.OccurrenceDate),
+			}
+			if event.Target != "" {
+				item["target"] = event.Target
+			}
+			items = append(items, item)
+		}
+
+		return toJSON(map[string]any{"events": items}, format)
+	}
+
+	if len(events) == 0 {
+		return noAlertsMessage, nil
+	}
+
+	lines := make([]string, 0, len(events))
+	for _, event := range events {
+		lines = append(lines, fmt.Sprintf("%s  %s", formatTimestamp(event.OccurrenceDate), describeAlertEvent(event)))
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// describeAlertEvent returns the text shown for an alert event.
+func describeAlertEvent(event api.AlertEvent) string {
+	switch event.Type {
+	case api.AlertDoorOpen, api.AlertWindowOpen:
+		return "Open: " + event.Target
+	case api.AlertHazardsOn:
+		return "Hazard lights on"
+	default:
+		return string(event.Type)
+	}
+}
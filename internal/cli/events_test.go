@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunEvents(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	// Trunk open an hour ago; driver door and a window open two days ago.
+	vehicleStatus := apitest.NewVehicleStatus().WithDoorStatus(api.DoorStatus{TrunkOpen: true}).Build()
+	vehicleStatus.AlertInfos[0].OccurrenceDate = "20250115110000"
+	vehicleStatus.AlertInfos = append(vehicleStatus.AlertInfos, api.AlertInfo{
+		OccurrenceDate: "20250113120000",
+		Door:           api.DoorInfo{DrStatDrv: float64(api.DoorOpen)},
+		Pw:             api.WindowInfo{PwPosPsngr: 30},
+	})
+
+	tests := []struct {
+		name       string
+		status     *api.VehicleStatusResponse
+		since      time.Duration
+		expected   []string
+		unexpected []string
+	}{
+		{
+			name:   "all events",
+			status: vehicleStatus,
+			expected: []string{
+				"2025-01-15 11:00:00 (",
+				"Open: trunk",
+				"Open: driver door",
+				"Open: passenger window",
+			},
+		},
+		{
+			name:       "since drops older events",
+			status:     vehicleStatus,
+			since:      24 * time.Hour,
+			expected:   []string{"Open: trunk"},
+			unexpected: []string{"driver door", "passenger window"},
+		},
+		{
+			name:     "no alerts",
+			status:   apitest.NewVehicleStatus().Build(),
+			expected: []string{noAlertsMessage},
+		},
+		{
+			name:     "nothing within since",
+			status:   vehicleStatus,
+			since:    time.Minute,
+			expected: []string{noAlertsMessage},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			require.NoError(t, runEvents(&out, tt.status, outputText, tt.since, now))
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, out.String(), expected)
+			}
+			for _, unexpected := range tt.unexpected {
+				assert.NotContains(t, out.String(), unexpected)
+			}
+		})
+	}
+
+	t.Run("newest first", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, runEvents(&out, vehicleStatus, outputText, 0, now))
+		assert.Less(t, bytes.Index(out.Bytes(), []byte("trunk")), bytes.Index(out.Bytes(), []byte("driver door")))
+	})
+}
+
+func TestRunEvents_JSON(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().WithDoorStatus(api.DoorStatus{HoodOpen: true}).Build()
+	vehicleStatus.AlertInfos[0].OccurrenceDate = "20250115110000"
+	vehicleStatus.AlertInfos[0].HazardLamp.HazardSw = float64(api.HazardLightsOn)
+
+	var out bytes.Buffer
+	require.NoError(t, runEvents(&out, vehicleStatus, outputJSON, 0, time.Now()))
+	assert.JSONEq(t, `{"events": [
+		{"type": "door_open", "target": "hood", "timestamp": "2025-01-15T11:00:00Z"},
+		{"type": "hazards_on", "timestamp": "2025-01-15T11:00:00Z"}
+	]}`, out.String())
+
+	out.Reset()
+	require.NoError(t, runEvents(&out, apitest.NewVehicleStatus().Build(), outputJSON, 0, time.Now()))
+	assert.JSONEq(t, `{"events": []}`, out.String())
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/cache"
+	"github.com/cv/mcs/internal/geocode"
+)
+
+// geocodeTimeout bounds an address lookup so a slow geocoder can't stall the command.
+const geocodeTimeout = 5 * time.Second
+
+// lookupAddress returns the street address at the coordinates, from the address
+// cache when it has them, and otherwise from the geocoder at geocoderURL, caching
+// the answer. Cache errors are logged and don't fail the lookup.
+func lookupAddress(ctx context.Context, geocoderURL string, latitude, longitude float64) (string, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	key := cache.AddressKey(latitude, longitude)
+	addresses, err := cache.LoadAddressesFrom(paths.AddressCache)
+	if err != nil {
+		log.Printf("Warning: failed to load address cache: %v", err)
+		addresses = cache.AddressCache{}
+	}
+	if address, ok := addresses[key]; ok {
+		return address, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, geocodeTimeout)
+	defer cancel()
+	geocoder, err := geocoderFromContext(ctx, geocoderURL)
+	if err != nil {
+		return "", err
+	}
+	address, err := geocoder.ReverseGeocode(ctx, latitude, longitude)
+	if err != nil {
+		return "", err
+	}
+
+	addresses[key] = address
+	if err := cache.SaveAddressesTo(addresses, paths.AddressCache); err != nil {
+		log.Printf("Warning: failed to save address cache: %v", err)
+	}
+
+	return address, nil
+}
+
+// geocoderFromContext returns the CLIConfig's Geocoder, or a Nominatim geocoder
+// for geocoderURL that identifies itself with the mcs version. The Nominatim
+// geocoder connects like the API client does, so --ca-cert, --insecure,
+// --min-tls, --no-http2 and proxies from the environment apply to it too.
+func geocoderFromContext(ctx context.Context, geocoderURL string) (geocode.Geocoder, error) {
+	version := "dev"
+	if cfg := ConfigFromContext(ctx); cfg != nil {
+		if cfg.Geocoder != nil {
+			return cfg.Geocoder, nil
+		}
+		if cfg.Version != "" {
+			version = cfg.Version
+		}
+	}
+
+	opts, err := clientOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	geocoder := geocode.NewNominatim(geocoderURL, "mcs/"+version+" (https://github.com/cv/mcs)")
+	geocoder.SetTransport(api.NewTransport(opts...))
+
+	return geocoder, nil
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGeocoder returns a fixed address, or err, and counts its lookups.
+type fakeGeocoder struct {
+	address string
+	err     error
+	calls   int
+}
+
+func (g *fakeGeocoder) ReverseGeocode(_ context.Context, _, _ float64) (string, error) {
+	g.calls++
+
+	return g.address, g.err
+}
+
+// testContextWithGeocoder returns a context whose address lookups use geocoder
+// and a temporary address cache.
+func testContextWithGeocoder(t *testing.T, geocoder *fakeGeocoder) context.Context {
+	t.Helper()
+
+	return ContextWithConfig(context.Background(), &CLIConfig{
+		Geocoder:         geocoder,
+		AddressCacheFile: filepath.Join(t.TempDir(), "geocode.json"),
+		PlacesFile:       filepath.Join(t.TempDir(), "places.json"),
+	})
+}
+
+// TestLookupAddress tests that addresses are cached, so a parked vehicle is
+// looked up only once.
+func TestLookupAddress(t *testing.T) {
+	t.Parallel()
+	geocoder := &fakeGeocoder{address: "1 Market Street, San Francisco"}
+	ctx := testContextWithGeocoder(t, geocoder)
+
+	for range 2 {
+		address, err := lookupAddress(ctx, "", 37.77493, -122.41942)
+		require.NoError(t, err)
+		assert.Equal(t, "1 Market Street, San Francisco", address)
+	}
+	assert.Equal(t, 1, geocoder.calls)
+
+	// A few meters away shares the cached address.
+	_, err := lookupAddress(ctx, "", 37.77491, -122.41939)
+	require.NoError(t, err)
+	assert.Equal(t, 1, geocoder.calls)
+}
+
+// TestLookupAddress_CACert tests that address lookups trust the --ca-cert the
+// API client does, e.g. behind a TLS-inspecting proxy.
+func TestLookupAddress_CACert(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"display_name": "1 Market Street, San Francisco"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	caCertPath := filepath.Join(t.TempDir(), "proxy-ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertPath, caCert, 0600))
+	contextWithCACert := func(caCertPath string) context.Context {
+		return ContextWithConfig(context.Background(), &CLIConfig{
+			CACert:           caCertPath,
+			AddressCacheFile: filepath.Join(t.TempDir(), "geocode.json"),
+		})
+	}
+
+	address, err := lookupAddress(contextWithCACert(caCertPath), server.URL, 37.77493, -122.41942)
+	require.NoError(t, err)
+	assert.Equal(t, "1 Market Street, San Francisco", address)
+
+	_, err = lookupAddress(contextWithCACert(""), server.URL, 37.77493, -122.41942)
+	require.ErrorContains(t, err, "certificate")
+}
+
+// TestRunStatusLocation tests the location view with and without --address.
+func TestRunStatusLocation(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+
+	t.Run("text with address", func(t *testing.T) {
+		t.Parallel()
+		ctx := testContextWithGeocoder(t, &fakeGeocoder{address: "1 Market Street, San Francisco"})
+		var out, errOut bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &errOut, vehicleStatus, outputText, true, "", 0.15))
+
+		assert.Regexp(t, `^LOCATION: [-\d.]+, [-\d.]+\n  1 Market Street, San Francisco\n`, out.String())
+		assert.Contains(t, out.String(), "https://maps.google.com/?q=")
+		assert.Empty(t, errOut.String())
+	})
+
+	t.Run("JSON with address", func(t *testing.T) {
+		t.Parallel()
+		ctx := testContextWithGeocoder(t, &fakeGeocoder{address: "1 Market Street, San Francisco"})
+		var out, errOut bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &errOut, vehicleStatus, outputJSON, true, "", 0.15))
+
+		assert.Equal(t, "1 Market Street, San Francisco", parseJSONToMap(t, out.String())["address"])
+	})
+
+	t.Run("without address", func(t *testing.T) {
+		t.Parallel()
+		geocoder := &fakeGeocoder{address: "1 Market Street, San Francisco"}
+		ctx := testContextWithGeocoder(t, geocoder)
+		var without, errOut bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &without, &errOut, vehicleStatus, outputText, false, "", 0.15))
+		locationInfo, err := vehicleStatus.GetLocationInfo()
+		require.NoError(t, err)
+		expected, err := formatLocationStatus(locationInfo, outputText, "", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, expected+"\n", without.String())
+		assert.Zero(t, geocoder.calls, "no network call unless asked")
+	})
+
+	t.Run("lookup failure keeps coordinates", func(t *testing.T) {
+		t.Parallel()
+		ctx := testContextWithGeocoder(t, &fakeGeocoder{err: errors.New("geocoder returned HTTP 429")})
+		var out, errOut bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &errOut, vehicleStatus, outputJSON, true, "", 0.15))
+
+		assert.NotContains(t, parseJSONToMap(t, out.String()), "address")
+		assert.Equal(t, "Warning: address lookup failed: geocoder returned HTTP 429\n", errOut.String())
+	})
+}
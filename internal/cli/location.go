@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/config"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+)
+
+// defaultNearThresholdMeters is how close the vehicle must be to a saved place
+// for status location to show it as at that place.
+const defaultNearThresholdMeters = 150
+
+// NewLocationCmd creates the location command.
+func NewLocationCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "location",
+		Short: "Manage saved places",
+		Long: `Manage named places, such as "home" and "work". mcs status location shows
+whether the vehicle is at one of them, or how far it is from the nearest.`,
+		Example: `  # Save where the vehicle is now as "home"
+  mcs location save home`,
+	}
+
+	cmd.AddCommand(newLocationSaveCmd())
+
+	return cmd
+}
+
+// newLocationSaveCmd creates the location save subcommand.
+func newLocationSaveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save the vehicle's current location as a named place",
+		Long: `Save the vehicle's last reported coordinates under a name, such as "home" or
+"work", replacing any place already saved under that name. Places are stored in
+~/.config/mcs/places.json and shared by all vehicles on the account.`,
+		Example: `  # Save where the vehicle is parked now as "home"
+  mcs location save home
+
+  # Later, status location shows "at home" or e.g. "2.3 km from home"
+  mcs status location`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				return runLocationSave(ctx, cmd.OutOrStdout(), &clientAdapter{client}, internalVIN, args[0])
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	return cmd
+}
+
+// runLocationSave saves the vehicle's current location under name.
+func runLocationSave(ctx context.Context, out io.Writer, client vehicleStatusGetter, internalVIN api.InternalVIN, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("place name must not be empty")
+	}
+
+	vehicleStatus, err := client.GetVehicleStatus(ctx, internalVIN)
+	if err != nil {
+		return fmt.Errorf("failed to get vehicle status: %w", err)
+	}
+	locationInfo, err := vehicleStatus.GetLocationInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get location info: %w", err)
+	}
+	// Vehicles without a GPS fix report 0, 0, which isn't worth saving.
+	if locationInfo.Latitude == 0 && locationInfo.Longitude == 0 {
+		return errors.New("the vehicle has not reported a GPS position; try again once it has")
+	}
+
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return err
+	}
+	places, err := config.LoadPlacesFrom(paths.PlacesFile)
+	if err != nil {
+		return fmt.Errorf("failed to load saved places: %w", err)
+	}
+	places[name] = config.Place{Latitude: locationInfo.Latitude, Longitude: locationInfo.Longitude}
+	if err := config.SavePlacesTo(places, paths.PlacesFile); err != nil {
+		return fmt.Errorf("failed to save place: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(out, "Saved %s at %.6f, %.6f\n", name, locationInfo.Latitude, locationInfo.Longitude)
+
+	return nil
+}
+
+// placeProximity is the saved place nearest to the vehicle, with the distance
+// unit and locale to show the distance in.
+type placeProximity struct {
+	name       string
+	distanceKm float64
+	at         bool // within the --near-threshold
+	unit       distanceUnit
+	locale     language.Tag
+}
+
+// String describes the proximity, e.g. "at home" or "2.3 km from work".
+func (p placeProximity) String() string {
+	if p.at {
+		return "at " + p.name
+	}
+
+	return fmt.Sprintf("%s %s from %s", formatNumber(p.unit.fromKm(p.distanceKm), 1, p.locale), p.unit, p.name)
+}
+
+// toMap converts the proximity for JSON output.
+func (p placeProximity) toMap() map[string]any {
+	return map[string]any{
+		"name":                 p.name,
+		"at":                   p.at,
+		p.unit.key("distance"): p.unit.fromKm(p.distanceKm),
+	}
+}
+
+// nearestSavedPlace returns the saved place nearest to locationInfo, or nil if
+// no places are saved. The vehicle is at the place when it's within thresholdKm.
+func nearestSavedPlace(ctx context.Context, locationInfo api.LocationInfo, thresholdKm float64) (*placeProximity, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	places, err := config.LoadPlacesFrom(paths.PlacesFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load saved places: %w", err)
+	}
+	if len(places) == 0 {
+		return nil, nil
+	}
+	unit, err := distanceUnitFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	locale, err := localeFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sorted so that equally near places resolve the same way every run.
+	var nearest *placeProximity
+	for _, name := range slices.Sorted(maps.Keys(places)) {
+		place := places[name]
+		distanceKm := haversineKm(locationInfo.Latitude, locationInfo.Longitude, place.Latitude, place.Longitude)
+		if nearest == nil || distanceKm < nearest.distanceKm {
+			nearest = &placeProximity{name: name, distanceKm: distanceKm, unit: unit, locale: locale}
+		}
+	}
+	nearest.at = nearest.distanceKm <= thresholdKm
+
+	return nearest, nil
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/cv/mcs/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLocationCommand tests the location command structure.
+func TestLocationCommand(t *testing.T) {
+	t.Parallel()
+	cmd := NewLocationCmd()
+	assertCommandBasics(t, cmd, "location")
+
+	save, _, err := cmd.Find([]string{"save"})
+	require.NoError(t, err)
+	assert.Equal(t, "save <name>", save.Use)
+	require.Error(t, save.ValidateArgs([]string{}))
+	require.NoError(t, save.ValidateArgs([]string{"home"}))
+}
+
+// TestLocationSave tests that a saved place annotates the location view: "at
+// <name>" within --near-threshold, and the distance to the nearest place otherwise.
+func TestLocationSave(t *testing.T) {
+	t.Parallel()
+	placesFile := filepath.Join(t.TempDir(), "places.json")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{PlacesFile: placesFile})
+	statusAt := func(latitude, longitude float64) *api.VehicleStatusResponse {
+		vehicleStatus := apitest.NewVehicleStatus().Build()
+		vehicleStatus.AlertInfos[0].PositionInfo.Latitude = latitude
+		vehicleStatus.AlertInfos[0].PositionInfo.Longitude = longitude
+
+		return vehicleStatus
+	}
+	saveAt := func(name string, latitude, longitude float64) {
+		t.Helper()
+		client := &mockClientForConfirm{
+			getVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.VehicleStatusResponse, error) {
+				return statusAt(latitude, longitude), nil
+			},
+		}
+		var out bytes.Buffer
+		require.NoError(t, runLocationSave(ctx, &out, client, "test-vin", name))
+		assert.Contains(t, out.String(), "Saved "+name+" at ")
+	}
+
+	saveAt("home", 37.7749, -122.4194)
+	saveAt("work", 37.8044, -122.2712)
+	places, err := config.LoadPlacesFrom(placesFile)
+	require.NoError(t, err)
+	assert.Equal(t, config.Places{
+		"home": {Latitude: 37.7749, Longitude: -122.4194},
+		"work": {Latitude: 37.8044, Longitude: -122.2712},
+	}, places)
+
+	t.Run("at a saved place", func(t *testing.T) {
+		t.Parallel()
+		// About 50 m from home.
+		var out, errOut bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &errOut, statusAt(37.7753, -122.4196), outputText, false, "", 0.15))
+
+		assert.Regexp(t, `^LOCATION: [-\d.]+, [-\d.]+\n  at home\n`, out.String())
+		assert.Empty(t, errOut.String())
+	})
+
+	t.Run("near a saved place", func(t *testing.T) {
+		t.Parallel()
+		// 0.01° of latitude, about 1.1 km, north of home.
+		var out bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &bytes.Buffer{}, statusAt(37.7849, -122.4194), outputText, false, "", 0.15))
+
+		assert.Contains(t, out.String(), "  1.1 km from home\n")
+	})
+
+	t.Run("within a wider threshold", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &bytes.Buffer{}, statusAt(37.7849, -122.4194), outputText, false, "", 2))
+
+		assert.Contains(t, out.String(), "  at home\n")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, runStatusLocation(ctx, &out, &bytes.Buffer{}, statusAt(37.8044, -122.2712), outputJSON, false, "", 0.15))
+
+		place, ok := parseJSONToMap(t, out.String())["place"].(map[string]any)
+		require.True(t, ok, "place should be an object")
+		assert.Equal(t, "work", place["name"])
+		assert.Equal(t, true, place["at"])
+		assert.InDelta(t, 0.0, place["distance_km"], 0.001)
+	})
+}
+
+// TestRunLocationSave_EmptyName tests that a place needs a name.
+func TestRunLocationSave_EmptyName(t *testing.T) {
+	t.Parallel()
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{PlacesFile: filepath.Join(t.TempDir(), "places.json")})
+
+	err := runLocationSave(ctx, &bytes.Buffer{}, &mockClientForConfirm{}, "test-vin", "  ")
+	require.EqualError(t, err, "place name must not be empty")
+}
+
+// TestRunLocationSave_NoPosition tests that 0, 0, reported without a GPS fix,
+// isn't saved.
+func TestRunLocationSave_NoPosition(t *testing.T) {
+	t.Parallel()
+	placesFile := filepath.Join(t.TempDir(), "places.json")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{PlacesFile: placesFile})
+	client := &mockClientForConfirm{
+		getVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.VehicleStatusResponse, error) {
+			vehicleStatus := apitest.NewVehicleStatus().Build()
+			vehicleStatus.AlertInfos[0].PositionInfo.Latitude = 0
+			vehicleStatus.AlertInfos[0].PositionInfo.Longitude = 0
+
+			return vehicleStatus, nil
+		},
+	}
+
+	err := runLocationSave(ctx, &bytes.Buffer{}, client, "test-vin", "home")
+	require.EqualError(t, err, "the vehicle has not reported a GPS position; try again once it has")
+	assert.NoFileExists(t, placesFile)
+}
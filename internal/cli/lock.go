@@ -25,14 +25,19 @@ func NewLockCmd() *cobra.Command {
   mcs lock --confirm=false
 
   # Lock doors and wait up to 60 seconds for confirmation
-  mcs lock --confirm-wait 60`,
+  mcs lock --confirm-wait 60
+
+  # Re-send the lock command up to 2 times if it isn't confirmed
+  mcs lock --retry 2`,
 		ConfirmFlagUsage: "wait for confirmation that doors are locked",
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.DoorLock(ctx, string(internalVIN))
-			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForDoorsLocked(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+			Actions: []ControlStep{{Endpoint: api.EndpointDoorLock}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					return waitForDoorsLocked(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
 			InitialDelay:  ConfirmationInitialDelay,
 			SuccessMsg:    "Doors locked successfully",
@@ -60,21 +65,27 @@ func NewUnlockCmd() *cobra.Command {
   mcs unlock --confirm=false
 
   # Unlock doors and wait up to 60 seconds for confirmation
-  mcs unlock --confirm-wait 60`,
+  mcs unlock --confirm-wait 60
+
+  # Re-sending an unlock needs --force
+  mcs unlock --retry 2 --force`,
 		ConfirmFlagUsage: "wait for confirmation that doors are unlocked",
 		Config: ConfirmableCommandConfig{
-			ActionFunc: func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
-				return client.DoorUnlock(ctx, string(internalVIN))
+			Actions: []ControlStep{{Endpoint: api.EndpointDoorUnlock}},
+			Confirm: &ConfirmStep{
+				Refresh:  api.EndpointRefreshVehicleStatus,
+				Endpoint: api.EndpointGetVehicleStatus,
+				Wait: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
+					return waitForDoorsUnlocked(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
+				},
 			},
-			WaitFunc: func(ctx context.Context, out io.Writer, client *api.Client, internalVIN api.InternalVIN, timeout, pollInterval time.Duration) confirmationResult {
-				return waitForDoorsUnlocked(ctx, out, &clientAdapter{Client: client}, internalVIN, timeout, pollInterval)
-			},
-			InitialDelay:  ConfirmationInitialDelay,
-			SuccessMsg:    "Doors unlocked successfully",
-			WaitingMsg:    "Unlock command sent, waiting for confirmation...",
-			ActionName:    "unlock doors",
-			ConfirmName:   "unlock status",
-			TimeoutSuffix: "confirmation timeout",
+			InitialDelay:   ConfirmationInitialDelay,
+			UnsafeToResend: true,
+			SuccessMsg:     "Doors unlocked successfully",
+			WaitingMsg:     "Unlock command sent, waiting for confirmation...",
+			ActionName:     "unlock doors",
+			ConfirmName:    "unlock status",
+			TimeoutSuffix:  "confirmation timeout",
 		},
 	})
 }
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cv/mcs/internal/notify"
+)
+
+// notifyTimeout bounds how long a notifier may take, so a hung one can't hold up the command.
+const notifyTimeout = 5 * time.Second
+
+// notifyDone shows a desktop notification when --notify is set. Failures are
+// ignored, as the command's own output already reports the outcome.
+func notifyDone(ctx context.Context, title, body string) {
+	cfg := ConfigFromContext(ctx)
+	if cfg == nil || !cfg.Notify {
+		return
+	}
+
+	send := cfg.Notifier
+	if send == nil {
+		send = notify.Send
+	}
+	// Notify even when the command was interrupted.
+	notifyCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+	defer cancel()
+	_ = send(notifyCtx, title, body)
+}
+
+// confirmationSummary describes the outcome of a confirmable command for a notification.
+func confirmationSummary(outcome string, elapsed time.Duration, err error) string {
+	elapsed = elapsed.Round(time.Second)
+	switch outcome {
+	case confirmOutcomeConfirmed:
+		return fmt.Sprintf("Confirmed after %s", elapsed)
+	case confirmOutcomeTimeout:
+		return fmt.Sprintf("Timed out after %s", elapsed)
+	case confirmOutcomeSent:
+		return "Sent"
+	default:
+		return fmt.Sprintf("Failed: %v", err)
+	}
+}
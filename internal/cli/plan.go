@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+)
+
+// PlanStep is one API call a command makes.
+type PlanStep struct {
+	Method   string // HTTP method, e.g. "POST"
+	Endpoint string // API endpoint, e.g. api.EndpointDoorLock
+	Note     string // when or how often the call is made; empty if always, once
+}
+
+// CommandPlan is the sequence of API calls a command makes, printed by --explain.
+type CommandPlan struct {
+	Command string // command name, e.g. "lock" or "status"
+	Steps   []PlanStep
+}
+
+// newCommandPlan starts a plan with the calls every vehicle command makes: logging
+// in when needed and listing the account's vehicles.
+func newCommandPlan(command string) *CommandPlan {
+	plan := &CommandPlan{Command: command}
+	plan.add(http.MethodPost, api.EndpointCheckVersion, fmt.Sprintf("only if the cached keys are missing or older than %.0f hours", api.KeysTTL.Hours()))
+	plan.add(http.MethodGet, api.EndpointEncryptionKey, "only if the cached token is missing or expired, to log in")
+	plan.add(http.MethodPost, api.EndpointLogin, "only if the cached token is missing or expired")
+	plan.add(http.MethodPost, api.EndpointGetVecBaseInfos, "")
+
+	return plan
+}
+
+// add appends a step to the plan.
+func (p *CommandPlan) add(method, endpoint, note string) {
+	p.Steps = append(p.Steps, PlanStep{Method: method, Endpoint: endpoint, Note: note})
+}
+
+// pollStep is a status request repeated every interval until done, for at most timeout.
+func pollStep(endpoint string, interval, timeout time.Duration, until string) PlanStep {
+	return PlanStep{
+		Method:   http.MethodPost,
+		Endpoint: endpoint,
+		Note:     fmt.Sprintf("every %s until %s, for up to %s", interval, until, timeout),
+	}
+}
+
+// String renders the plan as a numbered list of API calls.
+func (p *CommandPlan) String() string {
+	var b strings.Builder
+	_, _ = fmt.Fprintf(&b, "mcs %s would make these API calls:\n", p.Command)
+	for i, step := range p.Steps {
+		_, _ = fmt.Fprintf(&b, "  %d. %s %s", i+1, step.Method, step.Endpoint)
+		if step.Note != "" {
+			_, _ = fmt.Fprintf(&b, " (%s)", step.Note)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// printPlan prints plan for --explain.
+func printPlan(out io.Writer, plan *CommandPlan) {
+	_, _ = fmt.Fprint(out, plan.String())
+}
+
+// confirmablePlan lists the API calls of a confirmable command run with opts.
+func confirmablePlan(config ConfirmableCommandConfig, opts confirmOptions) *CommandPlan {
+	plan := newCommandPlan(opts.action)
+
+	if config.Before != nil && config.Before.Plan != nil {
+		plan.Steps = append(plan.Steps, config.Before.Plan()...)
+	}
+	if config.Skip != nil && !opts.force {
+		plan.add(http.MethodPost, config.Skip.Endpoint, "to skip the command if the vehicle is already in the requested state")
+	}
+
+	note := ""
+	if opts.retries > 0 && config.Confirm != nil && opts.confirm {
+		note = fmt.Sprintf("re-sent up to %d times if not confirmed", opts.retries)
+	}
+	for _, action := range config.Actions {
+		plan.add(http.MethodPost, action.Endpoint, note)
+	}
+
+	if config.Confirm != nil && opts.confirm {
+		pollInterval := config.PollInterval
+		if pollInterval == 0 {
+			pollInterval = DefaultPollInterval
+		}
+		// Wait sleeps for the initial delay, then refreshes once and polls.
+		delay := ""
+		if config.InitialDelay > 0 {
+			delay = fmt.Sprintf("after an initial %s delay, ", config.InitialDelay)
+		}
+		if config.Confirm.Refresh != "" {
+			note := "to get a fresh status before polling"
+			if delay != "" {
+				note = "after the initial delay, " + note
+			}
+			plan.add(http.MethodPost, config.Confirm.Refresh, note)
+		}
+		timeout := time.Duration(opts.confirmWait)*time.Second - config.InitialDelay
+		poll := pollStep(config.Confirm.Endpoint, pollInterval, timeout, "confirmed")
+		poll.Note = delay + poll.Note
+		plan.Steps = append(plan.Steps, poll)
+	}
+
+	return plan
+}
+
+// statusPlan lists the API calls of mcs status run with opts.
+func statusPlan(opts statusOptions) *CommandPlan {
+	plan := newCommandPlan("status")
+	if opts.allVehicles {
+		plan.add(http.MethodPost, api.EndpointGetEVVehicleStatus, "once per vehicle")
+		plan.add(http.MethodPost, api.EndpointGetVehicleStatus, "once per vehicle")
+
+		return plan
+	}
+
+	plan.add(http.MethodPost, api.EndpointGetEVVehicleStatus, "")
+	if opts.refresh || opts.waitFresh {
+		plan.add(http.MethodPost, api.EndpointRefreshVehicleStatus, "only for PHEV and EV models")
+		plan.Steps = append(plan.Steps, pollStep(api.EndpointGetEVVehicleStatus, refreshPollInterval, time.Duration(opts.refreshWait)*time.Second, "the status timestamp changes"))
+	}
+	plan.add(http.MethodPost, api.EndpointGetVehicleStatus, "")
+
+	return plan
+}
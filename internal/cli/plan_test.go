@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestExplain tests that --explain prints the API calls instead of making them.
+// The commands would fail without credentials if they ran.
+func TestExplain(t *testing.T) {
+	t.Parallel()
+	const preamble = "  1. POST service/checkVersion (only if the cached keys are missing or older than 12 hours)\n" +
+		"  2. GET system/encryptionKey (only if the cached token is missing or expired, to log in)\n" +
+		"  3. POST user/login (only if the cached token is missing or expired)\n" +
+		"  4. POST remoteServices/getVecBaseInfos/v4\n"
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "lock",
+			args: []string{"lock", "--explain"},
+			want: "mcs lock would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/doorLock/v4\n" +
+				"  6. POST remoteServices/activeRealTimeVehicleStatus/v4 (after the initial delay, to get a fresh status before polling)\n" +
+				"  7. POST remoteServices/getVehicleStatus/v4 (after an initial 20s delay, every 5s until confirmed, for up to 1m10s)\n",
+		},
+		{
+			name: "lock polling immediately",
+			args: []string{"lock", "--explain", "--initial-delay", "0"},
+			want: "mcs lock would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/doorLock/v4\n" +
+				"  6. POST remoteServices/activeRealTimeVehicleStatus/v4 (to get a fresh status before polling)\n" +
+				"  7. POST remoteServices/getVehicleStatus/v4 (every 5s until confirmed, for up to 1m30s)\n",
+		},
+		{
+			name: "lock with a confirm-wait shorter than the default initial delay",
+			args: []string{"lock", "--explain", "--confirm-wait", "15"},
+			want: "mcs lock would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/doorLock/v4\n" +
+				"  6. POST remoteServices/activeRealTimeVehicleStatus/v4 (after the initial delay, to get a fresh status before polling)\n" +
+				"  7. POST remoteServices/getVehicleStatus/v4 (after an initial 7s delay, every 5s until confirmed, for up to 8s)\n",
+		},
+		{
+			name: "lock without confirmation",
+			args: []string{"lock", "--explain", "--confirm=false", "--confirm-wait", "10"},
+			want: "mcs lock would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/doorLock/v4\n",
+		},
+		{
+			name: "status",
+			args: []string{"status", "--explain"},
+			want: "mcs status would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/getEVVehicleStatus/v4\n" +
+				"  6. POST remoteServices/getVehicleStatus/v4\n",
+		},
+		{
+			name: "status with refresh",
+			args: []string{"status", "--explain", "--refresh", "--refresh-wait", "60"},
+			want: "mcs status would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/getEVVehicleStatus/v4\n" +
+				"  6. POST remoteServices/activeRealTimeVehicleStatus/v4 (only for PHEV and EV models)\n" +
+				"  7. POST remoteServices/getEVVehicleStatus/v4 (every 30s until the status timestamp changes, for up to 1m0s)\n" +
+				"  8. POST remoteServices/getVehicleStatus/v4\n",
+		},
+		{
+			name: "charge start skips the state check with --force",
+			args: []string{"charge", "start", "--explain", "--force", "--confirm=false"},
+			want: "mcs charge start would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/chargeStart/v4\n",
+		},
+		{
+			name: "climate set",
+			args: []string{"climate", "set", "--temp", "22", "--explain"},
+			want: "mcs climate set would make these API calls:\n" + preamble +
+				"  5. POST remoteServices/updateHVACSetting/v4\n" +
+				"  6. POST remoteServices/activeRealTimeVehicleStatus/v4 (after the initial delay, to get a fresh status before polling)\n" +
+				"  7. POST remoteServices/getEVVehicleStatus/v4 (after an initial 20s delay, every 5s until confirmed, for up to 1m10s)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := testCLIConfig()
+			cfg.CacheFile = t.TempDir() + "/token.json"
+			rootCmd := NewRootCmd(cfg)
+			rootCmd.AddCommand(NewStatusCmd(), NewLockCmd(), NewChargeCmd(), NewClimateCmd())
+			var out bytes.Buffer
+			rootCmd.SetArgs(append([]string{"--config", t.TempDir() + "/missing.toml"}, tt.args...))
+			rootCmd.SetOut(&out)
+			rootCmd.SetErr(&bytes.Buffer{})
+			require.NoError(t, rootCmd.Execute())
+
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+// TestExplain_LockRefreshesBeforePolling tests that the plan lists the refresh
+// request the confirmation sends between the command and the status polls.
+func TestExplain_LockRefreshesBeforePolling(t *testing.T) {
+	t.Parallel()
+	cfg := testCLIConfig()
+	cfg.CacheFile = t.TempDir() + "/token.json"
+	rootCmd := NewRootCmd(cfg)
+	rootCmd.AddCommand(NewLockCmd())
+	var out bytes.Buffer
+	rootCmd.SetArgs([]string{"--config", t.TempDir() + "/missing.toml", "lock", "--explain"})
+	rootCmd.SetOut(&out)
+	rootCmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, rootCmd.Execute())
+
+	plan := out.String()
+	lock := strings.Index(plan, "POST "+api.EndpointDoorLock)
+	refresh := strings.Index(plan, "POST "+api.EndpointRefreshVehicleStatus)
+	poll := strings.Index(plan, "POST "+api.EndpointGetVehicleStatus)
+	require.NotEqual(t, -1, refresh, "plan should list the refresh request: %s", plan)
+	assert.Less(t, lock, refresh)
+	assert.Less(t, refresh, poll)
+	assert.Contains(t, plan, "after an initial 20s delay, every 5s until confirmed")
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cv/mcs/internal/api"
+	"golang.org/x/term"
+)
+
+// maxPasswordPrompts is how many times a rejected password is re-prompted
+// before giving up.
+const maxPasswordPrompts = 3
+
+// passwordPrompter asks for a replacement password after a rejected login.
+type passwordPrompter struct {
+	in    io.Reader
+	lines *bufio.Reader // reads piped input; terminals are read without echo instead
+	out   io.Writer
+}
+
+// newPasswordPrompter creates a prompter reading from in and prompting on out.
+func newPasswordPrompter(in io.Reader, out io.Writer) *passwordPrompter {
+	return &passwordPrompter{in: in, lines: bufio.NewReader(in), out: out}
+}
+
+// passwordPrompterKey is the context key for passwordPrompter.
+type passwordPrompterKey struct{}
+
+// contextWithPasswordPrompter returns a new context that allows re-prompting
+// for the password.
+func contextWithPasswordPrompter(ctx context.Context, p *passwordPrompter) context.Context {
+	return context.WithValue(ctx, passwordPrompterKey{}, p)
+}
+
+// passwordPrompterFromContext returns the context's prompter, or nil for
+// non-interactive sessions.
+func passwordPrompterFromContext(ctx context.Context) *passwordPrompter {
+	p, _ := ctx.Value(passwordPrompterKey{}).(*passwordPrompter)
+
+	return p
+}
+
+// isTerminalInput reports whether r is an interactive terminal.
+func isTerminalInput(r io.Reader) bool {
+	f, ok := r.(*os.File)
+
+	return ok && IsTTY(f)
+}
+
+// readPassword prompts for a password and reads one line. A terminal is read
+// without echoing the password; other input is read as a plain line.
+func (p *passwordPrompter) readPassword(ctx context.Context) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	_, _ = fmt.Fprint(p.out, "Password: ")
+
+	if f, ok := p.in.(*os.File); ok && IsTTY(f) {
+		return readTerminalPassword(ctx, f, p.out)
+	}
+
+	line, err := p.lines.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// readTerminalPassword reads a password from the terminal f with echo turned
+// off. A terminal read can't be interrupted, so if ctx is cancelled (e.g. by
+// Ctrl-C) the terminal is restored and the read is abandoned to end with the
+// process, which is exiting.
+func readTerminalPassword(ctx context.Context, f *os.File, out io.Writer) (string, error) {
+	fd := int(f.Fd())
+	state, err := term.GetState(fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	type result struct {
+		password []byte
+		err      error
+	}
+	passwords := make(chan result, 1)
+	go func() {
+		password, err := term.ReadPassword(fd)
+		passwords <- result{password, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		_ = term.Restore(fd, state)
+		_, _ = fmt.Fprintln(out)
+
+		return "", ctx.Err()
+	case r := <-passwords:
+		// The newline typed after the password wasn't echoed either.
+		_, _ = fmt.Fprintln(out)
+		if r.err != nil {
+			return "", fmt.Errorf("failed to read password: %w", r.err)
+		}
+
+		return string(r.password), nil
+	}
+}
+
+// retryOnInvalidCredential runs call and, in interactive sessions, re-prompts
+// for the password while the login is rejected. Non-interactive sessions fail
+// fast with the original error.
+func retryOnInvalidCredential(ctx context.Context, setPassword func(string), call func() error) error {
+	err := call()
+	prompter := passwordPrompterFromContext(ctx)
+	if prompter == nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxPasswordPrompts && api.IsInvalidCredential(err); attempt++ {
+		_, _ = fmt.Fprintf(prompter.out, "Login failed: %v. Try again.\n", err)
+		password, readErr := prompter.readPassword(ctx)
+		if readErr != nil {
+			return readErr
+		}
+		setPassword(password)
+		err = call()
+	}
+
+	return err
+}
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLogin returns a call that rejects every password except want, recording attempts.
+func fakeLogin(want string, password *string, attempts *int) func() error {
+	return func() error {
+		*attempts++
+		if *password != want {
+			return api.NewInvalidCredentialError()
+		}
+
+		return nil
+	}
+}
+
+func TestRetryOnInvalidCredential_RepromptsUntilAccepted(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	prompter := newPasswordPrompter(strings.NewReader("still-wrong\ncorrect\n"), &out)
+	ctx := contextWithPasswordPrompter(context.Background(), prompter)
+
+	password := "wrong"
+	attempts := 0
+	err := retryOnInvalidCredential(ctx, func(p string) { password = p }, fakeLogin("correct", &password, &attempts))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 2, strings.Count(out.String(), "Password: "))
+	assert.Contains(t, out.String(), "incorrect email or password")
+}
+
+func TestRetryOnInvalidCredential_GivesUpAfterMaxPrompts(t *testing.T) {
+	t.Parallel()
+	input := strings.Repeat("wrong\n", maxPasswordPrompts+1)
+	ctx := contextWithPasswordPrompter(context.Background(), newPasswordPrompter(strings.NewReader(input), &bytes.Buffer{}))
+
+	password := "wrong"
+	attempts := 0
+	err := retryOnInvalidCredential(ctx, func(p string) { password = p }, fakeLogin("correct", &password, &attempts))
+
+	require.True(t, api.IsInvalidCredential(err))
+	assert.Equal(t, maxPasswordPrompts+1, attempts)
+}
+
+func TestRetryOnInvalidCredential_NonInteractiveFailsFast(t *testing.T) {
+	t.Parallel()
+	password := "wrong"
+	attempts := 0
+	err := retryOnInvalidCredential(context.Background(), func(p string) { password = p }, fakeLogin("correct", &password, &attempts))
+
+	require.True(t, api.IsInvalidCredential(err))
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryOnInvalidCredential_OtherErrorsNotRetried(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	ctx := contextWithPasswordPrompter(context.Background(), newPasswordPrompter(strings.NewReader("correct\n"), &out))
+
+	attempts := 0
+	err := retryOnInvalidCredential(ctx, func(string) {}, func() error {
+		attempts++
+
+		return errors.New("network down")
+	})
+
+	require.EqualError(t, err, "network down")
+	assert.Equal(t, 1, attempts)
+	assert.Empty(t, out.String())
+}
+
+func TestPasswordPrompter_ReadPassword(t *testing.T) {
+	t.Parallel()
+	prompter := newPasswordPrompter(strings.NewReader("secret\r\n"), &bytes.Buffer{})
+	password, err := prompter.readPassword(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	// Input ends without a password.
+	_, err = prompter.readPassword(context.Background())
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	blocked := newPasswordPrompter(blockingReader{}, &bytes.Buffer{})
+	_, err = blocked.readPassword(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestPasswordPrompter_ReadPassword_Piped tests that input that isn't a terminal,
+// such as a pipe, is read as plain lines.
+func TestPasswordPrompter_ReadPassword_Piped(t *testing.T) {
+	t.Parallel()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+	_, err = w.WriteString("first\nsecond\n")
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	var out bytes.Buffer
+	prompter := newPasswordPrompter(r, &out)
+	for _, want := range []string{"first", "second"} {
+		password, err := prompter.readPassword(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, want, password)
+	}
+	assert.Equal(t, "Password: Password: ", out.String())
+
+	_, err = prompter.readPassword(context.Background())
+	require.ErrorContains(t, err, "failed to read password")
+}
+
+// blockingReader never returns, simulating a user who doesn't type anything.
+type blockingReader struct{}
+
+func (blockingReader) Read([]byte) (int, error) {
+	select {}
+}
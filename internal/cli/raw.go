@@ -2,7 +2,6 @@ package cli
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/cv/mcs/internal/api"
@@ -11,6 +10,8 @@ import (
 
 // NewRawCmd creates the raw command for debugging.
 func NewRawCmd() *cobra.Command {
+	var jsonCompact bool
+
 	rawCmd := &cobra.Command{
 		Use:   "raw",
 		Short: "Output raw API responses (for debugging)",
@@ -39,6 +40,8 @@ func NewRawCmd() *cobra.Command {
   # }`,
 	}
 
+	rawCmd.PersistentFlags().BoolVar(&jsonCompact, "json-compact", false, "output single-line JSON")
+
 	// Add subcommands
 	rawCmd.AddCommand(&cobra.Command{
 		Use:   "status",
@@ -49,7 +52,7 @@ func NewRawCmd() *cobra.Command {
 
   # Output includes remoteInfos, alertInfos, and vehicle status data`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRawStatus(cmd)
+			return runRawStatus(cmd, newOutputFormat(cmd.Context(), true, jsonCompact))
 		},
 		SilenceUsage: true,
 	})
@@ -63,7 +66,7 @@ func NewRawCmd() *cobra.Command {
 
   # Output includes battery, charging, and EV-specific data`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRawEV(cmd)
+			return runRawEV(cmd, newOutputFormat(cmd.Context(), true, jsonCompact))
 		},
 		SilenceUsage: true,
 	})
@@ -77,7 +80,7 @@ func NewRawCmd() *cobra.Command {
 
   # Output includes VIN, model, year, and vehicle metadata`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRawVehicle(cmd)
+			return runRawVehicle(cmd, newOutputFormat(cmd.Context(), true, jsonCompact))
 		},
 		SilenceUsage: true,
 	})
@@ -86,45 +89,45 @@ func NewRawCmd() *cobra.Command {
 }
 
 // runRawStatus executes the raw status command.
-func runRawStatus(cmd *cobra.Command) error {
+func runRawStatus(cmd *cobra.Command, format outputFormat) error {
 	return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 		vehicleStatus, err := client.GetVehicleStatus(ctx, string(internalVIN))
 		if err != nil {
 			return fmt.Errorf("failed to get vehicle status: %w", err)
 		}
 
-		jsonBytes, err := json.MarshalIndent(vehicleStatus, "", "  ")
+		output, err := toJSON(vehicleStatus, format)
 		if err != nil {
-			return fmt.Errorf("failed to marshal JSON: %w", err)
+			return err
 		}
 
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
 
 		return nil
 	})
 }
 
 // runRawEV executes the raw ev command.
-func runRawEV(cmd *cobra.Command) error {
+func runRawEV(cmd *cobra.Command, format outputFormat) error {
 	return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
 		evStatus, err := client.GetEVVehicleStatus(ctx, string(internalVIN))
 		if err != nil {
 			return fmt.Errorf("failed to get EV status: %w", err)
 		}
 
-		jsonBytes, err := json.MarshalIndent(evStatus, "", "  ")
+		output, err := toJSON(evStatus, format)
 		if err != nil {
-			return fmt.Errorf("failed to marshal JSON: %w", err)
+			return err
 		}
 
-		_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
 
 		return nil
 	})
 }
 
 // runRawVehicle executes the raw vehicle command.
-func runRawVehicle(cmd *cobra.Command) error {
+func runRawVehicle(cmd *cobra.Command, format outputFormat) error {
 	ctx := cmd.Context()
 	client, err := createAPIClient(ctx)
 	if err != nil {
@@ -137,12 +140,12 @@ func runRawVehicle(cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get vehicle info: %w", err)
 	}
 
-	jsonBytes, err := json.MarshalIndent(vecBaseInfos, "", "  ")
+	output, err := toJSON(vecBaseInfos, format)
 	if err != nil {
-		return fmt.Errorf("failed to marshal JSON: %w", err)
+		return err
 	}
 
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
 
 	return nil
 }
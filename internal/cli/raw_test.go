@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -113,11 +114,11 @@ func TestRunRawStatus_OutputFormat(t *testing.T) {
 	}{
 		{
 			name:     "basic vehicle status response",
-			response: NewMockVehicleStatus().Build(),
+			response: apitest.NewVehicleStatus().Build(),
 		},
 		{
 			name: "vehicle status with custom door state",
-			response: NewMockVehicleStatus().WithDoorStatus(api.DoorStatus{
+			response: apitest.NewVehicleStatus().WithDoorStatus(api.DoorStatus{
 				DriverOpen:      true,
 				PassengerOpen:   false,
 				RearLeftOpen:    false,
@@ -163,19 +164,19 @@ func TestRunRawEV_OutputFormat(t *testing.T) {
 	}{
 		{
 			name:     "basic EV status response",
-			response: NewMockEVVehicleStatus().Build(),
+			response: apitest.NewEVVehicleStatus().Build(),
 		},
 		{
 			name:     "EV status with HVAC on",
-			response: NewMockEVVehicleStatus().WithHVAC(true).Build(),
+			response: apitest.NewEVVehicleStatus().WithHVAC(true).Build(),
 		},
 		{
 			name:     "EV status with charging",
-			response: NewMockEVVehicleStatus().WithCharging(true).Build(),
+			response: apitest.NewEVVehicleStatus().WithCharging(true).Build(),
 		},
 		{
 			name:     "EV status without HVAC data",
-			response: NewMockEVVehicleStatus().WithoutHVAC().Build(),
+			response: apitest.NewEVVehicleStatus().WithoutHVAC().Build(),
 		},
 	}
 
@@ -293,7 +294,7 @@ func TestRawHandlers_ErrorContext(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
 		name            string
-		handlerFunc     func(*cobra.Command) error
+		handlerFunc     func(*cobra.Command, outputFormat) error
 		expectedErrType string
 	}{
 		{
@@ -327,7 +328,7 @@ func TestRawHandlers_ErrorContext(t *testing.T) {
 			cmd.SetErr(&buf)
 
 			// Execute the handler - we expect an error
-			err := tt.handlerFunc(cmd)
+			err := tt.handlerFunc(cmd, outputJSON)
 
 			// We expect an error due to the canceled context or missing config
 			// This tests that the error path is reachable
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/cache"
+	"github.com/spf13/cobra"
+)
+
+// NewReauthCmd creates the reauth command.
+func NewReauthCmd() *cobra.Command {
+	var login bool
+
+	cmd := &cobra.Command{
+		Use:   "reauth",
+		Short: "Clear cached credentials so the next command logs in again",
+		Long: `Delete the cached access token and encryption keys for the active profile.
+
+The next command fetches fresh keys and logs in with the configured email and
+password. Use this after changing your password, or when the cached token is
+rejected. With --login, log in straight away to check the credentials and cache
+the new token.`,
+		Example: `  # Force a fresh login on the next command
+  mcs reauth
+
+  # Clear the cache and log in now
+  mcs reauth --login
+
+  # Clear a named profile's cache
+  mcs --profile work reauth`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReauth(cmd.Context(), cmd.OutOrStdout(), login)
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&login, "login", false, "log in after clearing the cache to check the credentials")
+
+	return cmd
+}
+
+// runReauth removes the profile's token cache and, with login, logs in again
+// and caches the new credentials.
+func runReauth(ctx context.Context, out io.Writer, login bool) error {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	removed, err := cache.RemoveFrom(paths.TokenCache)
+	if err != nil {
+		return err
+	}
+	if removed {
+		_, _ = fmt.Fprintf(out, "Cleared cached token and encryption keys (%s)\n", paths.TokenCache)
+	} else {
+		_, _ = fmt.Fprintf(out, "No cached credentials to clear (%s)\n", paths.TokenCache)
+	}
+
+	if !login {
+		return nil
+	}
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	opts, err := clientOptions(ctx)
+	if err != nil {
+		return err
+	}
+	client, err := api.NewClient(cfg.Email, cfg.Password, cfg.Region, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := client.GetEncryptionKeys(ctx); err != nil {
+		return fmt.Errorf("failed to get encryption keys: %w", err)
+	}
+	if err := client.Login(ctx); err != nil {
+		return err
+	}
+	saveClientCache(ctx, client)
+	_, _ = fmt.Fprintf(out, "Logged in as %s\n", cfg.Email)
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReauthCommand tests the reauth command structure.
+func TestReauthCommand(t *testing.T) {
+	t.Parallel()
+	cmd := NewReauthCmd()
+	assertCommandBasics(t, cmd, "reauth")
+	assertNoArgsCommand(t, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("login"))
+}
+
+// TestRunReauth tests that the token cache is removed and the next client
+// starts without cached credentials, so it performs a full login.
+func TestRunReauth(t *testing.T) {
+	t.Parallel()
+	ctx := testContextWithValidConfig(t)
+	cacheFile := ConfigFromContext(ctx).CacheFile
+	require.NoError(t, cache.SaveTo(&cache.TokenCache{
+		AccessToken:             "cached-token",
+		AccessTokenExpirationTs: time.Now().Add(time.Hour).Unix(),
+		EncKey:                  "cached-enc-key",
+		SignKey:                 "cached-sign-key",
+	}, cacheFile))
+
+	// The cached token is used before reauth.
+	client, err := createAPIClient(ctx)
+	require.NoError(t, err)
+	accessToken, _, _, _ := client.GetCredentials()
+	assert.Equal(t, "cached-token", accessToken)
+
+	var out bytes.Buffer
+	require.NoError(t, runReauth(ctx, &out, false))
+	assert.Equal(t, "Cleared cached token and encryption keys ("+cacheFile+")\n", out.String())
+	assert.NoFileExists(t, cacheFile)
+
+	client, err = createAPIClient(ctx)
+	require.NoError(t, err)
+	accessToken, _, encKey, signKey := client.GetCredentials()
+	assert.Empty(t, accessToken)
+	assert.Empty(t, encKey)
+	assert.Empty(t, signKey)
+
+	// Running it again is harmless.
+	out.Reset()
+	require.NoError(t, runReauth(ctx, &out, false))
+	assert.Contains(t, out.String(), "No cached credentials to clear")
+}
+
+// TestRunReauth_LoginNeedsConfig tests that --login reports a config problem
+// after clearing the cache.
+func TestRunReauth_LoginNeedsConfig(t *testing.T) {
+	t.Parallel()
+	ctx := testContextWithEmptyConfig(t)
+
+	var out bytes.Buffer
+	err := runReauth(ctx, &out, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid config")
+	assert.Contains(t, out.String(), "No cached credentials to clear")
+}
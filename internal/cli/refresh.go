@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// NewRefreshCmd creates the refresh command.
+func NewRefreshCmd() *cobra.Command {
+	var wait bool
+	var refreshWait int
+
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Ask the vehicle to push fresh status, without displaying it",
+		Long: `Ask the vehicle to send its current status to the server, so that a later
+mcs status or integration read is fresh. Nothing but a short confirmation is printed.
+
+With --wait, mcs waits until the server has the new status, and fails with the
+timeout exit code if it doesn't arrive within --refresh-wait. Only PHEV and EV
+models can refresh.`,
+		Example: `  # Request fresh status and return straight away
+  mcs refresh
+
+  # Wait up to 2 minutes for the vehicle to report
+  mcs refresh --wait --refresh-wait 120`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateWaitSeconds("refresh-wait", refreshWait); err != nil {
+				return err
+			}
+
+			return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+				return runRefresh(ctx, cmd.OutOrStdout(), commandAction(cmd), &clientAdapter{Client: client}, vehicleInfo, wait, refreshWait, refreshPollInterval)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the vehicle has reported fresh status")
+	cmd.Flags().IntVar(&refreshWait, "refresh-wait", 90, "with --wait, max seconds to wait for the vehicle to report")
+
+	return cmd
+}
+
+// runRefresh requests fresh status and, with wait, polls every pollInterval until
+// the status timestamp changes. Progress goes to the info writer; only the
+// outcome is printed otherwise.
+func runRefresh(ctx context.Context, out io.Writer, action string, client vehicleStatusGetter, vehicleInfo VehicleInfo, wait bool, refreshWait int, pollInterval time.Duration) error {
+	if !vehicleInfo.Powertrain.SupportsRemoteRefresh() {
+		return fmt.Errorf("refresh not supported on this vehicle (%s)", vehicleInfo.Powertrain)
+	}
+
+	// The current timestamp is what --wait compares against.
+	var evStatus *api.EVVehicleStatusResponse
+	if wait {
+		var err error
+		evStatus, err = client.GetEVVehicleStatus(ctx, vehicleInfo.InternalVIN)
+		if err != nil {
+			return fmt.Errorf("failed to get EV status: %w", err)
+		}
+	}
+
+	if err := client.RefreshVehicleStatus(ctx, vehicleInfo.InternalVIN); err != nil {
+		return fmt.Errorf("failed to refresh vehicle status: %w", err)
+	}
+	if !wait {
+		_, _ = fmt.Fprintln(out, "Refresh requested")
+
+		return nil
+	}
+
+	freshStatus, err := waitForStatusUpdate(ctx, infoWriter(ctx, out), action, client, vehicleInfo.InternalVIN, evStatus, refreshWait, pollInterval, true)
+	if err != nil {
+		return err
+	}
+	timestamp, err := freshStatus.GetOccurrenceDate()
+	if err != nil {
+		return fmt.Errorf("failed to get occurrence date: %w", err)
+	}
+	_, _ = fmt.Fprintf(out, "Status refreshed as of %s\n", formatTimestamp(timestamp))
+
+	return nil
+}
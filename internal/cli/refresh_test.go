@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRefreshCommand tests the refresh command structure.
+func TestRefreshCommand(t *testing.T) {
+	t.Parallel()
+	cmd := NewRefreshCmd()
+	assertCommandBasics(t, cmd, "refresh")
+	assertNoArgsCommand(t, cmd)
+	assert.NotNil(t, cmd.Flags().Lookup("wait"))
+	assert.NotNil(t, cmd.Flags().Lookup("refresh-wait"))
+}
+
+// TestRunRefresh tests that refresh is requested and, with --wait, that the status
+// is polled until its timestamp changes.
+func TestRunRefresh(t *testing.T) {
+	t.Parallel()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-90 PHEV", Powertrain: api.PowertrainPHEV}}
+
+	t.Run("without wait", func(t *testing.T) {
+		t.Parallel()
+		client := &mockClientForConfirm{}
+		var out bytes.Buffer
+
+		require.NoError(t, runRefresh(context.Background(), &out, "refresh", client, vehicleInfo, false, 90, time.Millisecond))
+		assert.Equal(t, 1, client.refreshVehicleStatusCalls)
+		assert.Equal(t, "Refresh requested\n", out.String())
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		t.Parallel()
+		statusCalls := 0
+		client := &mockClientForConfirm{
+			getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+				statusCalls++
+				evStatus := apitest.NewEVVehicleStatus().Build()
+				// The first call reads the current status and the second poll sees the same one.
+				if statusCalls > 2 {
+					evStatus.ResultData[0].OccurrenceDate = "20250115121000"
+				}
+
+				return evStatus, nil
+			},
+		}
+		ctx := ContextWithConfig(context.Background(), &CLIConfig{Quiet: true})
+		var out bytes.Buffer
+
+		require.NoError(t, runRefresh(ctx, &out, "refresh", client, vehicleInfo, true, 90, time.Millisecond))
+		assert.Equal(t, 1, client.refreshVehicleStatusCalls)
+		assert.Equal(t, 3, statusCalls)
+		assert.Equal(t, "Status refreshed as of "+formatTimestamp("20250115121000")+"\n", out.String())
+	})
+
+	t.Run("wait times out", func(t *testing.T) {
+		t.Parallel()
+		client := &mockClientForConfirm{
+			getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+				return apitest.NewEVVehicleStatus().Build(), nil
+			},
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := runRefresh(ctx, &bytes.Buffer{}, "refresh", client, vehicleInfo, true, 10, time.Millisecond)
+		require.ErrorContains(t, err, "status did not update within 10s")
+		assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+	})
+
+	t.Run("unsupported vehicle", func(t *testing.T) {
+		t.Parallel()
+		client := &mockClientForConfirm{}
+
+		err := runRefresh(context.Background(), &bytes.Buffer{}, "refresh", client, VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", Powertrain: api.PowertrainICE}}, false, 90, time.Millisecond)
+		require.EqualError(t, err, "refresh not supported on this vehicle (ICE)")
+		assert.Equal(t, 0, client.refreshVehicleStatusCalls)
+	})
+}
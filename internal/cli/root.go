@@ -2,14 +2,49 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/config"
 	"github.com/spf13/cobra"
 )
 
+// Process exit codes returned by ExitCode, so scripts can branch on $?.
+const (
+	// ExitCodeError is the exit code for general failures.
+	ExitCodeError = 1
+
+	// ExitCodeAuthFailed is the exit code when the email or password was rejected.
+	ExitCodeAuthFailed = 2
+
+	// ExitCodeTimeout is the exit code when the vehicle didn't confirm a command,
+	// or a watched condition wasn't met, before the timeout.
+	ExitCodeTimeout = 3
+
+	// ExitCodeVehicleUnavailable is the exit code when the vehicle refused the
+	// command: another request is still in progress, or the remote start limit
+	// was reached.
+	ExitCodeVehicleUnavailable = 4
+
+	// ExitCodeRateLimited is the exit code when the API rate-limited the request
+	// (EX_TEMPFAIL), so scripts can wait and retry.
+	ExitCodeRateLimited = 75
+)
+
+// timeoutError reports that a command gave up waiting for the vehicle.
+// ExitCode maps it to ExitCodeTimeout.
+type timeoutError struct {
+	message string
+}
+
+func (e *timeoutError) Error() string {
+	return e.message
+}
+
 // checkSkillVersionMismatch checks if the installed skill version differs from the current
 // mcs version and prints a warning to stderr if so.
 func checkSkillVersionMismatch(cmd *cobra.Command) {
@@ -36,23 +71,63 @@ func checkSkillVersionMismatch(cmd *cobra.Command) {
 	}
 }
 
+// loadConfigDefaults applies the config file's [defaults] to cfg. A config file
+// that can't be read only produces a warning here; commands that need the
+// account settings report the error themselves.
+func loadConfigDefaults(ctx context.Context, cmd *cobra.Command, cfg *CLIConfig) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return
+	}
+
+	defaults, err := config.LoadDefaults(paths.ConfigFile)
+	if err != nil {
+		_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: ignoring config defaults: %v\n", err)
+
+		return
+	}
+	applyConfigDefaults(cmd, cfg, defaults)
+}
+
+// insecureWarning is printed to stderr whenever --insecure is used.
+const insecureWarning = "WARNING: --insecure disables TLS certificate verification. Your credentials and\n" +
+	"vehicle data can be intercepted. Prefer --ca-cert with your proxy's CA certificate."
+
 // NewRootCmd creates the root command with the given configuration.
 func NewRootCmd(cfg *CLIConfig) *cobra.Command {
+	pretty := true
 	rootCmd := &cobra.Command{
 		Use:   "mcs",
 		Short: "Control your connected vehicle",
-		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			// --pretty=false is the same as --no-pretty.
+			cfg.NoPretty = cfg.NoPretty || !pretty
+
 			// Attach config to context for use by subcommands.
 			ctx := ContextWithConfig(cmd.Context(), cfg)
+
+			// Fill in defaults from the config file for flags that weren't given.
+			loadConfigDefaults(ctx, cmd, cfg)
+
+			if cfg.Insecure {
+				_, _ = fmt.Fprintln(cmd.ErrOrStderr(), insecureWarning)
+			}
+
+			// Interactive sessions may re-prompt for a rejected password.
+			if isTerminalInput(cmd.InOrStdin()) {
+				ctx = contextWithPasswordPrompter(ctx, newPasswordPrompter(cmd.InOrStdin(), cmd.ErrOrStderr()))
+			}
 			cmd.SetContext(ctx)
 
-			// Disable colors if --no-color flag is set or not a TTY.
-			if cfg.NoColor || !IsTTY(os.Stdout) {
-				SetColorEnabled(false)
+			if err := validateColorMode(cfg.Color); err != nil {
+				return err
 			}
+			SetColorEnabled(shouldColor(cmd))
 
 			// Check for skill version mismatch and warn user.
 			checkSkillVersionMismatch(cmd)
+
+			return nil
 		},
 		Long: `mcs is a CLI tool for controlling your connected vehicle via manufacturer API.
 
@@ -112,11 +187,77 @@ Example config.toml:
 
 	// Add global flags - these bind to the config struct fields.
 	rootCmd.PersistentFlags().StringVarP(&cfg.ConfigFile, "config", "c", "", "config file (default is ~/.config/mcs/config.toml)")
-	rootCmd.PersistentFlags().BoolVar(&cfg.NoColor, "no-color", false, "disable colored output")
+	rootCmd.PersistentFlags().StringVar(&cfg.Profile, "profile", "", "named profile with its own config and token cache (e.g. work)")
+	rootCmd.PersistentFlags().BoolVar(&cfg.NoColor, "no-color", false, "disable colored output (same as --color never)")
+	rootCmd.PersistentFlags().StringVar(&cfg.Color, "color", colorModeAuto, "when to color text output: auto (terminals only, honoring NO_COLOR), always, or never")
+	rootCmd.PersistentFlags().StringVar(&cfg.DistanceUnit, "distance-unit", string(distanceKm), "distance unit for range and odometer: km or mi")
+	rootCmd.PersistentFlags().StringVar(&cfg.TempUnit, "temp-unit", "c", "temperature unit for climate and tire temperatures: c or f")
+	rootCmd.PersistentFlags().StringVar(&cfg.Locale, "locale", defaultLocale, "number format for odometer and range: a language tag such as en-US or de-DE")
+	rootCmd.PersistentFlags().StringVar(&cfg.CACert, "ca-cert", "", "PEM file of extra CA certificates to trust (e.g. a corporate proxy's)")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Insecure, "insecure", false, "skip TLS certificate verification (unsafe; last resort behind TLS-inspecting proxies)")
+	rootCmd.PersistentFlags().StringVar(&cfg.MinTLS, "min-tls", "1.2", "oldest TLS version to accept: 1.2 or 1.3")
+	rootCmd.PersistentFlags().BoolVar(&cfg.NoHTTP2, "no-http2", false, "use HTTP/1.1 only, for proxies that break HTTP/2")
+	rootCmd.PersistentFlags().StringVar(&cfg.UserAgent, "user-agent", api.UserAgentBaseAPI, "User-Agent header for API requests, e.g. to mimic another app version")
+	rootCmd.PersistentFlags().StringVar(&cfg.Theme, "theme", themeNameASCII, "status symbols: ascii or emoji (emoji only on a terminal)")
+	rootCmd.PersistentFlags().BoolVar(&pretty, "pretty", true, "indent JSON output")
+	rootCmd.PersistentFlags().BoolVar(&cfg.NoPretty, "no-pretty", false, "print JSON output on a single line, like --json-compact")
+	rootCmd.MarkFlagsMutuallyExclusive("pretty", "no-pretty")
+	rootCmd.MarkFlagsMutuallyExclusive("color", "no-color")
+	rootCmd.PersistentFlags().BoolVar(&cfg.MaskVIN, "mask-vin", false, "show only the last 6 characters of the VIN, for sharing output")
+	rootCmd.PersistentFlags().BoolVarP(&cfg.Quiet, "quiet", "q", false, "suppress progress messages, printing only results and errors")
+	rootCmd.PersistentFlags().BoolVar(&cfg.Notify, "notify", false, "show a desktop notification when a confirmable command or refresh finishes")
 
 	return rootCmd
 }
 
+// ExitCode maps an error returned by Execute to a process exit code.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var (
+		rateErr       *api.RateLimitedError
+		timeoutErr    *timeoutError
+		inProgressErr *api.RequestInProgressError
+		startLimitErr *api.EngineStartLimitError
+	)
+
+	switch {
+	case errors.As(err, &rateErr):
+		return ExitCodeRateLimited
+	case api.IsInvalidCredential(err):
+		return ExitCodeAuthFailed
+	case errors.As(err, &timeoutErr), errors.Is(err, context.DeadlineExceeded):
+		return ExitCodeTimeout
+	case errors.As(err, &inProgressErr), errors.As(err, &startLimitErr):
+		return ExitCodeVehicleUnavailable
+	default:
+		return ExitCodeError
+	}
+}
+
+// errorCodes returns the server's error codes carried by err, e.g.
+// "920000/400S11", or "" if it has none.
+func errorCodes(err error) string {
+	var coded interface{ Codes() string }
+	if errors.As(err, &coded) {
+		return coded.Codes()
+	}
+
+	return ""
+}
+
+// withErrorCodes appends the server's error codes to err's message, so that
+// every command shows them. err is returned unchanged if it has none.
+func withErrorCodes(err error) error {
+	if codes := errorCodes(err); codes != "" {
+		return fmt.Errorf("%w (code %s)", err, codes)
+	}
+
+	return err
+}
+
 // Execute runs the root command with signal-aware context.
 func Execute(version string) error {
 	// Create context that cancels on SIGINT or SIGTERM.
@@ -130,14 +271,22 @@ func Execute(version string) error {
 
 	// Add subcommands.
 	rootCmd.AddCommand(NewStatusCmd())
+	rootCmd.AddCommand(NewRefreshCmd())
 	rootCmd.AddCommand(NewLockCmd())
 	rootCmd.AddCommand(NewUnlockCmd())
 	rootCmd.AddCommand(NewStartCmd())
 	rootCmd.AddCommand(NewStopCmd())
 	rootCmd.AddCommand(NewChargeCmd())
 	rootCmd.AddCommand(NewClimateCmd())
+	rootCmd.AddCommand(NewWatchCmd())
+	rootCmd.AddCommand(NewEventsCmd())
+	rootCmd.AddCommand(NewLocationCmd())
+	rootCmd.AddCommand(NewBatchCmd())
+	rootCmd.AddCommand(NewDoctorCmd())
+	rootCmd.AddCommand(NewReauthCmd())
 	rootCmd.AddCommand(NewRawCmd())
+	rootCmd.AddCommand(NewConfigCmd())
 	rootCmd.AddCommand(NewSkillCmd(cfg))
 
-	return rootCmd.ExecuteContext(ctx)
+	return withErrorCodes(rootCmd.ExecuteContext(ctx))
 }
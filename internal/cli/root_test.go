@@ -3,11 +3,15 @@ package cli
 import (
 	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/cv/mcs/internal/api"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -196,3 +200,114 @@ func TestCheckSkillVersionMismatch_SkipsSkillSubcommands(t *testing.T) {
 	// Should not print anything for skill subcommand
 	assert.Empty(t, errBuf.String())
 }
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "no error", err: nil, want: 0},
+		{name: "general error", err: errors.New("boom"), want: ExitCodeError},
+		{name: "rate limited", err: api.NewRateLimitedError(30 * time.Second), want: ExitCodeRateLimited},
+		{name: "wrapped rate limited", err: fmt.Errorf("failed to get vehicle info: %w", api.NewRateLimitedError(0)), want: ExitCodeRateLimited},
+		{name: "invalid credentials", err: api.NewInvalidCredentialError(), want: ExitCodeAuthFailed},
+		{name: "confirmation timeout", err: &timeoutError{message: "Lock command sent (confirmation timeout)"}, want: ExitCodeTimeout},
+		{name: "deadline exceeded", err: fmt.Errorf("failed to confirm lock status: %w", context.DeadlineExceeded), want: ExitCodeTimeout},
+		{name: "request in progress", err: fmt.Errorf("failed to lock doors: %w", api.NewRequestInProgressError()), want: ExitCodeVehicleUnavailable},
+		{name: "engine start limit", err: fmt.Errorf("failed to start engine: %w", api.NewEngineStartLimitError()), want: ExitCodeVehicleUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExitCode(tt.err))
+		})
+	}
+}
+
+// TestWithErrorCodes tests that errors show the server's codes, and
+// that errors without codes are left alone.
+func TestWithErrorCodes(t *testing.T) {
+	t.Parallel()
+	err := withErrorCodes(fmt.Errorf("failed to start engine: %w", api.NewEngineStartLimitError()))
+	require.EqualError(t, err, "failed to start engine: The engine can only be remotely started 2 consecutive times. Please drive the vehicle to reset the counter. (code 920000/400S11)")
+	assert.Equal(t, ExitCodeVehicleUnavailable, ExitCode(err))
+
+	plain := errors.New("boom")
+	assert.Equal(t, plain, withErrorCodes(plain))
+	clientErr := api.NewAPIError(0, "", "Missing encryption key")
+	assert.Equal(t, error(clientErr), withErrorCodes(clientErr))
+}
+
+func TestRootCmd_InsecureWarning(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+
+	for _, insecure := range []bool{false, true} {
+		cfg := testCLIConfig()
+		rootCmd := NewRootCmd(cfg)
+		rootCmd.AddCommand(NewConfigCmd())
+		args := []string{"--config", configPath, "config", "init", "--force"}
+		if insecure {
+			args = append([]string{"--insecure"}, args...)
+		}
+		rootCmd.SetArgs(args)
+
+		var stdout, stderr bytes.Buffer
+		rootCmd.SetOut(&stdout)
+		rootCmd.SetErr(&stderr)
+		require.NoError(t, rootCmd.Execute())
+
+		if insecure {
+			assert.Contains(t, stderr.String(), insecureWarning)
+		} else {
+			assert.NotContains(t, stderr.String(), insecureWarning)
+		}
+	}
+}
+
+// TestRootCmd_Pretty tests that --no-pretty and --pretty=false make --json output compact.
+func TestRootCmd_Pretty(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args []string
+		want outputFormat
+	}{
+		{args: nil, want: outputJSON},
+		{args: []string{"--pretty"}, want: outputJSON},
+		{args: []string{"--pretty=false"}, want: outputJSONCompact},
+		{args: []string{"--no-pretty"}, want: outputJSONCompact},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.args), func(t *testing.T) {
+			t.Parallel()
+			var got outputFormat
+			rootCmd := NewRootCmd(testCLIConfig())
+			rootCmd.AddCommand(&cobra.Command{
+				Use: "probe",
+				Run: func(cmd *cobra.Command, args []string) {
+					got = newOutputFormat(cmd.Context(), true, false)
+				},
+			})
+			rootCmd.SetArgs(append(tt.args, "probe"))
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+			require.NoError(t, rootCmd.Execute())
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("conflicting flags", func(t *testing.T) {
+		t.Parallel()
+		rootCmd := NewRootCmd(testCLIConfig())
+		rootCmd.AddCommand(&cobra.Command{Use: "probe", Run: func(cmd *cobra.Command, args []string) {}})
+		rootCmd.SetArgs([]string{"--pretty", "--no-pretty", "probe"})
+		rootCmd.SetOut(&bytes.Buffer{})
+		rootCmd.SetErr(&bytes.Buffer{})
+		require.Error(t, rootCmd.Execute())
+	})
+}
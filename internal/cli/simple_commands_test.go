@@ -1,9 +1,15 @@
 package cli
 
 import (
+	"context"
+	"io"
+	"path/filepath"
 	"testing"
 
+	"github.com/cv/mcs/internal/api"
 	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestSimpleCommands tests basic properties of simple control commands.
@@ -25,6 +31,8 @@ func TestSimpleCommands(t *testing.T) {
 			t.Parallel()
 			cmd := tt.cmdFactory()
 			assertCommandBasics(t, cmd, tt.expectedUse)
+			assert.NotNil(t, cmd.Flags().Lookup("json"), "expected --json flag")
+			assert.NotNil(t, cmd.Flags().Lookup("json-compact"), "expected --json-compact flag")
 		})
 	}
 }
@@ -50,3 +58,67 @@ func TestSimpleCommands_NoArgs(t *testing.T) {
 		})
 	}
 }
+
+// TestCommandAction tests that the JSON action name is the command path without the root.
+func TestCommandAction(t *testing.T) {
+	t.Parallel()
+	root := &cobra.Command{Use: "mcs"}
+	charge := NewChargeCmd()
+	root.AddCommand(NewLockCmd(), charge)
+
+	lock, _, err := root.Find([]string{"lock"})
+	require.NoError(t, err)
+	assert.Equal(t, "lock", commandAction(lock))
+
+	start, _, err := root.Find([]string{"charge", "start"})
+	require.NoError(t, err)
+	assert.Equal(t, "charge start", commandAction(start))
+}
+
+// TestCommands_RejectOutOfRangeWait tests that wait and retry flags are validated before any client is created.
+func TestCommands_RejectOutOfRangeWait(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		cmdFactory func() *cobra.Command
+		args       []string
+		wantErr    string
+	}{
+		{"lock too long", NewLockCmd, []string{"--confirm-wait", "9000"}, "--confirm-wait must be between 10 and 600 seconds, got 9000"},
+		{"unlock negative", NewUnlockCmd, []string{"--confirm-wait", "-5"}, "--confirm-wait must be between 10 and 600 seconds, got -5"},
+		{"start too short", NewStartCmd, []string{"--confirm-wait", "1"}, "--confirm-wait must be between"},
+		{"climate set", NewClimateCmd, []string{"set", "--temp", "22", "--confirm-wait", "601"}, "--confirm-wait must be between"},
+		{"unlock retry without force", NewUnlockCmd, []string{"--retry", "2"}, "pass --force"},
+		{"lock too many retries", NewLockCmd, []string{"--retry", "9"}, "--retry must be between 0 and 5, got 9"},
+		{"status refresh", NewStatusCmd, []string{"--refresh", "--refresh-wait", "9000"}, "--refresh-wait must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cmd := tt.cmdFactory()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+
+			// A config file that doesn't exist would fail client setup with a different
+			// error, so getting the validation error proves no client was created.
+			ctx := ContextWithConfig(context.Background(), &CLIConfig{ConfigFile: filepath.Join(t.TempDir(), "missing.toml")})
+			err := cmd.ExecuteContext(ctx)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckEngineStartSupported(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, checkEngineStartSupported(VehicleInfo{}))
+	require.NoError(t, checkEngineStartSupported(VehicleInfo{VehicleInfo: api.VehicleInfo{ModelName: "CX-90 PHEV", ModelCode: "KKEH", Powertrain: api.PowertrainPHEV}}))
+	require.EqualError(t,
+		checkEngineStartSupported(VehicleInfo{VehicleInfo: api.VehicleInfo{ModelName: "MX-30 EV", ModelCode: "DR4B", Powertrain: api.PowertrainEV}}),
+		"remote engine start is not supported on this vehicle (MX-30 EV, model code DR4B)")
+	require.EqualError(t,
+		checkEngineStartSupported(VehicleInfo{VehicleInfo: api.VehicleInfo{Powertrain: api.PowertrainEV}}),
+		"remote engine start is not supported on this vehicle (EV)")
+}
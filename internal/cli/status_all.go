@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/cv/mcs/internal/api"
+)
+
+// Bounds for status --concurrency.
+const (
+	defaultVehicleConcurrency = 3
+	maxVehicleConcurrency     = 10
+)
+
+// vehicleResult is the outcome of running a function for one vehicle.
+type vehicleResult[T any] struct {
+	vehicle VehicleInfo
+	value   T
+	err     error
+}
+
+// forEachVehicle calls fn for every vehicle using at most concurrency workers and
+// returns the results in the order of vehicles. Each worker calls fn with its own
+// index, from 0 to concurrency-1, so callers can give every worker its own client.
+// Vehicles not yet started when ctx is done get ctx's error.
+func forEachVehicle[T any](ctx context.Context, vehicles []VehicleInfo, concurrency int, fn func(ctx context.Context, worker int, vehicle VehicleInfo) (T, error)) []vehicleResult[T] {
+	results := make([]vehicleResult[T], len(vehicles))
+	for i, vehicle := range vehicles {
+		results[i].vehicle = vehicle
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for worker := range min(max(concurrency, 1), len(vehicles)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i].value, results[i].err = fn(ctx, worker, vehicles[i])
+			}
+		}()
+	}
+
+	for i := range vehicles {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i].err = ctx.Err()
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// validateConcurrency rejects --concurrency values outside 1 to maxVehicleConcurrency.
+func validateConcurrency(concurrency int) error {
+	if concurrency < 1 || concurrency > maxVehicleConcurrency {
+		return fmt.Errorf("--concurrency must be between 1 and %d, got %d", maxVehicleConcurrency, concurrency)
+	}
+
+	return nil
+}
+
+// runStatusAllVehicles shows the status of every vehicle on the account, fetching
+// up to concurrency vehicles at a time. Each worker uses its own copy of the
+// logged-in client, as a client isn't safe for concurrent use. Vehicles that fail
+// are reported on errOut and make the command fail once all have been tried.
+func runStatusAllVehicles(ctx context.Context, out, errOut io.Writer, concurrency int, strict bool, opts statusDisplayOptions) error {
+	client, vecBaseInfos, err := loginAndListVehicles(ctx)
+	if err != nil {
+		return err
+	}
+	defer saveClientCache(ctx, client)
+
+	vehicles := make([]VehicleInfo, 0, len(vecBaseInfos.VecBaseInfos))
+	for _, info := range vecBaseInfos.VecBaseInfos {
+		vehicles = append(vehicles, VehicleInfo{VehicleInfo: info.Info(), MaskVIN: maskVINFromContext(ctx)})
+	}
+	if len(vehicles) == 0 {
+		return errors.New("no vehicles found")
+	}
+
+	clients := []*api.Client{client}
+	for len(clients) < min(concurrency, len(vehicles)) {
+		workerClient, err := createAPIClient(ctx)
+		if err != nil {
+			return err
+		}
+		workerClient.SetCachedCredentials(client.GetCredentials())
+		workerClient.Keys.FetchedAt = client.Keys.FetchedAt
+		clients = append(clients, workerClient)
+	}
+
+	results := forEachVehicle(ctx, vehicles, concurrency, func(ctx context.Context, worker int, vehicle VehicleInfo) (vehicleStatuses, error) {
+		return fetchVehicleStatuses(ctx, clients[worker], vehicle, strict)
+	})
+
+	return printAllVehicleStatus(out, errOut, results, opts)
+}
+
+// vehicleStatuses holds the two status responses shown by mcs status.
+type vehicleStatuses struct {
+	vehicle *api.VehicleStatusResponse
+	ev      *api.EVVehicleStatusResponse
+}
+
+// fetchVehicleStatuses gets the vehicle and EV status of vehicle. With strict, a
+// status with missing sections is an error.
+func fetchVehicleStatuses(ctx context.Context, client *api.Client, vehicle VehicleInfo, strict bool) (vehicleStatuses, error) {
+	evStatus, err := client.GetEVVehicleStatus(ctx, string(vehicle.InternalVIN))
+	if err != nil {
+		return vehicleStatuses{}, fmt.Errorf("failed to get EV status: %w", err)
+	}
+	vehicleStatus, err := client.GetVehicleStatus(ctx, string(vehicle.InternalVIN))
+	if err != nil {
+		return vehicleStatuses{}, fmt.Errorf("failed to get vehicle status: %w", err)
+	}
+	if strict {
+		if err := checkStatusComplete(vehicleStatus, evStatus); err != nil {
+			return vehicleStatuses{}, err
+		}
+	}
+
+	return vehicleStatuses{vehicle: vehicleStatus, ev: evStatus}, nil
+}
+
+// printAllVehicleStatus prints the status of each vehicle that succeeded, as a JSON
+// array, a table with a row per vehicle, or text blocks separated by blank lines,
+// and reports the failures.
+func printAllVehicleStatus(out, errOut io.Writer, results []vehicleResult[vehicleStatuses], opts statusDisplayOptions) error {
+	var failed int
+	var outputs []string
+	allData := []map[string]any{}
+	rows := [][]string{vehicleTableHeader()}
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			_, _ = fmt.Fprintf(errOut, "Error: %s: %v\n", vehicleLabel(result.vehicle), result.err)
+
+			continue
+		}
+		if opts.format == outputTable {
+			rows = append(rows, vehicleTableRow(result.vehicle, result.value))
+
+			continue
+		}
+		if opts.format.isJSON() {
+			allData = append(allData, buildAllStatusData(result.value.vehicle, result.value.ev, result.vehicle, opts.distanceUnit))
+
+			continue
+		}
+		output, err := displayAllStatus(result.value.vehicle, result.value.ev, result.vehicle, opts)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, output)
+	}
+
+	if opts.format.isJSON() {
+		output, err := toJSON(allData, opts.format)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, output)
+	} else if opts.format == outputTable && len(rows) > 1 {
+		_, _ = fmt.Fprintln(out, formatTable(rows))
+	} else if len(outputs) > 0 {
+		_, _ = fmt.Fprintln(out, strings.Join(outputs, "\n\n"))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to get status for %d of %d vehicles", failed, len(results))
+	}
+
+	return nil
+}
+
+// vehicleTableHeader is the header row of the --output table vehicle table.
+func vehicleTableHeader() []string {
+	return []string{"VIN", "NICKNAME", "MODEL", "YEAR", "BATTERY", "FUEL", "DOORS"}
+}
+
+// vehicleTableRow is a vehicle's row of the --output table vehicle table, with "-"
+// for anything not reported.
+func vehicleTableRow(vehicle VehicleInfo, statuses vehicleStatuses) []string {
+	row := []string{vehicle.displayVIN(), vehicle.Nickname, vehicle.ModelName, vehicle.ModelYear, "-", "-", "-"}
+	for i, cell := range row[:4] {
+		if cell == "" {
+			row[i] = "-"
+		}
+	}
+	if batteryInfo, err := statuses.ev.GetBatteryInfo(); err == nil {
+		row[4] = fmt.Sprintf("%.0f%%", batteryInfo.BatteryLevel)
+	}
+	if fuelInfo, err := statuses.vehicle.GetFuelInfo(); err == nil {
+		row[5] = fmt.Sprintf("%.0f%%", fuelInfo.FuelLevel)
+	}
+	if doorStatus, err := statuses.vehicle.GetDoorsInfo(); err == nil {
+		row[6] = "unlocked"
+		if doorStatus.AllLocked {
+			row[6] = "locked"
+		}
+	}
+
+	return row
+}
+
+// vehicleLabel names a vehicle in messages by VIN, or internal VIN if that's unknown.
+func vehicleLabel(vehicle VehicleInfo) string {
+	if vehicle.VIN != "" {
+		return vehicle.displayVIN()
+	}
+
+	return string(vehicle.InternalVIN)
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestForEachVehicle tests that every vehicle is processed, in order, without
+// exceeding the concurrency cap.
+func TestForEachVehicle(t *testing.T) {
+	t.Parallel()
+	vehicles := make([]VehicleInfo, 10)
+	for i := range vehicles {
+		vehicles[i] = VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: fmt.Sprintf("VIN%d", i)}}
+	}
+
+	var inFlight, maxInFlight atomic.Int32
+	results := forEachVehicle(context.Background(), vehicles, 3, func(ctx context.Context, worker int, vehicle VehicleInfo) (string, error) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if current <= seen || maxInFlight.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		assert.Less(t, worker, 3)
+		time.Sleep(5 * time.Millisecond)
+
+		if vehicle.VIN == "VIN4" {
+			return "", errors.New("vehicle offline")
+		}
+
+		return "status of " + vehicle.VIN, nil
+	})
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(3))
+	require.Len(t, results, len(vehicles))
+	for i, result := range results {
+		assert.Equal(t, vehicles[i], result.vehicle)
+		if i == 4 {
+			require.EqualError(t, result.err, "vehicle offline")
+
+			continue
+		}
+		require.NoError(t, result.err)
+		assert.Equal(t, "status of "+vehicles[i].VIN, result.value)
+	}
+}
+
+// TestForEachVehicle_Canceled tests that vehicles not started before cancellation get the context error.
+func TestForEachVehicle_Canceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := forEachVehicle(ctx, []VehicleInfo{{VehicleInfo: api.VehicleInfo{VIN: "VIN0"}}, {VehicleInfo: api.VehicleInfo{VIN: "VIN1"}}}, 1, func(ctx context.Context, worker int, vehicle VehicleInfo) (string, error) {
+		return "", ctx.Err()
+	})
+
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.ErrorIs(t, result.err, context.Canceled)
+	}
+}
+
+func TestValidateConcurrency(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateConcurrency(1))
+	require.NoError(t, validateConcurrency(maxVehicleConcurrency))
+	require.EqualError(t, validateConcurrency(0), "--concurrency must be between 1 and 10, got 0")
+	require.Error(t, validateConcurrency(maxVehicleConcurrency+1))
+}
+
+// TestPrintAllVehicleStatus tests that successes are printed and failures reported.
+func TestPrintAllVehicleStatus(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	statuses := vehicleStatuses{vehicle: apitest.NewVehicleStatus().Build(), ev: apitest.NewEVVehicleStatus().Build()}
+	results := []vehicleResult[vehicleStatuses]{
+		{vehicle: VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0000001", ModelName: "CX-90 PHEV"}}, value: statuses},
+		{vehicle: VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: api.InternalVIN("INTERNAL2")}}, err: errors.New("vehicle offline")},
+		{vehicle: VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0000003", ModelName: "CX-70 PHEV"}}, value: statuses},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		err := printAllVehicleStatus(&out, &errOut, results, statusDisplayOptions{format: outputText})
+		require.EqualError(t, err, "failed to get status for 1 of 3 vehicles")
+
+		assert.Contains(t, out.String(), "JM3KKEHC1R0000001")
+		assert.Contains(t, out.String(), "JM3KKEHC1R0000003")
+		assert.Less(t, bytes.Index(out.Bytes(), []byte("0000001")), bytes.Index(out.Bytes(), []byte("0000003")))
+		assert.Equal(t, "Error: INTERNAL2: vehicle offline\n", errOut.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		err := printAllVehicleStatus(&out, &errOut, results[:1], statusDisplayOptions{format: outputJSON})
+		require.NoError(t, err)
+
+		assert.Contains(t, out.String(), `"vin": "JM3KKEHC1R0000001"`)
+		assert.Equal(t, byte('['), out.Bytes()[0])
+		assert.Empty(t, errOut.String())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		tableResults := []vehicleResult[vehicleStatuses]{
+			results[0],
+			{vehicle: VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0000003", Nickname: "Family Car", ModelName: "CX-70 PHEV", ModelYear: "2025"}}, value: statuses},
+		}
+		err := printAllVehicleStatus(&out, &errOut, tableResults, statusDisplayOptions{format: outputTable})
+		require.NoError(t, err)
+
+		want := "VIN                NICKNAME    MODEL       YEAR  BATTERY  FUEL  DOORS\n" +
+			"JM3KKEHC1R0000001  -           CX-90 PHEV  -     80%      0%    unlocked\n" +
+			"JM3KKEHC1R0000003  Family Car  CX-70 PHEV  2025  80%      0%    unlocked\n"
+		assert.Equal(t, want, out.String())
+		assert.Empty(t, errOut.String())
+	})
+}
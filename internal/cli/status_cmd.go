@@ -2,18 +2,20 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/geocode"
 	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
 )
 
 // NewStatusCmd creates the status command.
 func NewStatusCmd() *cobra.Command {
-	var jsonOutput bool
-	var refresh bool
-	var refreshWait int
+	var opts statusOptions
 
 	statusCmd := &cobra.Command{
 		Use:   "status",
@@ -39,24 +41,735 @@ func NewStatusCmd() *cobra.Command {
   mcs status --json
 
   # Request fresh status from vehicle (PHEV/EV only, waits up to 90 seconds)
-  mcs status --refresh`,
+  mcs status --refresh
+
+  # Same, but exit non-zero rather than show stale status (for automation)
+  mcs status --wait-fresh
+
+  # Only print status when the vehicle reported something new (for cron jobs)
+  mcs status --only-if-changed --fail-if-unchanged
+
+  # One-line summary, e.g. "CX-90 PHEV: 80% battery (plugged, charging), all doors locked, parked."
+  mcs status --summary
+
+  # Include trim, color, and transmission in the header
+  mcs status --verbose
+
+  # Print only the status lines, e.g. to pipe into grep
+  mcs status --no-header
+
+  # Show the status timestamp in ISO 8601 (RFC3339) form
+  mcs status --timestamp-format iso8601
+
+  # Fail rather than show zeros for sections the vehicle didn't report
+  mcs status --strict
+
+  # Fail if the status is more than an hour old
+  mcs status --max-age 1h
+
+  # Show it anyway, marked [STALE] (or "stale": true in JSON), for dashboards
+  mcs status --max-age 1h --acknowledge-stale
+
+  # Refresh and print the status every 10 minutes, highlighting what changed
+  mcs status --refresh --watch --watch-interval 600
+
+  # Keep watching after a status that was just shown, starting one interval later
+  mcs status && mcs status --watch --no-initial-fetch
+
+  # Show what changed (odometer, battery, fuel, distance moved) since the last check
+  mcs status --diff-previous
+
+  # Show every vehicle on the account, fetching two at a time
+  mcs status --all-vehicles --concurrency 2
+
+  # Show every vehicle as one row of a table
+  mcs status --all-vehicles --output table
+
+  # Render the status with a template; fields match the --json output
+  mcs status --template-file status.html
+
+  # Show battery status and estimated battery health
+  mcs status battery --health`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus(cmd, jsonOutput, refresh, refreshWait)
+			return runStatus(cmd, opts)
 		},
 		SilenceUsage: true,
 	}
 
 	// Add flags
-	statusCmd.Flags().BoolVar(&jsonOutput, "json", false, "output in JSON format")
-	statusCmd.Flags().BoolVarP(&refresh, "refresh", "r", false, "request fresh status from vehicle (PHEV/EV only)")
-	statusCmd.Flags().IntVar(&refreshWait, "refresh-wait", 90, "max seconds to wait for vehicle response")
+	addJSONFlags(statusCmd, &opts.jsonOutput, &opts.jsonCompact)
+	statusCmd.Flags().BoolVarP(&opts.refresh, "refresh", "r", false, "request fresh status from vehicle (PHEV/EV only)")
+	statusCmd.Flags().BoolVar(&opts.waitFresh, "wait-fresh", false, "like --refresh, but fail instead of showing stale status if the vehicle doesn't respond")
+	statusCmd.Flags().IntVar(&opts.refreshWait, "refresh-wait", 90, "max seconds to wait for vehicle response")
+	statusCmd.Flags().BoolVar(&opts.retryOnInProgress, "retry-on-in-progress", false, "with --refresh, wait for a refresh that's already running instead of failing")
+	statusCmd.Flags().BoolVar(&opts.verbose, "verbose", false, "show trim, color, and transmission in the vehicle header")
+	statusCmd.Flags().IntVar(&opts.barWidth, "bar-width", defaultBarWidth, "number of segments in the battery level bar")
+	statusCmd.Flags().StringVar(&opts.timestampFormat, "timestamp-format", timestampFormatDefault, "timestamp style for text output: default or iso8601")
+	statusCmd.Flags().BoolVar(&opts.onlyIfChanged, "only-if-changed", false, "skip output if status hasn't changed since the last check")
+	statusCmd.Flags().BoolVar(&opts.failIfUnchanged, "fail-if-unchanged", false, "with --only-if-changed, exit non-zero when nothing changed")
+	statusCmd.Flags().BoolVar(&opts.summary, "summary", false, "print a one-line summary sentence (e.g. for a cron email subject)")
+	statusCmd.Flags().BoolVar(&opts.noHeader, "no-header", false, "omit the vehicle header and timestamps, printing only the status lines")
+	statusCmd.Flags().StringVar(&opts.templateFile, "template-file", "", "render the status with a Go template file (.html/.htm files are HTML-escaped)")
+	statusCmd.Flags().BoolVar(&opts.strict, "strict", false, "fail, listing the missing sections, instead of showing partial status")
+	statusCmd.Flags().DurationVar(&opts.maxAge, "max-age", 0, "fail if the status is older than this, e.g. 1h (0 means no limit)")
+	statusCmd.Flags().BoolVar(&opts.acknowledgeStale, "acknowledge-stale", false, "with --max-age, show old status marked as stale instead of failing")
+	statusCmd.Flags().BoolVar(&opts.allVehicles, "all-vehicles", false, "show the status of every vehicle on the account")
+	statusCmd.Flags().IntVar(&opts.concurrency, "concurrency", defaultVehicleConcurrency, "with --all-vehicles, max vehicles to fetch at once")
+	statusCmd.Flags().BoolVar(&opts.explain, "explain", false, "print the API calls the command would make instead of running it")
+	statusCmd.Flags().BoolVar(&opts.watch, "watch", false, "print the status every --watch-interval until interrupted, highlighting changes")
+	statusCmd.Flags().IntVar(&opts.watchInterval, "watch-interval", defaultWatchInterval, "with --watch, seconds between status checks")
+	statusCmd.Flags().BoolVar(&opts.noInitialFetch, "no-initial-fetch", false, "with --watch, wait one interval before the first status check")
+	addOutputFlag(statusCmd, &opts.output)
+	statusCmd.Flags().BoolVar(&opts.diffPrevious, "diff-previous", false, "show what changed since the last --diff-previous check of this vehicle")
+	// The full status has no single value to print; --plain is only accepted here
+	// to point at the subcommands that have one.
+	statusCmd.Flags().BoolVar(&opts.plain, "plain", false, "print only the value (battery, fuel, and odometer subcommands only)")
+	_ = statusCmd.Flags().MarkHidden("plain")
+	// A troubleshooting aid for reporting vehicles whose door or window codes are
+	// misread, rather than a regular display option.
+	statusCmd.Flags().BoolVar(&opts.showRawFlags, "show-raw-flags", false, "also print the raw door, lock, window, and hazard codes from the API")
+	_ = statusCmd.Flags().MarkHidden("show-raw-flags")
+	statusCmd.MarkFlagsMutuallyExclusive("summary", "json")
+	statusCmd.MarkFlagsMutuallyExclusive("summary", "json-compact")
+	statusCmd.MarkFlagsMutuallyExclusive("template-file", "summary", "json", "json-compact")
+	for _, flag := range []string{"refresh", "wait-fresh", "only-if-changed", "summary", "template-file", "max-age"} {
+		statusCmd.MarkFlagsMutuallyExclusive("all-vehicles", flag)
+	}
+	for _, flag := range []string{"json", "json-compact", "summary", "template-file"} {
+		statusCmd.MarkFlagsMutuallyExclusive("output", flag)
+	}
+	for _, flag := range []string{"all-vehicles", "summary", "template-file", "watch"} {
+		statusCmd.MarkFlagsMutuallyExclusive("diff-previous", flag)
+	}
+	for _, flag := range []string{"json", "json-compact", "summary", "template-file", "only-if-changed", "all-vehicles", "strict", "max-age", "explain"} {
+		statusCmd.MarkFlagsMutuallyExclusive("watch", flag)
+	}
+
+	statusCmd.AddCommand(newStatusBatteryCmd())
+	statusCmd.AddCommand(newStatusWindowsCmd())
+	statusCmd.AddCommand(newStatusFuelCmd())
+	statusCmd.AddCommand(newStatusTiresCmd())
+	statusCmd.AddCommand(newStatusOdometerCmd())
+	statusCmd.AddCommand(newStatusLocationCmd())
 
 	return statusCmd
 }
 
+// newStatusBatteryCmd creates the status battery subcommand.
+func newStatusBatteryCmd() *cobra.Command {
+	var jsonOutput, jsonCompact, plain bool
+	var health bool
+	var barWidth int
+
+	cmd := &cobra.Command{
+		Use:   "battery",
+		Short: "Show battery status",
+		Long:  `Show high-voltage battery level, range, and charging state (PHEV/EV only).`,
+		Example: `  # Show battery status
+  mcs status battery
+
+  # Show estimated battery state of health
+  mcs status battery --health
+
+  # Print just the charge level, e.g. 80
+  mcs status battery --plain`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateBarWidth(barWidth); err != nil {
+				return err
+			}
+			unit, err := distanceUnitFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			locale, err := localeFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				evStatus, err := client.GetEVVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get EV status: %w", err)
+				}
+
+				format := newOutputFormat(cmd.Context(), jsonOutput, jsonCompact)
+				if plain {
+					format = outputPlain
+				}
+				var output string
+				if health {
+					output, err = formatBatteryHealth(evStatus, format)
+				} else {
+					var batteryInfo api.BatteryInfo
+					batteryInfo, err = evStatus.GetBatteryInfo()
+					if err != nil {
+						return fmt.Errorf("failed to get battery info: %w", err)
+					}
+					output, err = formatBatteryStatus(batteryInfo, format, barWidth, unit, locale)
+				}
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
+
+				return nil
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().BoolVar(&health, "health", false, "show estimated battery state of health")
+	cmd.Flags().IntVar(&barWidth, "bar-width", defaultBarWidth, "number of segments in the battery level bar")
+	addPlainFlag(cmd, &plain)
+	cmd.MarkFlagsMutuallyExclusive("plain", "health")
+
+	return cmd
+}
+
+// newStatusWindowsCmd creates the status windows subcommand.
+func newStatusWindowsCmd() *cobra.Command {
+	var jsonOutput, jsonCompact bool
+	var check bool
+
+	cmd := &cobra.Command{
+		Use:   "windows",
+		Short: "Show window positions",
+		Long:  `Show the position of each window. With --check, exit non-zero if any window is open.`,
+		Example: `  # Show window positions
+  mcs status windows
+
+  # Fail if any window is open (e.g. before rain)
+  mcs status windows --check || echo "Close your windows!"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+				if !vehicleInfo.capabilities().WindowStatus {
+					return fmt.Errorf("window positions are not reported by this vehicle (%s)", vehicleDescription(vehicleInfo))
+				}
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(vehicleInfo.InternalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+
+				return runStatusWindows(cmd.OutOrStdout(), vehicleStatus, newOutputFormat(cmd.Context(), jsonOutput, jsonCompact), check)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().BoolVar(&check, "check", false, "exit non-zero if any window is open")
+
+	return cmd
+}
+
+// newStatusFuelCmd creates the status fuel subcommand.
+func newStatusFuelCmd() *cobra.Command {
+	var jsonOutput, jsonCompact, plain bool
+	var opts fuelEstimateOptions
+
+	cmd := &cobra.Command{
+		Use:   "fuel",
+		Short: "Show fuel level and range",
+		Long: `Show fuel level and range. Optionally flag low fuel and estimate how much
+it takes to fill the tank. Estimates are computed locally from the fuel level.`,
+		Example: `  # Show fuel status
+  mcs status fuel
+
+  # Flag fuel at or below 15%
+  mcs status fuel --fuel-warn 15
+
+  # Estimate liters and cost to fill a 56 L tank at 1.85 per liter
+  mcs status fuel --tank-size 56 --fuel-price 1.85
+
+  # Print just the fuel level, e.g. 75
+  mcs status fuel --plain`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := opts.validate(); err != nil {
+				return err
+			}
+			unit, err := distanceUnitFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			locale, err := localeFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+				fuelInfo, err := vehicleStatus.GetFuelInfo()
+				if err != nil {
+					return fmt.Errorf("failed to get fuel info: %w", err)
+				}
+
+				format := newOutputFormat(cmd.Context(), jsonOutput, jsonCompact)
+				if plain {
+					format = outputPlain
+				}
+				output, err := formatFuelReport(fuelInfo, format, unit, locale, opts)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
+
+				return nil
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().Float64Var(&opts.warnLevel, "fuel-warn", 0, "flag low fuel at or below this percent (0 disables)")
+	cmd.Flags().Float64Var(&opts.tankLiters, "tank-size", 0, "tank capacity in liters, to estimate liters to fill")
+	cmd.Flags().Float64Var(&opts.pricePerLiter, "fuel-price", 0, "fuel price per liter, to estimate cost to fill (requires --tank-size)")
+	addPlainFlag(cmd, &plain)
+
+	return cmd
+}
+
+// newStatusTiresCmd creates the status tires subcommand.
+func newStatusTiresCmd() *cobra.Command {
+	var jsonOutput, jsonCompact bool
+	var output string
+	var targets tirePressureTargets
+	var save bool
+
+	cmd := &cobra.Command{
+		Use:   "tires",
+		Short: "Show tire pressures",
+		Long: `Show tire pressures, and temperatures where the vehicle reports them.
+Optionally compare them with the recommended pressures from the door jamb label.
+
+With --compare-recommended-tire, the recommended pressures are saved for this
+vehicle and compared against on every later run, without passing them again.
+Without --front-psi or --rear-psi, --compare-recommended-tire asks for them.`,
+		Example: `  # Show tire pressures
+  mcs status tires
+
+  # Show how far each tire is from the recommended 36 PSI front, 33 PSI rear
+  mcs status tires --front-psi 36 --rear-psi 33
+
+  # Same, and remember them for this vehicle so plain "mcs status tires" compares too
+  mcs status tires --front-psi 36 --rear-psi 33 --compare-recommended-tire
+
+  # Be asked for the recommended pressures to save
+  mcs status tires --compare-recommended-tire
+
+  # Show the tires as a 2x2 grid, laid out as they sit on the car
+  mcs status tires --output table`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := targets.validate(); err != nil {
+				return err
+			}
+			format, err := applyOutputMode(newOutputFormat(cmd.Context(), jsonOutput, jsonCompact), output)
+			if err != nil {
+				return err
+			}
+			tempUnit, err := temperatureUnitFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			// Ask before logging in, so a bad answer doesn't cost a login. An empty
+			// answer leaves that pressure at its saved value, filled in below.
+			if save && !cmd.Flags().Changed("front-psi") && !cmd.Flags().Changed("rear-psi") {
+				if !isTerminalInput(cmd.InOrStdin()) {
+					return errors.New("--compare-recommended-tire needs --front-psi or --rear-psi when not run interactively")
+				}
+				if targets, err = promptTireTargets(cmd.InOrStdin(), cmd.ErrOrStderr()); err != nil {
+					return err
+				}
+			}
+
+			return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+				key := statusStateKey(vehicleInfo)
+				targets, err := savedTireTargets(ctx, key, targets)
+				if err != nil {
+					return err
+				}
+				if save {
+					if err := saveTireTargets(ctx, key, targets); err != nil {
+						return err
+					}
+					_, _ = fmt.Fprintf(infoWriter(ctx, cmd.ErrOrStderr()), "Saved recommended tire pressures for %s: %s\n", vehicleInfo.displayVIN(), formatTireTargets(targets))
+				}
+
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(vehicleInfo.InternalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+				tireInfo, err := vehicleStatus.GetTiresInfo()
+				if err != nil {
+					return fmt.Errorf("failed to get tire info: %w", err)
+				}
+
+				report, err := formatTiresReport(tireInfo, format, tempUnit, targets)
+				if err != nil {
+					return err
+				}
+				_, _ = fmt.Fprintln(cmd.OutOrStdout(), report)
+
+				return nil
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	addOutputFlag(cmd, &output)
+	cmd.Flags().Float64Var(&targets.frontPSI, "front-psi", 0, "recommended front tire pressure, to show each front tire's difference from it")
+	cmd.Flags().Float64Var(&targets.rearPSI, "rear-psi", 0, "recommended rear tire pressure, to show each rear tire's difference from it")
+	cmd.Flags().BoolVar(&save, "compare-recommended-tire", false, "save the recommended pressures for this vehicle, to compare against on later runs (asks if not given)")
+	cmd.MarkFlagsMutuallyExclusive("output", "json")
+	cmd.MarkFlagsMutuallyExclusive("output", "json-compact")
+
+	return cmd
+}
+
+// newStatusOdometerCmd creates the status odometer subcommand.
+func newStatusOdometerCmd() *cobra.Command {
+	var jsonOutput, jsonCompact, plain bool
+	var trip bool
+
+	cmd := &cobra.Command{
+		Use:   "odometer",
+		Short: "Show the odometer reading",
+		Long: `Show the odometer reading. With --trip, also show the trip meter (distance,
+driving time, fuel used, and average economy) for vehicles that report one.
+JSON output includes a "trip" object whenever the vehicle reports trip data.`,
+		Example: `  # Show the odometer
+  mcs status odometer
+
+  # Include the trip meter
+  mcs status odometer --trip
+
+  # Print just the reading in the configured unit, e.g. 12345.6
+  mcs status odometer --plain`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			unit, err := distanceUnitFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+			locale, err := localeFromContext(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+
+				format := newOutputFormat(cmd.Context(), jsonOutput, jsonCompact)
+				if plain {
+					format = outputPlain
+				}
+
+				return runStatusOdometer(cmd.OutOrStdout(), vehicleStatus, format, unit, locale, trip)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().BoolVar(&trip, "trip", false, "also show the trip meter, if the vehicle reports one")
+	addPlainFlag(cmd, &plain)
+	cmd.MarkFlagsMutuallyExclusive("plain", "trip")
+
+	return cmd
+}
+
+// newStatusLocationCmd creates the status location subcommand.
+func newStatusLocationCmd() *cobra.Command {
+	var jsonOutput, jsonCompact bool
+	var address, pollMoving bool
+	var geocoderURL string
+	var nearThresholdMeters float64
+	var movingIntervalSeconds int
+
+	cmd := &cobra.Command{
+		Use:   "location",
+		Short: "Show the vehicle's location",
+		Long: `Show the vehicle's last reported coordinates, heading and speed, and a map link.
+
+With --address, also look up the street address of the coordinates with a
+Nominatim reverse geocoder (the public OpenStreetMap server by default). This
+sends the coordinates to that server, so it only happens when asked for. Each
+lookup is limited to a few seconds, and addresses are cached so that a parked
+vehicle is looked up only once. If the lookup fails, the coordinates are still shown.
+
+With --poll-moving, read the location twice, 10 seconds apart (see
+--poll-moving-interval), and print whether the vehicle appears to be moving,
+with its speed estimated from the distance between the two positions (e.g.
+"appears to be moving (~45 km/h)"), or "stationary". A parked vehicle doesn't
+report new positions, so a second read that returns the same position is shown
+as "stationary (no new position since <time>)".
+
+When places are saved with mcs location save, also show whether the vehicle is
+at one of them (within --near-threshold), e.g. "at home", or how far it is from
+the nearest, e.g. "2.3 km from work".`,
+		Example: `  # Show the location
+  mcs status location
+
+  # Include the street address
+  mcs status location --address
+
+  # Use your own Nominatim server
+  mcs status location --address --geocoder-url https://nominatim.example.com
+
+  # Tell whether the vehicle is moving
+  mcs status location --poll-moving
+
+  # Wait a minute between the two reads
+  mcs status location --poll-moving --poll-moving-interval 60`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("poll-moving-interval") && !pollMoving {
+				return errors.New("--poll-moving-interval requires --poll-moving")
+			}
+			if err := validateWaitSeconds("poll-moving-interval", movingIntervalSeconds); err != nil {
+				return err
+			}
+			if nearThresholdMeters <= 0 {
+				return fmt.Errorf("--near-threshold must be greater than 0, got %g", nearThresholdMeters)
+			}
+			if cfg := ConfigFromContext(cmd.Context()); cfg != nil {
+				geocoderURL = resolveSetting(geocoderURL, cmd.Flags().Changed("geocoder-url"), cfg.GeocoderURL, geocode.DefaultNominatimURL)
+			}
+
+			return withVehicleClient(cmd.Context(), func(ctx context.Context, client *api.Client, internalVIN api.InternalVIN) error {
+				if pollMoving {
+					return runPollMoving(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), &clientAdapter{client}, internalVIN, newOutputFormat(cmd.Context(), jsonOutput, jsonCompact), time.Duration(movingIntervalSeconds)*time.Second)
+				}
+
+				vehicleStatus, err := client.GetVehicleStatus(ctx, string(internalVIN))
+				if err != nil {
+					return fmt.Errorf("failed to get vehicle status: %w", err)
+				}
+
+				return runStatusLocation(ctx, cmd.OutOrStdout(), cmd.ErrOrStderr(), vehicleStatus, newOutputFormat(cmd.Context(), jsonOutput, jsonCompact), address, geocoderURL, nearThresholdMeters/1000)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	addJSONFlags(cmd, &jsonOutput, &jsonCompact)
+	cmd.Flags().BoolVar(&address, "address", false, "look up the street address of the coordinates (sends them to the geocoder)")
+	cmd.Flags().StringVar(&geocoderURL, "geocoder-url", geocode.DefaultNominatimURL, "Nominatim-compatible server used by --address")
+	cmd.Flags().BoolVar(&pollMoving, "poll-moving", false, "read the location twice, --poll-moving-interval apart, and print whether the vehicle appears to be moving")
+	cmd.Flags().IntVar(&movingIntervalSeconds, "poll-moving-interval", defaultMovingSampleSeconds, "with --poll-moving, seconds between the two reads")
+	cmd.Flags().Float64Var(&nearThresholdMeters, "near-threshold", defaultNearThresholdMeters, "meters from a saved place within which the vehicle is shown as at it")
+	cmd.MarkFlagsMutuallyExclusive("poll-moving", "address")
+	cmd.MarkFlagsMutuallyExclusive("poll-moving", "near-threshold")
+
+	return cmd
+}
+
+// runStatusLocation prints the location, the nearest saved place, and, when
+// address is set, its street address. A failed lookup or unreadable places file
+// is reported on errOut and leaves that line out.
+func runStatusLocation(ctx context.Context, out, errOut io.Writer, vehicleStatus *api.VehicleStatusResponse, format outputFormat, address bool, geocoderURL string, nearThresholdKm float64) error {
+	locationInfo, err := vehicleStatus.GetLocationInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get location info: %w", err)
+	}
+
+	streetAddress := ""
+	if address {
+		streetAddress, err = lookupAddress(ctx, geocoderURL, locationInfo.Latitude, locationInfo.Longitude)
+		if err != nil {
+			_, _ = fmt.Fprintf(errOut, "Warning: address lookup failed: %v\n", err)
+		}
+	}
+
+	place, err := nearestSavedPlace(ctx, locationInfo, nearThresholdKm)
+	if err != nil {
+		_, _ = fmt.Fprintf(errOut, "Warning: %v\n", err)
+	}
+
+	output, err := formatLocationStatus(locationInfo, format, streetAddress, place)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	return nil
+}
+
+// runStatusOdometer prints the odometer and, when trip is set, the trip meter.
+func runStatusOdometer(out io.Writer, vehicleStatus *api.VehicleStatusResponse, format outputFormat, unit distanceUnit, locale language.Tag, trip bool) error {
+	odometerInfo, err := vehicleStatus.GetOdometerInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get odometer info: %w", err)
+	}
+
+	output, err := formatOdometerStatus(odometerInfo, format, unit, locale)
+	if err != nil {
+		return err
+	}
+	if trip && !format.isJSON() {
+		output += "\n" + formatTripStatus(odometerInfo.Trip, unit, locale)
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	return nil
+}
+
+// runStatusWindows prints window status and, when check is set, fails if any window is open.
+func runStatusWindows(out io.Writer, vehicleStatus *api.VehicleStatusResponse, format outputFormat, check bool) error {
+	windowsInfo, err := vehicleStatus.GetWindowsInfo()
+	if err != nil {
+		return fmt.Errorf("failed to get windows info: %w", err)
+	}
+
+	output, err := formatWindowsStatus(windowsInfo, format)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	if check && windowsInfo.AnyOpen() {
+		return fmt.Errorf("%d window(s) open", windowsInfo.OpenCount())
+	}
+
+	return nil
+}
+
+// validateBarWidth rejects --bar-width values outside 1 to maxBarWidth.
+func validateBarWidth(width int) error {
+	if width < 1 || width > maxBarWidth {
+		return fmt.Errorf("--bar-width must be between 1 and %d, got %d", maxBarWidth, width)
+	}
+
+	return nil
+}
+
+// statusOptions holds the flag values for the status command.
+type statusOptions struct {
+	jsonOutput        bool
+	jsonCompact       bool
+	refresh           bool
+	waitFresh         bool
+	refreshWait       int
+	retryOnInProgress bool
+	onlyIfChanged     bool
+	failIfUnchanged   bool
+	summary           bool
+	verbose           bool
+	noHeader          bool
+	barWidth          int
+	timestampFormat   string
+	templateFile      string
+	strict            bool
+	maxAge            time.Duration
+	acknowledgeStale  bool
+	allVehicles       bool
+	concurrency       int
+	explain           bool
+	watch             bool
+	watchInterval     int
+	noInitialFetch    bool
+	output            string
+	diffPrevious      bool
+	plain             bool
+	showRawFlags      bool
+}
+
 // runStatus executes the status command.
-func runStatus(cmd *cobra.Command, jsonOutput bool, refresh bool, refreshWait int) error {
+func runStatus(cmd *cobra.Command, opts statusOptions) error {
+	if opts.plain {
+		return errors.New("--plain prints a single value, so it needs a subcommand such as mcs status battery --plain; use --json to script against the full status")
+	}
+	if err := validateWaitSeconds("refresh-wait", opts.refreshWait); err != nil {
+		return err
+	}
+	if err := validateBarWidth(opts.barWidth); err != nil {
+		return err
+	}
+	if err := validateTimestampFormat(opts.timestampFormat); err != nil {
+		return err
+	}
+	if err := validateMaxAge(opts.maxAge, opts.acknowledgeStale); err != nil {
+		return err
+	}
+	if opts.noInitialFetch && !opts.watch {
+		return errors.New("--no-initial-fetch requires --watch")
+	}
+	if opts.watch {
+		if err := validateWaitSeconds("watch-interval", opts.watchInterval); err != nil {
+			return err
+		}
+		opts.watchInterval = clampPollInterval(cmd.ErrOrStderr(), "watch-interval", opts.watchInterval, opts.refresh || opts.waitFresh)
+	}
+	unit, err := distanceUnitFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	tempUnit, err := temperatureUnitFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	locale, err := localeFromContext(cmd.Context())
+	if err != nil {
+		return err
+	}
+	th, err := themeFromContext(cmd.Context(), cmd.OutOrStdout())
+	if err != nil {
+		return err
+	}
+	// Parse the template up front so a typo fails before any API calls.
+	var tmpl statusTemplate
+	if opts.templateFile != "" {
+		if tmpl, err = loadStatusTemplate(opts.templateFile); err != nil {
+			return err
+		}
+	}
+
+	format, err := applyOutputMode(newOutputFormat(cmd.Context(), opts.jsonOutput, opts.jsonCompact), opts.output)
+	if err != nil {
+		return err
+	}
+	if format == outputTable && !opts.allVehicles {
+		return errors.New("--output table requires --all-vehicles")
+	}
+
+	displayOpts := statusDisplayOptions{
+		format:          format,
+		verbose:         opts.verbose,
+		noHeader:        opts.noHeader,
+		barWidth:        opts.barWidth,
+		timestampFormat: opts.timestampFormat,
+		distanceUnit:    unit,
+		tempUnit:        tempUnit,
+		locale:          locale,
+		theme:           th,
+		showRawFlags:    opts.showRawFlags,
+	}
+
+	if opts.allVehicles {
+		if err := validateConcurrency(opts.concurrency); err != nil {
+			return err
+		}
+	}
+	if opts.explain {
+		printPlan(cmd.OutOrStdout(), statusPlan(opts))
+
+		return nil
+	}
+
+	if opts.allVehicles {
+		return runStatusAllVehicles(cmd.Context(), cmd.OutOrStdout(), cmd.ErrOrStderr(), opts.concurrency, opts.strict, displayOpts)
+	}
+
 	return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+		if opts.watch {
+			return runStatusWatch(ctx, cmd, &clientAdapter{Client: client}, vehicleInfo, opts, displayOpts, time.Duration(opts.watchInterval)*time.Second)
+		}
+
 		// Get initial EV status (needed for refresh comparison and final display)
 		evStatus, err := client.GetEVVehicleStatus(ctx, string(vehicleInfo.InternalVIN))
 		if err != nil {
@@ -64,8 +777,8 @@ func runStatus(cmd *cobra.Command, jsonOutput bool, refresh bool, refreshWait in
 		}
 
 		// If refresh requested, trigger status refresh and poll until timestamp changes
-		if refresh {
-			evStatus, err = refreshAndWaitForStatus(ctx, cmd, client, vehicleInfo.InternalVIN, evStatus, refreshWait)
+		if opts.refresh || opts.waitFresh {
+			evStatus, err = refreshAndWaitForStatus(ctx, infoWriter(ctx, cmd.OutOrStdout()), commandAction(cmd), &clientAdapter{Client: client}, vehicleInfo, evStatus, opts.refreshWaitOptions())
 			if err != nil {
 				return err
 			}
@@ -77,8 +790,66 @@ func runStatus(cmd *cobra.Command, jsonOutput bool, refresh bool, refreshWait in
 			return fmt.Errorf("failed to get vehicle status: %w", err)
 		}
 
+		if opts.strict {
+			if err := checkStatusComplete(vehicleStatus, evStatus); err != nil {
+				return err
+			}
+		}
+
+		if opts.maxAge > 0 {
+			stale, err := checkStatusAge(vehicleStatus, evStatus, opts.maxAge, opts.acknowledgeStale, time.Now())
+			if err != nil {
+				return err
+			}
+			displayOpts.stale = stale
+		}
+
+		if opts.onlyIfChanged {
+			current := statusStateFor(vehicleStatus, evStatus)
+			changed, err := recordStatusState(ctx, statusStateKey(vehicleInfo), current)
+			if err != nil {
+				return err
+			}
+			if !changed {
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "No change since last check (status as of %s)\n", formatTimestampStyle(current.OccurrenceDate, opts.timestampFormat))
+				if opts.failIfUnchanged {
+					return errStatusUnchanged
+				}
+
+				return nil
+			}
+		}
+
+		if opts.diffPrevious {
+			change, err := recordStatusSnapshot(ctx, statusStateKey(vehicleInfo), statusSnapshotFor(vehicleStatus, evStatus, time.Now()))
+			if err != nil {
+				return err
+			}
+			displayOpts.sincePrevious = &change
+		}
+
+		if opts.summary {
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), staleMarker(displayOpts.stale)+formatStatusSummary(vehicleStatus, evStatus, vehicleInfo, maxSummaryLength))
+
+			return nil
+		}
+
+		if tmpl != nil {
+			data := buildAllStatusData(vehicleStatus, evStatus, vehicleInfo, unit)
+			if displayOpts.stale {
+				data["stale"] = true
+			}
+			output, err := renderStatusTemplate(tmpl, data)
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprint(cmd.OutOrStdout(), output)
+
+			return nil
+		}
+
 		// Display status
-		output, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, jsonOutput)
+		output, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, displayOpts)
 		if err != nil {
 			return err
 		}
@@ -88,25 +859,132 @@ func runStatus(cmd *cobra.Command, jsonOutput bool, refresh bool, refreshWait in
 	})
 }
 
+// validateMaxAge rejects a negative --max-age, and --acknowledge-stale without
+// --max-age, which would have nothing to acknowledge.
+func validateMaxAge(maxAge time.Duration, acknowledgeStale bool) error {
+	if maxAge < 0 {
+		return fmt.Errorf("--max-age must not be negative, got %s", maxAge)
+	}
+	if acknowledgeStale && maxAge == 0 {
+		return errors.New("--acknowledge-stale needs --max-age")
+	}
+
+	return nil
+}
+
+// statusAge returns how old the vehicle's status is at now, from the EV status
+// occurrence date or, if that isn't reported, the position acquisition time.
+// ok is false when neither is reported.
+func statusAge(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, now time.Time) (age time.Duration, ok bool) {
+	timestamp, _ := evStatus.GetOccurrenceDate()
+	if timestamp == "" {
+		if locationInfo, err := vehicleStatus.GetLocationInfo(); err == nil {
+			timestamp = locationInfo.Timestamp
+		}
+	}
+	t, ok := parseAPITimestamp(timestamp)
+	if !ok {
+		return 0, false
+	}
+
+	return now.Sub(t), true
+}
+
+// checkStatusAge reports whether the status is older than maxAge. Old status is
+// an error unless acknowledgeStale is set. Status without a timestamp can't be
+// judged and counts as fresh.
+func checkStatusAge(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, maxAge time.Duration, acknowledgeStale bool, now time.Time) (bool, error) {
+	age, ok := statusAge(vehicleStatus, evStatus, now)
+	if !ok || age <= maxAge {
+		return false, nil
+	}
+	if !acknowledgeStale {
+		return false, fmt.Errorf("status is %s old, older than --max-age %s (use --acknowledge-stale to show it anyway)", age.Round(time.Second), maxAge)
+	}
+
+	return true, nil
+}
+
+// staleMarker is the prefix of text output for status older than --max-age.
+func staleMarker(stale bool) string {
+	if stale {
+		return "[STALE] "
+	}
+
+	return ""
+}
+
+// refreshPollInterval is the time between status checks after a refresh request.
+const refreshPollInterval = 30 * time.Second
+
+// refreshWaitOptions controls how refreshAndWaitForStatus waits for fresh status.
+type refreshWaitOptions struct {
+	refreshWait       int // max seconds to wait for the vehicle
+	pollInterval      time.Duration
+	requireFresh      bool // fail instead of returning stale status
+	retryOnInProgress bool // wait on an already-running refresh instead of failing
+}
+
+// refreshWaitOptions returns the refresh options set by the status flags.
+func (o statusOptions) refreshWaitOptions() refreshWaitOptions {
+	return refreshWaitOptions{
+		refreshWait:       o.refreshWait,
+		pollInterval:      refreshPollInterval,
+		requireFresh:      o.waitFresh,
+		retryOnInProgress: o.retryOnInProgress,
+	}
+}
+
 // refreshAndWaitForStatus triggers a status refresh and polls until the timestamp changes.
-func refreshAndWaitForStatus(ctx context.Context, cmd *cobra.Command, client *api.Client, internalVIN api.InternalVIN, evStatus *api.EVVehicleStatusResponse, refreshWait int) (*api.EVVehicleStatusResponse, error) {
+// Vehicles that can't push fresh status return the current status immediately. If the
+// status doesn't update in time, the stale status is returned with a warning, or, with
+// requireFresh, a timeout error; requireFresh also fails for vehicles that can't refresh.
+// With retryOnInProgress, a refresh that is already running is waited on as if this
+// one had been sent.
+// Progress goes to info, and the --notify notification names the command action.
+func refreshAndWaitForStatus(ctx context.Context, info io.Writer, action string, client vehicleStatusGetter, vehicleInfo VehicleInfo, evStatus *api.EVVehicleStatusResponse, opts refreshWaitOptions) (*api.EVVehicleStatusResponse, error) {
+	if err := validateWaitSeconds("refresh-wait", opts.refreshWait); err != nil {
+		return nil, err
+	}
+
+	if !vehicleInfo.Powertrain.SupportsRemoteRefresh() {
+		if opts.requireFresh {
+			return nil, fmt.Errorf("refresh not supported on this vehicle (%s); --wait-fresh can't get fresh status", vehicleInfo.Powertrain)
+		}
+		_, _ = fmt.Fprintf(info, "Refresh not supported on this vehicle (%s); showing last reported status\n", vehicleInfo.Powertrain)
+
+		return evStatus, nil
+	}
+
 	initialTimestamp, err := evStatus.GetOccurrenceDate()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get occurrence date: %w", err)
 	}
-	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Current status from: %s\n", formatTimestamp(initialTimestamp))
-	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Requesting fresh status from vehicle...")
+	_, _ = fmt.Fprintf(info, "Current status from: %s\n", formatTimestamp(initialTimestamp))
+	_, _ = fmt.Fprintln(info, "Requesting fresh status from vehicle...")
 
-	if err := client.RefreshVehicleStatus(ctx, string(internalVIN)); err != nil {
-		return nil, fmt.Errorf("failed to refresh vehicle status: %w", err)
+	if err := client.RefreshVehicleStatus(ctx, vehicleInfo.InternalVIN); err != nil {
+		var inProgressErr *api.RequestInProgressError
+		if !opts.retryOnInProgress || !errors.As(err, &inProgressErr) {
+			return nil, fmt.Errorf("failed to refresh vehicle status: %w", err)
+		}
+		_, _ = fmt.Fprintln(info, "A refresh is already in progress; waiting for it to finish")
 	}
 
-	// Poll every 30 seconds until timestamp changes or timeout
-	pollInterval := 30 * time.Second
-	maxWait := time.Duration(refreshWait) * time.Second
+	return waitForStatusUpdate(ctx, info, action, client, vehicleInfo.InternalVIN, evStatus, opts.refreshWait, opts.pollInterval, opts.requireFresh)
+}
+
+// waitForStatusUpdate polls the EV status every pollInterval until its timestamp
+// differs from evStatus's, for up to refreshWait seconds. If it doesn't change in
+// time, evStatus is returned with a warning, or, with requireFresh, a timeout error.
+func waitForStatusUpdate(ctx context.Context, info io.Writer, action string, client vehicleStatusGetter, internalVIN api.InternalVIN, evStatus *api.EVVehicleStatusResponse, refreshWait int, pollInterval time.Duration, requireFresh bool) (*api.EVVehicleStatusResponse, error) {
+	initialTimestamp, err := evStatus.GetOccurrenceDate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get occurrence date: %w", err)
+	}
 
 	// Create a context with timeout
-	timeoutCtx, cancel := context.WithTimeout(ctx, maxWait)
+	timeoutCtx, cancel := context.WithTimeout(ctx, time.Duration(refreshWait)*time.Second)
 	defer cancel()
 
 	ticker := time.NewTicker(pollInterval)
@@ -117,10 +995,10 @@ func refreshAndWaitForStatus(ctx context.Context, cmd *cobra.Command, client *ap
 		select {
 		case <-ticker.C:
 			elapsed := time.Since(startTime)
-			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Waiting for vehicle response... (%ds/%ds)\n", int(elapsed.Seconds()), refreshWait)
+			_, _ = fmt.Fprintf(info, "Waiting for vehicle response... (%ds/%ds)\n", int(elapsed.Seconds()), refreshWait)
 
 			// Fetch new EV status
-			newEvStatus, err := client.GetEVVehicleStatus(timeoutCtx, string(internalVIN))
+			newEvStatus, err := client.GetEVVehicleStatus(timeoutCtx, internalVIN)
 			if err != nil {
 				continue // Keep trying on error
 			}
@@ -130,14 +1008,19 @@ func refreshAndWaitForStatus(ctx context.Context, cmd *cobra.Command, client *ap
 				continue // Keep trying on error
 			}
 			if newTimestamp != initialTimestamp {
-				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Got fresh status from: %s\n", formatTimestamp(newTimestamp))
+				_, _ = fmt.Fprintf(info, "Got fresh status from: %s\n", formatTimestamp(newTimestamp))
+				notifyDone(ctx, "mcs "+action, "Got fresh status from "+formatTimestamp(newTimestamp))
 
 				return newEvStatus, nil
 			}
 
 		case <-timeoutCtx.Done():
 			if timeoutCtx.Err() == context.DeadlineExceeded {
-				_, _ = fmt.Fprintln(cmd.OutOrStdout(), "Warning: status did not update within timeout period")
+				notifyDone(ctx, "mcs "+action, fmt.Sprintf("Status did not update within %ds", refreshWait))
+				if requireFresh {
+					return nil, &timeoutError{message: fmt.Sprintf("status did not update within %ds", refreshWait)}
+				}
+				_, _ = fmt.Fprintln(info, "Warning: status did not update within timeout period")
 
 				return evStatus, nil
 			}
@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/cache"
+	"golang.org/x/text/language"
+)
+
+// statusSnapshotFor records the readings of a status that --diff-previous compares.
+func statusSnapshotFor(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, checkedAt time.Time) cache.StatusSnapshot {
+	snapshot := cache.StatusSnapshot{CheckedAt: checkedAt.UTC()}
+	if odometerInfo, err := vehicleStatus.GetOdometerInfo(); err == nil {
+		snapshot.OdometerKm = &odometerInfo.OdometerKm
+	}
+	if batteryInfo, err := evStatus.GetBatteryInfo(); err == nil {
+		snapshot.BatteryLevel = &batteryInfo.BatteryLevel
+	}
+	if fuelInfo, err := vehicleStatus.GetFuelInfo(); err == nil {
+		snapshot.FuelLevel = &fuelInfo.FuelLevel
+	}
+	if locationInfo, err := vehicleStatus.GetLocationInfo(); err == nil {
+		snapshot.Latitude, snapshot.Longitude = &locationInfo.Latitude, &locationInfo.Longitude
+	}
+
+	return snapshot
+}
+
+// statusChange is what changed since the previous status, for --diff-previous.
+// Changes are nil when either status didn't report the reading.
+type statusChange struct {
+	found        bool      // whether there was a previous status to compare with
+	since        time.Time // when the previous status was checked
+	odometerKm   *float64
+	batteryLevel *float64 // percentage points
+	fuelLevel    *float64 // percentage points
+	movedKm      *float64 // straight-line distance between the two positions
+}
+
+// diffStatusSnapshots compares current with previous.
+func diffStatusSnapshots(previous, current cache.StatusSnapshot) statusChange {
+	delta := func(before, after *float64) *float64 {
+		if before == nil || after == nil {
+			return nil
+		}
+		d := *after - *before
+
+		return &d
+	}
+
+	change := statusChange{
+		found:        true,
+		since:        previous.CheckedAt,
+		odometerKm:   delta(previous.OdometerKm, current.OdometerKm),
+		batteryLevel: delta(previous.BatteryLevel, current.BatteryLevel),
+		fuelLevel:    delta(previous.FuelLevel, current.FuelLevel),
+	}
+	if previous.Latitude != nil && previous.Longitude != nil && current.Latitude != nil && current.Longitude != nil {
+		moved := haversineKm(*previous.Latitude, *previous.Longitude, *current.Latitude, *current.Longitude)
+		change.movedKm = &moved
+	}
+
+	return change
+}
+
+// recordStatusSnapshot stores current as the last snapshot for key and returns
+// what changed since the one it replaces.
+func recordStatusSnapshot(ctx context.Context, key string, current cache.StatusSnapshot) (statusChange, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return statusChange{}, err
+	}
+
+	store, err := cache.LoadSnapshotsFrom(paths.SnapshotFile)
+	if err != nil {
+		return statusChange{}, fmt.Errorf("failed to load previous status: %w", err)
+	}
+
+	var change statusChange
+	if previous, ok := store[key]; ok {
+		change = diffStatusSnapshots(previous, current)
+	}
+
+	store[key] = current
+	if err := cache.SaveSnapshotsTo(store, paths.SnapshotFile); err != nil {
+		return statusChange{}, fmt.Errorf("failed to save status snapshot: %w", err)
+	}
+
+	return change, nil
+}
+
+// formatStatusChange formats the CHANGES line, e.g. "CHANGES since 3 hours ago:
+// odometer +42.3 km, battery -12%, fuel -5%, moved 12.4 km".
+func formatStatusChange(change statusChange, unit distanceUnit, locale language.Tag) string {
+	if !change.found {
+		return "CHANGES: No previous status saved for this vehicle; the next check will compare against this one"
+	}
+
+	signed := func(value float64, decimals int) string {
+		sign := "+"
+		if value < 0 {
+			sign, value = "-", -value
+		}
+
+		return sign + formatNumber(value, decimals, locale)
+	}
+
+	var parts []string
+	if change.odometerKm != nil {
+		parts = append(parts, fmt.Sprintf("odometer %s %s", signed(unit.fromKm(*change.odometerKm), 1), unit))
+	}
+	if change.batteryLevel != nil {
+		parts = append(parts, fmt.Sprintf("battery %s%%", signed(*change.batteryLevel, 0)))
+	}
+	if change.fuelLevel != nil {
+		parts = append(parts, fmt.Sprintf("fuel %s%%", signed(*change.fuelLevel, 0)))
+	}
+	if change.movedKm != nil {
+		parts = append(parts, fmt.Sprintf("moved %s %s", formatNumber(unit.fromKm(*change.movedKm), 1, locale), unit))
+	}
+	if len(parts) == 0 {
+		parts = append(parts, "nothing to compare")
+	}
+
+	return fmt.Sprintf("CHANGES since %s: %s", formatRelativeTime(change.since), strings.Join(parts, ", "))
+}
+
+// statusChangeToMap converts a statusChange for JSON output. It is nil when there
+// was no previous status, and leaves out readings that couldn't be compared.
+func statusChangeToMap(change statusChange, unit distanceUnit) map[string]any {
+	if !change.found {
+		return nil
+	}
+
+	data := map[string]any{"since": change.since.Format(time.RFC3339)}
+	if change.odometerKm != nil {
+		data[unit.key("odometer_delta")] = unit.fromKm(*change.odometerKm)
+	}
+	if change.batteryLevel != nil {
+		data["battery_level_delta"] = *change.batteryLevel
+	}
+	if change.fuelLevel != nil {
+		data["fuel_level_delta"] = *change.fuelLevel
+	}
+	if change.movedKm != nil {
+		data[unit.key("moved")] = unit.fromKm(*change.movedKm)
+	}
+
+	return data
+}
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/cv/mcs/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+// statusesAt builds a vehicle and EV status with the readings --diff-previous compares.
+func statusesAt(odometerKm, fuelLevel, batteryLevel, latitude, longitude float64) (*api.VehicleStatusResponse, *api.EVVehicleStatusResponse) {
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	vehicleStatus.RemoteInfos[0].DriveInformation.OdoDispValue = odometerKm
+	vehicleStatus.RemoteInfos[0].ResidualFuel.FuelSegmentDActl = fuelLevel
+	vehicleStatus.AlertInfos[0].PositionInfo.Latitude = latitude
+	vehicleStatus.AlertInfos[0].PositionInfo.Longitude = longitude
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	evStatus.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.SmaphSOC = batteryLevel
+
+	return vehicleStatus, evStatus
+}
+
+// TestRecordStatusSnapshot_ConsecutiveRuns simulates two status --diff-previous runs.
+func TestRecordStatusSnapshot_ConsecutiveRuns(t *testing.T) {
+	t.Parallel()
+	snapshotFile := filepath.Join(t.TempDir(), "snapshots.json")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{SnapshotFile: snapshotFile})
+	firstRun := time.Now().Add(-3 * time.Hour)
+
+	// First run: nothing to compare with yet.
+	vehicleStatus, evStatus := statusesAt(12000, 60, 80, 37.7749, -122.4194)
+	change, err := recordStatusSnapshot(ctx, "VIN1", statusSnapshotFor(vehicleStatus, evStatus, firstRun))
+	require.NoError(t, err)
+	assert.False(t, change.found)
+	assert.Equal(t, "CHANGES: No previous status saved for this vehicle; the next check will compare against this one",
+		formatStatusChange(change, distanceKm, language.AmericanEnglish))
+
+	// Second run, three hours later and about 11 km further south.
+	vehicleStatus, evStatus = statusesAt(12042.3, 55, 68, 37.6749, -122.4194)
+	change, err = recordStatusSnapshot(ctx, "VIN1", statusSnapshotFor(vehicleStatus, evStatus, time.Now()))
+	require.NoError(t, err)
+	require.True(t, change.found)
+	assert.Equal(t, "CHANGES since 3 hours ago: odometer +42.3 km, battery -12%, fuel -5%, moved 11.1 km",
+		formatStatusChange(change, distanceKm, language.AmericanEnglish))
+
+	data := statusChangeToMap(change, distanceKm)
+	assert.InDelta(t, 42.3, data["odometer_delta_km"], 0.001)
+	assert.InDelta(t, -12.0, data["battery_level_delta"], 0.001)
+	assert.InDelta(t, -5.0, data["fuel_level_delta"], 0.001)
+	assert.InDelta(t, 11.1, data["moved_km"], 0.05)
+
+	// The second run is now the one the next run compares against.
+	store, err := cache.LoadSnapshotsFrom(snapshotFile)
+	require.NoError(t, err)
+	require.NotNil(t, store["VIN1"].OdometerKm)
+	assert.InDelta(t, 12042.3, *store["VIN1"].OdometerKm, 0.001)
+}
+
+// TestDiffStatusSnapshots_MissingReadings tests that readings either status lacks are left out.
+func TestDiffStatusSnapshots_MissingReadings(t *testing.T) {
+	t.Parallel()
+	odometer, later := 12000.0, 12010.0
+	previous := cache.StatusSnapshot{CheckedAt: time.Now().Add(-time.Minute), OdometerKm: &odometer}
+	current := cache.StatusSnapshot{OdometerKm: &later}
+
+	change := diffStatusSnapshots(previous, current)
+	assert.Nil(t, change.batteryLevel)
+	assert.Nil(t, change.movedKm)
+	assert.Equal(t, "CHANGES since 1 min ago: odometer +6.2 mi", formatStatusChange(change, distanceMi, language.AmericanEnglish))
+	assert.Nil(t, statusChangeToMap(statusChange{}, distanceKm))
+}
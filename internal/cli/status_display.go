@@ -1,10 +1,14 @@
 package cli
 
 import (
-	"encoding/json"
+	"cmp"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/cv/mcs/internal/api"
+	"golang.org/x/text/language"
 )
 
 // appendFormattedSection appends a formatted section to the output string with a newline.
@@ -19,37 +23,195 @@ func appendFormattedSection(output *string, formatter func() (string, error)) er
 	return nil
 }
 
-// displayAllStatusJSON formats all status as JSON.
-func displayAllStatusJSON(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo) (string, error) {
+// buildAllStatusData assembles all status sections into a single map for structured output.
+// Distances are reported in unit. status_timestamp (position acquisition time),
+// vehicle_status_timestamp, and ev_status_timestamp let consumers detect stale
+// data; each is omitted when not reported, as is aux_battery (the 12V
+// battery). powertrain and capabilities let consumers adapt to the vehicle
+// without model-specific logic.
+func buildAllStatusData(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, unit distanceUnit) map[string]any {
 	hazardsOn, _ := vehicleStatus.GetHazardInfo()
+	doorStatus, _ := vehicleStatus.GetDoorsInfo()
+
 	data := map[string]any{
-		"vehicle":  extractVehicleInfoData(vehicleInfo),
-		"battery":  extractBatteryData(evStatus),
-		"fuel":     extractFuelData(vehicleStatus),
-		"location": extractLocationData(vehicleStatus),
-		"tires":    extractTiresData(vehicleStatus),
-		"doors":    extractDoorsData(vehicleStatus),
-		"windows":  extractWindowsData(vehicleStatus),
-		"hazards":  hazardsOn,
-		"climate":  extractHvacData(evStatus),
-		"odometer": extractOdometerData(vehicleStatus),
-	}
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		"vehicle":      extractVehicleInfoData(vehicleInfo),
+		"powertrain":   vehicleInfo.powertrainName(),
+		"capabilities": vehicleInfo.capabilityNames(),
+		"battery":      extractBatteryData(evStatus, unit),
+		"fuel":         extractFuelData(vehicleStatus, unit),
+		"location":     extractLocationData(vehicleStatus),
+		"tires":        extractTiresData(vehicleStatus),
+		"doors":        extractDoorsData(vehicleStatus),
+		"windows":      extractWindowsData(vehicleStatus),
+		"hazards":      hazardsOn,
+		"climate":      extractHvacData(evStatus),
+		"odometer":     extractOdometerData(vehicleStatus, unit),
+		// Duplicated from doors so a lid left open is easy to spot.
+		"fuel_lid_open": doorStatus.FuelLidOpen,
+	}
+	if locationInfo, err := vehicleStatus.GetLocationInfo(); err == nil && locationInfo.Timestamp != "" {
+		data["status_timestamp"] = formatTimestampRFC3339(locationInfo.Timestamp)
+	}
+	if occurrenceDate, err := vehicleStatus.GetOccurrenceDate(); err == nil && occurrenceDate != "" {
+		data["vehicle_status_timestamp"] = formatTimestampRFC3339(occurrenceDate)
+	}
+	if occurrenceDate, err := evStatus.GetOccurrenceDate(); err == nil && occurrenceDate != "" {
+		data["ev_status_timestamp"] = formatTimestampRFC3339(occurrenceDate)
+	}
+	if auxBattery, err := vehicleStatus.GetAuxBatteryInfo(); err == nil {
+		data["aux_battery"] = auxBatteryToMap(auxBattery)
+	}
+
+	return data
+}
+
+// checkStatusComplete returns an error naming every status section whose data the
+// vehicle didn't report. The full status otherwise shows such sections as zeros.
+func checkStatusComplete(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse) error {
+	sections := []struct {
+		name string
+		err  error
+	}{
+		{"battery", sectionErr(evStatus.GetBatteryInfo())},
+		{"climate", sectionErr(evStatus.GetHvacInfo())},
+		{"fuel", sectionErr(vehicleStatus.GetFuelInfo())},
+		{"doors", sectionErr(vehicleStatus.GetDoorsInfo())},
+		{"windows", sectionErr(vehicleStatus.GetWindowsInfo())},
+		{"hazards", sectionErr(vehicleStatus.GetHazardInfo())},
+		{"tires", sectionErr(vehicleStatus.GetTiresInfo())},
+		{"location", sectionErr(vehicleStatus.GetLocationInfo())},
+		{"odometer", sectionErr(vehicleStatus.GetOdometerInfo())},
+	}
+
+	var missing []string
+	for _, section := range sections {
+		if section.err != nil {
+			missing = append(missing, section.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("incomplete status: no data for %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// sectionErr discards a status getter's value, keeping its error.
+func sectionErr[T any](_ T, err error) error {
+	return err
+}
+
+// rawFlags lists the door, lock, window, and hazard codes from an alert snapshot
+// under their API names, in the order --show-raw-flags prints them.
+func rawFlags(alertInfo api.AlertInfo) []struct {
+	name  string
+	value float64
+} {
+	door, window := alertInfo.Door, alertInfo.Pw
+
+	return []struct {
+		name  string
+		value float64
+	}{
+		{"DrStatDrv", door.DrStatDrv},
+		{"DrStatPsngr", door.DrStatPsngr},
+		{"DrStatRl", door.DrStatRl},
+		{"DrStatRr", door.DrStatRr},
+		{"DrStatTrnkLg", door.DrStatTrnkLg},
+		{"DrStatHood", door.DrStatHood},
+		{"LockLinkSwDrv", door.LockLinkSwDrv},
+		{"LockLinkSwPsngr", door.LockLinkSwPsngr},
+		{"LockLinkSwRl", door.LockLinkSwRl},
+		{"LockLinkSwRr", door.LockLinkSwRr},
+		{"FuelLidOpenStatus", door.FuelLidOpenStatus},
+		{"PwPosDrv", window.PwPosDrv},
+		{"PwPosPsngr", window.PwPosPsngr},
+		{"PwPosRl", window.PwPosRl},
+		{"PwPosRr", window.PwPosRr},
+		{"HazardSw", alertInfo.HazardLamp.HazardSw},
+	}
+}
+
+// formatRawFlags formats the RAW line of --show-raw-flags, e.g.
+// "RAW: DrStatDrv=0 DrStatPsngr=0 ... HazardSw=0".
+func formatRawFlags(alertInfo api.AlertInfo) string {
+	flags := rawFlags(alertInfo)
+	parts := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		parts = append(parts, fmt.Sprintf("%s=%g", flag.name, flag.value))
+	}
+
+	return "RAW: " + strings.Join(parts, " ")
+}
+
+// rawFlagsToMap converts the --show-raw-flags codes for JSON output, keyed by
+// their API names.
+func rawFlagsToMap(alertInfo api.AlertInfo) map[string]any {
+	data := map[string]any{}
+	for _, flag := range rawFlags(alertInfo) {
+		data[flag.name] = flag.value
+	}
+
+	return data
+}
+
+// statusTimestampSkew is how far apart the EV and vehicle status may have been
+// reported before the full status shows both times.
+const statusTimestampSkew = 5 * time.Minute
+
+// statusTimestamps picks the "Status as of" time for the full status: the newer
+// of the EV status (battery, climate) and the vehicle status (doors, windows,
+// fuel, tires, odometer). When the other was reported more than
+// statusTimestampSkew earlier, it is returned as older, with the sections it
+// covers, so that a fresh battery reading isn't mistaken for a fresh lock state.
+func statusTimestamps(evDate, vehicleDate string) (newest, older, olderSections string) {
+	evTime, evOK := parseAPITimestamp(evDate)
+	vehicleTime, vehicleOK := parseAPITimestamp(vehicleDate)
+	if !evOK || !vehicleOK {
+		return cmp.Or(evDate, vehicleDate), "", ""
+	}
+
+	if vehicleTime.After(evTime) {
+		if vehicleTime.Sub(evTime) > statusTimestampSkew {
+			return vehicleDate, evDate, "Battery and climate"
+		}
+
+		return vehicleDate, "", ""
+	}
+	if evTime.Sub(vehicleTime) > statusTimestampSkew {
+		return evDate, vehicleDate, "Doors, windows, fuel, tires, and odometer"
+	}
+
+	return evDate, "", ""
+}
+
+// displayAllStatusJSON formats all status as JSON.
+func displayAllStatusJSON(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, opts statusDisplayOptions) (string, error) {
+	data := buildAllStatusData(vehicleStatus, evStatus, vehicleInfo, opts.distanceUnit)
+	if opts.stale {
+		data["stale"] = true
+	}
+	if opts.sincePrevious != nil {
+		data["since_previous"] = statusChangeToMap(*opts.sincePrevious, opts.distanceUnit)
+	}
+	if opts.showRawFlags {
+		if alertInfo, err := vehicleStatus.GetLatestAlertInfo(); err == nil {
+			data["raw_flags"] = rawFlagsToMap(alertInfo)
+		}
 	}
 
-	return string(jsonBytes), nil
+	return toJSON(data, opts.format)
 }
 
 // displayAllStatusText formats all status as human-readable text.
-func displayAllStatusText(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo) (string, error) {
+func displayAllStatusText(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, opts statusDisplayOptions) (string, error) {
 	// Get timestamp from EV status
 	occurrenceDate, err := evStatus.GetOccurrenceDate()
 	if err != nil {
 		return "", fmt.Errorf("failed to get occurrence date: %w", err)
 	}
-	timestamp := formatTimestamp(occurrenceDate)
+	vehicleDate, _ := vehicleStatus.GetOccurrenceDate()
+	newestDate, olderDate, olderSections := statusTimestamps(occurrenceDate, vehicleDate)
 
 	// Extract HVAC info
 	hvacInfo, err := evStatus.GetHvacInfo()
@@ -68,25 +230,39 @@ func displayAllStatusText(vehicleStatus *api.VehicleStatusResponse, evStatus *ap
 	locationInfo, _ := vehicleStatus.GetLocationInfo()
 
 	// Build vehicle header
-	output := formatVehicleHeader(vehicleInfo) + "\n"
-	output += fmt.Sprintf("Status as of %s\n\n", timestamp)
-	output += formatBatteryStatusCompact(batteryInfo) + "\n"
-	output += formatFuelStatusWithRange(fuelInfo, batteryInfo) + "\n"
+	var output string
+	if !opts.noHeader {
+		output = formatVehicleHeader(vehicleInfo)
+		if opts.verbose {
+			output += formatVehicleDetails(vehicleInfo)
+		}
+		output += "\n"
+		output += fmt.Sprintf("Status as of %s\n", formatTimestampStyle(newestDate, opts.timestampFormat))
+		if olderDate != "" {
+			output += fmt.Sprintf("%s as of %s\n", olderSections, formatTimestampStyle(olderDate, opts.timestampFormat))
+		}
+		if locationInfo.Timestamp != "" && locationInfo.Timestamp != newestDate && locationInfo.Timestamp != olderDate {
+			output += fmt.Sprintf("Position as of %s\n", formatTimestampStyle(locationInfo.Timestamp, opts.timestampFormat))
+		}
+		output += "\n"
+	}
+	output += formatBatteryStatusCompact(batteryInfo, opts.barWidth) + "\n"
+	output += formatFuelStatusWithRange(fuelInfo, batteryInfo, opts.distanceUnit, opts.locale) + "\n"
 
 	if err := appendFormattedSection(&output, func() (string, error) {
-		return formatHvacStatus(hvacInfo, false)
+		return formatHvacStatus(hvacInfo, outputText, opts.tempUnit, opts.locale)
 	}); err != nil {
 		return "", err
 	}
 
 	if err := appendFormattedSection(&output, func() (string, error) {
-		return formatDoorsStatus(doorStatus, false)
+		return formatDoorsStatus(doorStatus, outputText, opts.theme)
 	}); err != nil {
 		return "", err
 	}
 
 	if err := appendFormattedSection(&output, func() (string, error) {
-		return formatWindowsStatus(windowsInfo, false)
+		return formatWindowsStatus(windowsInfo, outputText)
 	}); err != nil {
 		return "", err
 	}
@@ -96,33 +272,154 @@ func displayAllStatusText(vehicleStatus *api.VehicleStatusResponse, evStatus *ap
 		output += "HAZARDS: On\n"
 	}
 
+	// Called out on its own line as it's easy to miss among the door issues.
+	if doorStatus.FuelLidOpen {
+		output += "FUEL LID: " + Red("Open") + "\n"
+	}
+
+	// Only shown by vehicles that report it.
+	if auxBattery, err := vehicleStatus.GetAuxBatteryInfo(); err == nil {
+		output += formatAuxBatteryStatus(auxBattery) + "\n"
+	}
+
+	if opts.showRawFlags {
+		if alertInfo, err := vehicleStatus.GetLatestAlertInfo(); err == nil {
+			output += formatRawFlags(alertInfo) + "\n"
+		}
+	}
+
 	if err := appendFormattedSection(&output, func() (string, error) {
-		return formatTiresStatus(tireInfo, false)
+		return formatTiresStatus(tireInfo, outputText, opts.tempUnit)
 	}); err != nil {
 		return "", err
 	}
 
 	if err := appendFormattedSection(&output, func() (string, error) {
-		return formatLocationStatus(locationInfo, false)
+		return formatLocationStatus(locationInfo, outputText, "", nil)
 	}); err != nil {
 		return "", err
 	}
 
 	// Note: odometer is the last section, so no trailing newline
-	odometerOutput, err := formatOdometerStatus(odometerInfo, false)
+	odometerOutput, err := formatOdometerStatus(odometerInfo, outputText, opts.distanceUnit, opts.locale)
 	if err != nil {
 		return "", err
 	}
 	output += odometerOutput
 
-	return output, nil
+	if opts.sincePrevious != nil {
+		output += "\n" + formatStatusChange(*opts.sincePrevious, opts.distanceUnit, opts.locale)
+	}
+
+	return staleMarker(opts.stale) + output, nil
+}
+
+// maxSummaryLength is the length budget, in characters, for --summary. It keeps
+// the line short enough for an email subject.
+const maxSummaryLength = 100
+
+// formatStatusSummary formats the status as one sentence for --summary, e.g.
+// "CX-90 PHEV: 80% battery (plugged, charging), all doors locked, parked."
+// Clauses for data the vehicle didn't report are left out, and trailing clauses
+// are dropped to fit within maxLen.
+func formatStatusSummary(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, maxLen int) string {
+	var clauses []string
+
+	if vehicleInfo.Powertrain.IsElectrified() {
+		if batteryInfo, err := evStatus.GetBatteryInfo(); err == nil {
+			clauses = append(clauses, fmt.Sprintf("%.0f%% battery (%s)", batteryInfo.BatteryLevel, summaryChargeState(batteryInfo)))
+		}
+	} else if fuelInfo, err := vehicleStatus.GetFuelInfo(); err == nil {
+		clauses = append(clauses, fmt.Sprintf("%.0f%% fuel", fuelInfo.FuelLevel))
+	}
+
+	if doorStatus, err := vehicleStatus.GetDoorsInfo(); err == nil {
+		if doorStatus.AllLocked {
+			clauses = append(clauses, "all doors locked")
+		} else {
+			clauses = append(clauses, "doors unlocked")
+		}
+	}
+
+	if locationInfo, err := vehicleStatus.GetLocationInfo(); err == nil && locationInfo.SpeedKmh != nil {
+		if *locationInfo.SpeedKmh == 0 {
+			clauses = append(clauses, "parked")
+		} else {
+			clauses = append(clauses, "moving")
+		}
+	}
+
+	name := vehicleInfo.ModelName
+	if name == "" {
+		name = vehicleInfo.Nickname
+	}
+
+	summary := buildSummary(name, clauses)
+	for utf8.RuneCountInString(summary) > maxLen && len(clauses) > 1 {
+		clauses = clauses[:len(clauses)-1]
+		summary = buildSummary(name, clauses)
+	}
+	if runes := []rune(summary); len(runes) > maxLen {
+		summary = string(runes[:maxLen-1]) + "…"
+	}
+
+	return summary
+}
+
+// summaryChargeState describes the charger for --summary, e.g. "plugged, charging".
+func summaryChargeState(batteryInfo api.BatteryInfo) string {
+	switch {
+	case !batteryInfo.PluggedIn:
+		return "unplugged"
+	case batteryInfo.Charging:
+		return "plugged, charging"
+	case batteryInfo.ChargeState == api.ChargeStateComplete:
+		return "plugged, charge complete"
+	case batteryInfo.ChargeState == api.ChargeStateInterrupted:
+		return "plugged, charge interrupted"
+	case batteryInfo.ChargeState == api.ChargeStateScheduled:
+		return "plugged, waiting to charge"
+	case batteryInfo.ChargeState == api.ChargeStateNotCharging, batteryInfo.ChargeState == "":
+		return "plugged, not charging"
+	default:
+		return "plugged, charge state " + string(batteryInfo.ChargeState)
+	}
+}
+
+// buildSummary joins the vehicle name and clauses into a sentence.
+func buildSummary(name string, clauses []string) string {
+	sentence := strings.Join(clauses, ", ")
+	if sentence == "" {
+		sentence = "no status reported"
+	}
+	if name != "" {
+		sentence = name + ": " + sentence
+	}
+
+	return sentence + "."
+}
+
+// statusDisplayOptions controls how the full status is rendered.
+type statusDisplayOptions struct {
+	format          outputFormat
+	verbose         bool
+	noHeader        bool                // omit the vehicle header and timestamps in text output
+	barWidth        int                 // zero means defaultBarWidth
+	timestampFormat string              // empty means timestampFormatDefault
+	distanceUnit    distanceUnit        // empty means kilometers
+	tempUnit        api.TemperatureUnit // zero value means Celsius
+	locale          language.Tag        // number separators; zero value means en-US
+	theme           theme               // zero value is the plain ASCII theme
+	stale           bool                // older than --max-age; marked [STALE] in text and "stale": true in JSON
+	sincePrevious   *statusChange       // changes since the last --diff-previous; nil when not requested
+	showRawFlags    bool                // add the raw door, lock, window, and hazard codes, for troubleshooting
 }
 
 // displayAllStatus displays all status information.
-func displayAllStatus(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return displayAllStatusJSON(vehicleStatus, evStatus, vehicleInfo)
+func displayAllStatus(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse, vehicleInfo VehicleInfo, opts statusDisplayOptions) (string, error) {
+	if opts.format.isJSON() {
+		return displayAllStatusJSON(vehicleStatus, evStatus, vehicleInfo, opts)
 	}
 
-	return displayAllStatusText(vehicleStatus, evStatus, vehicleInfo)
+	return displayAllStatusText(vehicleStatus, evStatus, vehicleInfo, opts)
 }
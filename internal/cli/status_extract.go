@@ -21,59 +21,107 @@ func extractWithGetter[T any](getter func() (T, error), converter func(T) map[st
 // extractVehicleInfoData extracts vehicle info for JSON output.
 func extractVehicleInfoData(vehicleInfo VehicleInfo) map[string]any {
 	return map[string]any{
-		"vin":        vehicleInfo.VIN,
-		"nickname":   vehicleInfo.Nickname,
-		"model_name": vehicleInfo.ModelName,
-		"model_year": vehicleInfo.ModelYear,
+		"vin":            vehicleInfo.displayVIN(),
+		"nickname":       vehicleInfo.Nickname,
+		"model_name":     vehicleInfo.ModelName,
+		"model_year":     vehicleInfo.ModelYear,
+		"carline":        vehicleInfo.Carline,
+		"carline_code":   vehicleInfo.CarlineCode,
+		"model_code":     vehicleInfo.ModelCode,
+		"exterior_color": vehicleInfo.ExteriorColor,
+		"interior_color": vehicleInfo.InteriorColor,
+		"transmission":   vehicleInfo.Transmission,
+		"econnect_type":  int(vehicleInfo.EconnectType),
 	}
 }
 
-// batteryInfoToMap converts BatteryInfo to a map for JSON output.
-func batteryInfoToMap(batteryInfo api.BatteryInfo) map[string]any {
+// batteryInfoToMap converts BatteryInfo to a map for JSON output, with range in unit.
+// last_charged is omitted for vehicles that don't report it.
+func batteryInfoToMap(batteryInfo api.BatteryInfo, unit distanceUnit) map[string]any {
 	data := map[string]any{
-		"battery_level": batteryInfo.BatteryLevel,
-		"range_km":      batteryInfo.RangeKm,
-		"plugged_in":    batteryInfo.PluggedIn,
-		"charging":      batteryInfo.Charging,
-		"heater_on":     batteryInfo.HeaterOn,
-		"heater_auto":   batteryInfo.HeaterAuto,
+		"battery_level":   batteryInfo.BatteryLevel,
+		unit.key("range"): unit.fromKm(batteryInfo.RangeKm),
+		"plugged_in":      batteryInfo.PluggedIn,
+		"charging":        batteryInfo.Charging,
+		"charge_state":    string(batteryInfo.ChargeState),
+		"heater_on":       batteryInfo.HeaterOn,
+		"heater_auto":     batteryInfo.HeaterAuto,
+		// The estimates are always present, even while paused or unplugged, so the
+		// schema doesn't change with the charging state; null means no estimate.
+		"charge_time_ac_minutes":  chargeEstimate(batteryInfo.ChargeTimeACMin),
+		"charge_time_qbc_minutes": chargeEstimate(batteryInfo.ChargeTimeQBCMin),
 	}
 	if batteryInfo.Charging {
-		data["charge_time_ac_minutes"] = batteryInfo.ChargeTimeACMin
-		data["charge_time_qbc_minutes"] = batteryInfo.ChargeTimeQBCMin
+		if batteryInfo.ChargeRate.Type != "" {
+			data["charge_type"] = batteryInfo.ChargeRate.Type
+		}
+		if batteryInfo.ChargeRate.PowerKW > 0 {
+			data["charge_power_kw"] = batteryInfo.ChargeRate.PowerKW
+		}
+	}
+	if batteryInfo.LastChargedAt != "" {
+		data["last_charged"] = formatTimestampRFC3339(batteryInfo.LastChargedAt)
 	}
 
 	return data
 }
 
+// chargeEstimate returns a charge time estimate in minutes for JSON output, or
+// nil when the vehicle reports none.
+func chargeEstimate(minutes float64) any {
+	if minutes <= 0 {
+		return nil
+	}
+
+	return minutes
+}
+
 // extractBatteryData extracts battery data for JSON output.
-func extractBatteryData(evStatus *api.EVVehicleStatusResponse) map[string]any {
-	return extractWithGetter(evStatus.GetBatteryInfo, batteryInfoToMap)
+func extractBatteryData(evStatus *api.EVVehicleStatusResponse, unit distanceUnit) map[string]any {
+	return extractWithGetter(evStatus.GetBatteryInfo, func(batteryInfo api.BatteryInfo) map[string]any {
+		return batteryInfoToMap(batteryInfo, unit)
+	})
 }
 
-// fuelInfoToMap converts FuelInfo to a map for JSON output.
-func fuelInfoToMap(fuelInfo api.FuelInfo) map[string]any {
-	return map[string]any{
-		"fuel_level": fuelInfo.FuelLevel,
-		"range_km":   fuelInfo.RangeKm,
+// fuelInfoToMap converts FuelInfo to a map for JSON output, with range in unit.
+// low_fuel_warning is only included when the vehicle reports it.
+func fuelInfoToMap(fuelInfo api.FuelInfo, unit distanceUnit) map[string]any {
+	data := map[string]any{
+		"fuel_level":      fuelInfo.FuelLevel,
+		unit.key("range"): unit.fromKm(fuelInfo.RangeKm),
+	}
+	if fuelInfo.APILowFuel != nil {
+		data["low_fuel_warning"] = *fuelInfo.APILowFuel
 	}
+
+	return data
 }
 
 // extractFuelData extracts fuel data for JSON output.
-func extractFuelData(vehicleStatus *api.VehicleStatusResponse) map[string]any {
-	return extractWithGetter(vehicleStatus.GetFuelInfo, fuelInfoToMap)
+func extractFuelData(vehicleStatus *api.VehicleStatusResponse, unit distanceUnit) map[string]any {
+	return extractWithGetter(vehicleStatus.GetFuelInfo, func(fuelInfo api.FuelInfo) map[string]any {
+		return fuelInfoToMap(fuelInfo, unit)
+	})
 }
 
 // locationInfoToMap converts LocationInfo to a map for JSON output.
 func locationInfoToMap(locationInfo api.LocationInfo) map[string]any {
 	mapsURL := fmt.Sprintf("https://maps.google.com/?q=%f,%f", locationInfo.Latitude, locationInfo.Longitude)
 
-	return map[string]any{
+	data := map[string]any{
 		"latitude":  locationInfo.Latitude,
 		"longitude": locationInfo.Longitude,
-		"timestamp": locationInfo.Timestamp,
+		"timestamp": formatTimestampRFC3339(locationInfo.Timestamp),
 		"maps_url":  mapsURL,
 	}
+	if locationInfo.Heading != nil {
+		data["heading_deg"] = *locationInfo.Heading
+	}
+	if locationInfo.SpeedKmh != nil {
+		data["speed_kmh"] = *locationInfo.SpeedKmh
+	}
+
+	return data
 }
 
 // extractLocationData extracts location data for JSON output.
@@ -82,13 +130,35 @@ func extractLocationData(vehicleStatus *api.VehicleStatusResponse) map[string]an
 }
 
 // tireInfoToMap converts TireInfo to a map for JSON output.
+// Temperature keys, always in Celsius, are omitted for tires that don't report them.
 func tireInfoToMap(tireInfo api.TireInfo) map[string]any {
-	return map[string]any{
+	data := map[string]any{
 		"front_left_psi":  tireInfo.FrontLeftPsi,
 		"front_right_psi": tireInfo.FrontRightPsi,
 		"rear_left_psi":   tireInfo.RearLeftPsi,
 		"rear_right_psi":  tireInfo.RearRightPsi,
 	}
+	temps := map[string]*float64{
+		"front_left_temp_c":  tireInfo.FrontLeftTempC,
+		"front_right_temp_c": tireInfo.FrontRightTempC,
+		"rear_left_temp_c":   tireInfo.RearLeftTempC,
+		"rear_right_temp_c":  tireInfo.RearRightTempC,
+	}
+	for key, temp := range temps {
+		if temp != nil {
+			data[key] = *temp
+		}
+	}
+
+	return data
+}
+
+// auxBatteryToMap converts AuxBatteryInfo to a map for JSON output.
+func auxBatteryToMap(auxBattery api.AuxBatteryInfo) map[string]any {
+	return map[string]any{
+		"voltage": auxBattery.VoltageV,
+		"low":     auxBattery.Low(),
+	}
 }
 
 // extractTiresData extracts tire data for JSON output.
@@ -111,6 +181,7 @@ func doorStatusToMap(doorStatus api.DoorStatus) map[string]any {
 		"passenger_locked":  doorStatus.PassengerLocked,
 		"rear_left_locked":  doorStatus.RearLeftLocked,
 		"rear_right_locked": doorStatus.RearRightLocked,
+		"summary":           doorStatus.Summary(),
 	}
 }
 
@@ -119,27 +190,70 @@ func extractDoorsData(vehicleStatus *api.VehicleStatusResponse) map[string]any {
 	return extractWithGetter(vehicleStatus.GetDoorsInfo, doorStatusToMap)
 }
 
-// odometerInfoToMap converts OdometerInfo to a map for JSON output.
-func odometerInfoToMap(odometerInfo api.OdometerInfo) map[string]any {
-	return map[string]any{
-		"odometer_km": odometerInfo.OdometerKm,
+// odometerInfoToMap converts OdometerInfo to a map for JSON output, in unit.
+// A "trip" map is included only when the vehicle reports a trip meter.
+func odometerInfoToMap(odometerInfo api.OdometerInfo, unit distanceUnit) map[string]any {
+	data := map[string]any{
+		unit.key("odometer"): unit.fromKm(odometerInfo.OdometerKm),
+	}
+	if odometerInfo.Trip != nil {
+		data["trip"] = tripInfoToMap(*odometerInfo.Trip, unit)
 	}
+
+	return data
+}
+
+// tripInfoToMap converts TripInfo to a map for JSON output, omitting values the
+// vehicle doesn't report.
+func tripInfoToMap(trip api.TripInfo, unit distanceUnit) map[string]any {
+	data := map[string]any{}
+	if trip.DistanceKm != nil {
+		data[unit.key("distance")] = unit.fromKm(*trip.DistanceKm)
+	}
+	if trip.DriveTimeMinutes != nil {
+		data["drive_time_minutes"] = *trip.DriveTimeMinutes
+	}
+	if trip.FuelUsedLiters != nil {
+		data["fuel_used_liters"] = *trip.FuelUsedLiters
+	}
+	if trip.AvgFuelEconomy != nil {
+		data["average_fuel_economy"] = *trip.AvgFuelEconomy
+	}
+	if trip.AvgEnergyEconomy != nil {
+		data["average_energy_economy"] = *trip.AvgEnergyEconomy
+	}
+
+	return data
 }
 
 // extractOdometerData extracts odometer data for JSON output.
-func extractOdometerData(vehicleStatus *api.VehicleStatusResponse) map[string]any {
-	return extractWithGetter(vehicleStatus.GetOdometerInfo, odometerInfoToMap)
+func extractOdometerData(vehicleStatus *api.VehicleStatusResponse, unit distanceUnit) map[string]any {
+	return extractWithGetter(vehicleStatus.GetOdometerInfo, func(odometerInfo api.OdometerInfo) map[string]any {
+		return odometerInfoToMap(odometerInfo, unit)
+	})
 }
 
-// hvacInfoToMap converts HVACInfo to a map for JSON output.
+// hvacInfoToMap converts HVACInfo to a map for JSON output. Temperatures are in
+// Celsius as reported, without the rounding of text output. Defroster keys are
+// omitted for vehicles that don't report them.
 func hvacInfoToMap(hvacInfo api.HVACInfo) map[string]any {
-	return map[string]any{
+	data := map[string]any{
 		"hvac_on":                hvacInfo.HVACOn,
-		"front_defroster":        hvacInfo.FrontDefroster,
-		"rear_defroster":         hvacInfo.RearDefroster,
 		"interior_temperature_c": hvacInfo.InteriorTempC,
 		"target_temperature_c":   hvacInfo.TargetTempC,
+		"mode":                   string(hvacInfo.Mode()),
 	}
+	if hvacInfo.FrontDefroster != nil {
+		data["front_defroster"] = *hvacInfo.FrontDefroster
+	}
+	if hvacInfo.RearDefroster != nil {
+		data["rear_defroster"] = *hvacInfo.RearDefroster
+	}
+	if hvacInfo.RemainingMinutes != nil {
+		data["remaining_minutes"] = *hvacInfo.RemainingMinutes
+	}
+
+	return data
 }
 
 // extractHvacData extracts HVAC data for JSON output.
@@ -154,6 +268,8 @@ func windowStatusToMap(windowsInfo api.WindowStatus) map[string]any {
 		"passenger_position":  windowsInfo.PassengerPosition,
 		"rear_left_position":  windowsInfo.RearLeftPosition,
 		"rear_right_position": windowsInfo.RearRightPosition,
+		"any_window_open":     windowsInfo.AnyOpen(),
+		"open_window_count":   windowsInfo.OpenCount(),
 	}
 }
 
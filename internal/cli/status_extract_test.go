@@ -25,6 +25,7 @@ func TestBatteryInfoToMap(t *testing.T) {
 				ChargeTimeQBCMin: 45,
 				PluggedIn:        true,
 				Charging:         true,
+				ChargeState:      api.ChargeStateCharging,
 				HeaterOn:         false,
 				HeaterAuto:       false,
 			},
@@ -33,12 +34,31 @@ func TestBatteryInfoToMap(t *testing.T) {
 				"range_km":                245.5,
 				"plugged_in":              true,
 				"charging":                true,
+				"charge_state":            "charging",
 				"heater_on":               false,
 				"heater_auto":             false,
 				"charge_time_ac_minutes":  float64(180),
 				"charge_time_qbc_minutes": float64(45),
 			},
 		},
+		{
+			name: "plugged in but paused keeps its estimates",
+			batteryInfo: api.BatteryInfo{
+				BatteryLevel:     40,
+				RangeKm:          120.0,
+				ChargeTimeACMin:  240,
+				ChargeTimeQBCMin: 60,
+				PluggedIn:        true,
+				Charging:         false,
+				ChargeState:      api.ChargeStateScheduled,
+			},
+			wantFields: map[string]any{
+				"charging":                false,
+				"charge_state":            "scheduled",
+				"charge_time_ac_minutes":  float64(240),
+				"charge_time_qbc_minutes": float64(60),
+			},
+		},
 		{
 			name: "not charging",
 			batteryInfo: api.BatteryInfo{
@@ -52,12 +72,14 @@ func TestBatteryInfoToMap(t *testing.T) {
 				HeaterAuto:       true,
 			},
 			wantFields: map[string]any{
-				"battery_level": float64(50),
-				"range_km":      150.0,
-				"plugged_in":    false,
-				"charging":      false,
-				"heater_on":     true,
-				"heater_auto":   true,
+				"battery_level":           float64(50),
+				"range_km":                150.0,
+				"plugged_in":              false,
+				"charging":                false,
+				"heater_on":               true,
+				"heater_auto":             true,
+				"charge_time_ac_minutes":  nil,
+				"charge_time_qbc_minutes": nil,
 			},
 		},
 	}
@@ -65,19 +87,13 @@ func TestBatteryInfoToMap(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			data := batteryInfoToMap(tt.batteryInfo)
+			data := batteryInfoToMap(tt.batteryInfo, distanceKm)
 
 			for key, expected := range tt.wantFields {
 				actual, ok := data[key]
 				assert.Truef(t, ok, "Expected key %q to exist in map", key)
 				assert.Equalf(t, expected, actual, "Expected %s to be %v, got %v", key, expected, actual)
 			}
-
-			// Verify charge time fields are only present when charging
-			if !tt.batteryInfo.Charging {
-				assert.NotContains(t, data, "charge_time_ac_minutes")
-				assert.NotContains(t, data, "charge_time_qbc_minutes")
-			}
 		})
 	}
 }
@@ -90,7 +106,7 @@ func TestFuelInfoToMap(t *testing.T) {
 		RangeKm:   630.0,
 	}
 
-	data := fuelInfoToMap(fuelInfo)
+	data := fuelInfoToMap(fuelInfo, distanceKm)
 
 	assertMapValue(t, data, "fuel_level", float64(92))
 	assertMapValue(t, data, "range_km", 630.0)
@@ -109,7 +125,7 @@ func TestLocationInfoToMap(t *testing.T) {
 
 	assertMapValue(t, data, "latitude", 37.7749)
 	assertMapValue(t, data, "longitude", -122.4194)
-	assertMapValue(t, data, "timestamp", "20231201120000")
+	assertMapValue(t, data, "timestamp", "2023-12-01T12:00:00Z")
 
 	mapsURL, ok := data["maps_url"].(string)
 	require.True(t, ok, "Expected maps_url to be a string")
@@ -117,6 +133,16 @@ func TestLocationInfoToMap(t *testing.T) {
 	assert.Equal(t, expectedURL, mapsURL)
 }
 
+// TestBatteryInfoToMap_LastCharged tests that last_charged is RFC3339 and omitted when unreported.
+func TestBatteryInfoToMap_LastCharged(t *testing.T) {
+	t.Parallel()
+	data := batteryInfoToMap(api.BatteryInfo{LastChargedAt: "20231129063000"}, distanceKm)
+	assertMapValue(t, data, "last_charged", "2023-11-29T06:30:00Z")
+
+	data = batteryInfoToMap(api.BatteryInfo{}, distanceKm)
+	assert.NotContains(t, data, "last_charged")
+}
+
 // TestTireInfoToMap tests tireInfoToMap conversion.
 func TestTireInfoToMap(t *testing.T) {
 	t.Parallel()
@@ -133,6 +159,14 @@ func TestTireInfoToMap(t *testing.T) {
 	assertMapValue(t, data, "front_right_psi", 32.0)
 	assertMapValue(t, data, "rear_left_psi", 31.5)
 	assertMapValue(t, data, "rear_right_psi", 31.8)
+	assert.NotContains(t, data, "front_left_temp_c")
+
+	frontLeftTemp := 28.0
+	tireInfo.FrontLeftTempC = &frontLeftTemp
+	data = tireInfoToMap(tireInfo)
+
+	assertMapValue(t, data, "front_left_temp_c", 28.0)
+	assert.NotContains(t, data, "rear_right_temp_c")
 }
 
 // TestDoorStatusToMap tests doorStatusToMap conversion.
@@ -158,6 +192,7 @@ func TestDoorStatusToMap(t *testing.T) {
 	assertMapValue(t, data, "all_locked", true)
 	assertMapValue(t, data, "driver_open", false)
 	assertMapValue(t, data, "driver_locked", true)
+	assertMapValue(t, data, "summary", "All locked")
 }
 
 // TestOdometerInfoToMap tests odometerInfoToMap conversion.
@@ -165,7 +200,7 @@ func TestOdometerInfoToMap(t *testing.T) {
 	t.Parallel()
 	odometerInfo := api.OdometerInfo{OdometerKm: 12345.6}
 
-	data := odometerInfoToMap(odometerInfo)
+	data := odometerInfoToMap(odometerInfo, distanceKm)
 
 	assertMapValue(t, data, "odometer_km", 12345.6)
 }
@@ -173,10 +208,11 @@ func TestOdometerInfoToMap(t *testing.T) {
 // TestHvacInfoToMap tests hvacInfoToMap conversion.
 func TestHvacInfoToMap(t *testing.T) {
 	t.Parallel()
+	on, off := true, false
 	hvacInfo := api.HVACInfo{
 		HVACOn:         true,
-		FrontDefroster: true,
-		RearDefroster:  false,
+		FrontDefroster: &on,
+		RearDefroster:  &off,
 		InteriorTempC:  21,
 		TargetTempC:    22,
 	}
@@ -206,16 +242,20 @@ func TestWindowStatusToMap(t *testing.T) {
 	assertMapValue(t, data, "passenger_position", float64(50))
 	assertMapValue(t, data, "rear_left_position", float64(75))
 	assertMapValue(t, data, "rear_right_position", float64(100))
+	assertMapValue(t, data, "any_window_open", true)
+	assertMapValue(t, data, "open_window_count", 4)
 }
 
 // TestExtractVehicleInfoDataHelper tests vehicle info extraction.
 func TestExtractVehicleInfoDataHelper(t *testing.T) {
 	t.Parallel()
 	info := VehicleInfo{
-		VIN:       "JM3KKEHC1R0123456",
-		Nickname:  "My CX-90",
-		ModelName: "CX-90 PHEV",
-		ModelYear: "2024",
+		VehicleInfo: api.VehicleInfo{
+			VIN:       "JM3KKEHC1R0123456",
+			Nickname:  "My CX-90",
+			ModelName: "CX-90 PHEV",
+			ModelYear: "2024",
+		},
 	}
 
 	data := extractVehicleInfoData(info)
@@ -244,7 +284,7 @@ func TestExtractWithGetter(t *testing.T) {
 
 	data := extractWithGetter(
 		vehicleStatus.GetFuelInfo,
-		fuelInfoToMap,
+		func(fuelInfo api.FuelInfo) map[string]any { return fuelInfoToMap(fuelInfo, distanceKm) },
 	)
 
 	assertMapValue(t, data, "fuel_level", float64(92))
@@ -263,7 +303,7 @@ func TestExtractWithGetterError(t *testing.T) {
 	// Should return empty map when getter fails
 	data := extractWithGetter(
 		vehicleStatus.GetFuelInfo,
-		fuelInfoToMap,
+		func(fuelInfo api.FuelInfo) map[string]any { return fuelInfoToMap(fuelInfo, distanceKm) },
 	)
 
 	assert.Empty(t, data)
@@ -1,14 +1,18 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
 	"golang.org/x/text/language"
-	"golang.org/x/text/message"
 )
 
 // formatVehicleHeader formats vehicle identification for display.
@@ -33,15 +37,117 @@ func formatVehicleHeader(vehicleInfo VehicleInfo) string {
 
 	// Add VIN line if available
 	if vehicleInfo.VIN != "" {
-		header += fmt.Sprintf("VIN: %s\n", vehicleInfo.VIN)
+		header += fmt.Sprintf("VIN: %s\n", vehicleInfo.displayVIN())
 	}
 
 	return header
 }
 
-// toJSON converts a map to formatted JSON string.
-func toJSON(data map[string]any) (string, error) {
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+// formatVehicleDetails formats the extended vehicle details shown by --verbose.
+// Lines for details the vehicle doesn't report are omitted.
+func formatVehicleDetails(vehicleInfo VehicleInfo) string {
+	var details string
+
+	if vehicleInfo.Carline != "" {
+		details += "Trim: " + vehicleInfo.Carline
+		if vehicleInfo.ModelCode != "" {
+			details += fmt.Sprintf(" (%s)", vehicleInfo.ModelCode)
+		}
+		details += "\n"
+	} else if vehicleInfo.ModelCode != "" {
+		details += fmt.Sprintf("Model code: %s\n", vehicleInfo.ModelCode)
+	}
+
+	if vehicleInfo.ExteriorColor != "" {
+		details += "Color: " + vehicleInfo.ExteriorColor
+		if vehicleInfo.InteriorColor != "" {
+			details += fmt.Sprintf(" / %s interior", vehicleInfo.InteriorColor)
+		}
+		details += "\n"
+	}
+
+	if vehicleInfo.Transmission != "" {
+		details += fmt.Sprintf("Transmission: %s\n", vehicleInfo.Transmission)
+	}
+
+	if vehicleInfo.EconnectType != api.EconnectTypeUnknown {
+		details += fmt.Sprintf("Connectivity: %s\n", vehicleInfo.EconnectType)
+	}
+
+	return details
+}
+
+// outputFormat selects how formatters render their result.
+type outputFormat int
+
+const (
+	// outputText is human-readable text.
+	outputText outputFormat = iota
+	// outputJSON is indented JSON.
+	outputJSON
+	// outputJSONCompact is single-line JSON, for log ingestion and small payloads.
+	outputJSONCompact
+	// outputTable is plain text in aligned columns, for --output table.
+	outputTable
+	// outputPlain is a subcommand's primary value alone, for --plain.
+	outputPlain
+)
+
+// newOutputFormat resolves the --json and --json-compact flags; --json-compact implies --json.
+// --json output is also compact when the global --no-pretty flag is set.
+func newOutputFormat(ctx context.Context, jsonOutput, jsonCompact bool) outputFormat {
+	switch {
+	case jsonCompact, jsonOutput && !prettyFromContext(ctx):
+		return outputJSONCompact
+	case jsonOutput:
+		return outputJSON
+	default:
+		return outputText
+	}
+}
+
+// prettyFromContext reports whether structured output should be indented, i.e.
+// --no-pretty (or --pretty=false) wasn't given.
+func prettyFromContext(ctx context.Context) bool {
+	cfg := ConfigFromContext(ctx)
+
+	return cfg == nil || !cfg.NoPretty
+}
+
+// isJSON reports whether the format is either JSON variant.
+func (f outputFormat) isJSON() bool {
+	return f == outputJSON || f == outputJSONCompact
+}
+
+// addJSONFlags registers the --json and --json-compact flags on a command.
+func addJSONFlags(cmd *cobra.Command, jsonOutput, jsonCompact *bool) {
+	cmd.Flags().BoolVar(jsonOutput, "json", false, "output in JSON format")
+	cmd.Flags().BoolVar(jsonCompact, "json-compact", false, "output single-line JSON (implies --json)")
+}
+
+// addPlainFlag registers the --plain flag on a status subcommand with a primary value.
+func addPlainFlag(cmd *cobra.Command, plain *bool) {
+	cmd.Flags().BoolVar(plain, "plain", false, "print only the value, without labels, e.g. for scripts")
+	cmd.MarkFlagsMutuallyExclusive("plain", "json")
+	cmd.MarkFlagsMutuallyExclusive("plain", "json-compact")
+}
+
+// formatPlainValue formats a --plain value with at most one decimal and no
+// grouping, units, or locale, so that scripts can parse it (e.g. 80, 12345.6).
+func formatPlainValue(value float64) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64)
+}
+
+// toJSON marshals data as JSON, indented or on a single line depending on format.
+// All JSON output goes through here so the choice is consistent across commands.
+func toJSON(data any, format outputFormat) (string, error) {
+	var jsonBytes []byte
+	var err error
+	if format == outputJSONCompact {
+		jsonBytes, err = json.Marshal(data)
+	} else {
+		jsonBytes, err = json.MarshalIndent(data, "", "  ")
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal JSON: %w", err)
 	}
@@ -49,19 +155,48 @@ func toJSON(data map[string]any) (string, error) {
 	return string(jsonBytes), nil
 }
 
-// getChargingStatusFlag returns the charging status flag string.
-func getChargingStatusFlag(charging bool, chargeTimeACMin, chargeTimeQBCMin float64) string {
-	if !charging {
-		return "plugged in, not charging"
+// getChargingStatusFlag returns the charging status flag string. An interrupted
+// charge is shown in red, since the car won't be charged when expected.
+func getChargingStatusFlag(batteryInfo api.BatteryInfo) string {
+	if !batteryInfo.Charging {
+		switch batteryInfo.ChargeState {
+		case api.ChargeStateComplete:
+			return "plugged in, charge complete"
+		case api.ChargeStateInterrupted:
+			return "plugged in, " + Red("charge interrupted")
+		case api.ChargeStateScheduled:
+			return "plugged in, waiting for scheduled charge"
+		case api.ChargeStateNotCharging, "":
+			return "plugged in, not charging"
+		default:
+			return "plugged in, charge state " + string(batteryInfo.ChargeState)
+		}
+	}
+
+	charging := "charging"
+	rate := batteryInfo.ChargeRate
+	switch {
+	case rate.PowerKW > 0 && rate.Type != "":
+		charging += fmt.Sprintf(" at %.1f kW (%s)", rate.PowerKW, rate.Type)
+	case rate.PowerKW > 0:
+		charging += fmt.Sprintf(" at %.1f kW", rate.PowerKW)
+	case rate.Type != "":
+		charging += fmt.Sprintf(" (%s)", rate.Type)
 	}
 
-	// Show charging time estimates
-	timeStr := formatChargeTime(chargeTimeACMin, chargeTimeQBCMin)
-	if timeStr != "" {
-		return "charging, " + timeStr
+	// Show charging time estimates, only for the charger in use when it's known.
+	acMinutes, qbcMinutes := batteryInfo.ChargeTimeACMin, batteryInfo.ChargeTimeQBCMin
+	switch rate.Type {
+	case "AC":
+		qbcMinutes = 0
+	case "DC":
+		acMinutes = 0
+	}
+	if timeStr := formatChargeTime(acMinutes, qbcMinutes); timeStr != "" {
+		return charging + ", " + timeStr
 	}
 
-	return "charging"
+	return charging
 }
 
 // getHeaterStatusFlag returns the heater status flag string.
@@ -84,7 +219,7 @@ func buildBatteryStatusFlags(batteryInfo api.BatteryInfo) []string {
 	var flags []string
 
 	if batteryInfo.PluggedIn {
-		flags = append(flags, getChargingStatusFlag(batteryInfo.Charging, batteryInfo.ChargeTimeACMin, batteryInfo.ChargeTimeQBCMin))
+		flags = append(flags, getChargingStatusFlag(batteryInfo))
 	}
 
 	// Add heater status
@@ -95,15 +230,18 @@ func buildBatteryStatusFlags(batteryInfo api.BatteryInfo) []string {
 	return flags
 }
 
-// formatBatteryStatus formats battery status for display.
-func formatBatteryStatus(batteryInfo api.BatteryInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(batteryInfoToMap(batteryInfo))
+// formatBatteryStatus formats battery status for display with a barWidth-segment level bar.
+func formatBatteryStatus(batteryInfo api.BatteryInfo, format outputFormat, barWidth int, unit distanceUnit, locale language.Tag) (string, error) {
+	if format.isJSON() {
+		return toJSON(batteryInfoToMap(batteryInfo, unit), format)
+	}
+	if format == outputPlain {
+		return formatPlainValue(batteryInfo.BatteryLevel), nil
 	}
 
 	// Create progress bar and format percentage/range
-	progressBar := ProgressBar(batteryInfo.BatteryLevel, 10)
-	status := fmt.Sprintf("BATTERY: %s (%.1f km range)", progressBar, batteryInfo.RangeKm)
+	progressBar := ProgressBar(batteryInfo.BatteryLevel, barWidth)
+	status := fmt.Sprintf("BATTERY: %s (%s %s range)", progressBar, formatNumber(unit.fromKm(batteryInfo.RangeKm), 1, locale), unit)
 
 	// Build status flags
 	flags := buildBatteryStatusFlags(batteryInfo)
@@ -112,23 +250,151 @@ func formatBatteryStatus(batteryInfo api.BatteryInfo, jsonOutput bool) (string,
 		status += fmt.Sprintf(" [%s]", strings.Join(flags, ", "))
 	}
 
+	if lastCharged, ok := parseAPITimestamp(batteryInfo.LastChargedAt); ok {
+		status += "\n  Last charged " + formatRelativeTime(lastCharged)
+	}
+
 	return status, nil
 }
 
+// formatAuxBatteryStatus formats the 12V battery voltage for the full status,
+// e.g. "12V: 12.4V (OK)", flagging it in red when low.
+func formatAuxBatteryStatus(auxBattery api.AuxBatteryInfo) string {
+	state := "OK"
+	if auxBattery.Low() {
+		state = Red("Low")
+	}
+
+	return fmt.Sprintf("12V: %.1fV (%s)", auxBattery.VoltageV, state)
+}
+
+// formatBatteryHealth formats the estimated battery state of health for display.
+// Vehicles that don't report it get an explicit "not reported" message rather than a guess.
+func formatBatteryHealth(evStatus *api.EVVehicleStatusResponse, format outputFormat) (string, error) {
+	health, err := evStatus.GetBatteryHealth()
+	available := true
+	if errors.Is(err, api.ErrBatteryHealthUnavailable) {
+		available = false
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get battery health: %w", err)
+	}
+
+	if format.isJSON() {
+		data := map[string]any{"available": available}
+		if available {
+			data["state_of_health_percent"] = health
+			data["estimated"] = true
+		}
+
+		return toJSON(data, format)
+	}
+
+	if !available {
+		return "BATTERY HEALTH: " + err.Error(), nil
+	}
+
+	return fmt.Sprintf("BATTERY HEALTH: %.0f%% (estimated)\n"+
+		"  Note: reported by the vehicle's battery management system; not a diagnostic measurement", health), nil
+}
+
 // formatFuelStatus formats fuel status for display.
-func formatFuelStatus(fuelInfo api.FuelInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(fuelInfoToMap(fuelInfo))
+func formatFuelStatus(fuelInfo api.FuelInfo, format outputFormat, unit distanceUnit, locale language.Tag) (string, error) {
+	if format.isJSON() {
+		return toJSON(fuelInfoToMap(fuelInfo, unit), format)
 	}
 
 	progressBar := ProgressBar(fuelInfo.FuelLevel, 10)
 
-	return fmt.Sprintf("FUEL: %s (%.1f km range)", progressBar, fuelInfo.RangeKm), nil
+	return fmt.Sprintf("FUEL: %s (%s %s range)", progressBar, formatNumber(unit.fromKm(fuelInfo.RangeKm), 1, locale), unit) + lowFuelWarning(fuelInfo), nil
+}
+
+// lowFuelWarning returns the " ⚠ low" marker shown after the fuel line while the
+// vehicle's low-fuel warning lamp is on, or "" otherwise.
+func lowFuelWarning(fuelInfo api.FuelInfo) string {
+	if fuelInfo.APILowFuel == nil || !*fuelInfo.APILowFuel {
+		return ""
+	}
+
+	return " " + Red("⚠ low")
+}
+
+// fuelEstimateOptions holds the optional thresholds for the status fuel view.
+// Zero values turn the corresponding output off.
+type fuelEstimateOptions struct {
+	warnLevel     float64 // fuel percent at or below which fuel is flagged low
+	tankLiters    float64 // tank capacity, for the fill estimate
+	pricePerLiter float64 // fuel price, for the cost estimate
+}
+
+// validate rejects out-of-range values and a price without a tank size.
+func (o fuelEstimateOptions) validate() error {
+	if o.warnLevel < 0 || o.warnLevel > 100 {
+		return fmt.Errorf("--fuel-warn must be between 0 and 100, got %g", o.warnLevel)
+	}
+	if o.tankLiters < 0 {
+		return fmt.Errorf("--tank-size must not be negative, got %g", o.tankLiters)
+	}
+	if o.pricePerLiter < 0 {
+		return fmt.Errorf("--fuel-price must not be negative, got %g", o.pricePerLiter)
+	}
+	if o.pricePerLiter > 0 && o.tankLiters == 0 {
+		return errors.New("--fuel-price requires --tank-size")
+	}
+
+	return nil
+}
+
+// isLow reports whether level is at or below the warning threshold.
+func (o fuelEstimateOptions) isLow(level float64) bool {
+	return o.warnLevel > 0 && level <= o.warnLevel
+}
+
+// litersToFill estimates the liters needed to fill the tank from level percent.
+func (o fuelEstimateOptions) litersToFill(level float64) float64 {
+	return o.tankLiters * max(0, 100-level) / 100
+}
+
+// formatFuelReport formats fuel status with the optional low-fuel warning and
+// fill estimate. The first line matches formatFuelStatus.
+func formatFuelReport(fuelInfo api.FuelInfo, format outputFormat, unit distanceUnit, locale language.Tag, opts fuelEstimateOptions) (string, error) {
+	if format == outputPlain {
+		return formatPlainValue(fuelInfo.FuelLevel), nil
+	}
+	liters := opts.litersToFill(fuelInfo.FuelLevel)
+
+	if format.isJSON() {
+		data := fuelInfoToMap(fuelInfo, unit)
+		data["low_fuel"] = opts.isLow(fuelInfo.FuelLevel)
+		if opts.tankLiters > 0 {
+			data["liters_to_fill"] = liters
+		}
+		if opts.pricePerLiter > 0 {
+			data["cost_to_fill"] = liters * opts.pricePerLiter
+		}
+
+		return toJSON(data, format)
+	}
+
+	output, err := formatFuelStatus(fuelInfo, format, unit, locale)
+	if err != nil {
+		return "", err
+	}
+	if opts.isLow(fuelInfo.FuelLevel) {
+		output += " " + Red("LOW FUEL")
+	}
+	if opts.tankLiters > 0 {
+		output += fmt.Sprintf("\nTO FILL: %.1f L", liters)
+		if opts.pricePerLiter > 0 {
+			output += fmt.Sprintf(" (about %.2f)", liters*opts.pricePerLiter)
+		}
+	}
+
+	return output, nil
 }
 
 // formatBatteryStatusCompact formats battery status without range (for combined view).
-func formatBatteryStatusCompact(batteryInfo api.BatteryInfo) string {
-	progressBar := ProgressBar(batteryInfo.BatteryLevel, 10)
+func formatBatteryStatusCompact(batteryInfo api.BatteryInfo, barWidth int) string {
+	progressBar := ProgressBar(batteryInfo.BatteryLevel, barWidth)
 	status := "BATTERY: " + progressBar
 
 	// Build status flags
@@ -144,33 +410,77 @@ func formatBatteryStatusCompact(batteryInfo api.BatteryInfo) string {
 // formatFuelStatusWithRange formats fuel status with range display for PHEVs
 // For PHEVs: RemDrvDistDActlKm (fuel API) = total range, SmaphRemDrvDistKm (EV API) = fuel-only range
 // EV range = total - fuel-only.
-func formatFuelStatusWithRange(fuelInfo api.FuelInfo, batteryInfo api.BatteryInfo) string {
+func formatFuelStatusWithRange(fuelInfo api.FuelInfo, batteryInfo api.BatteryInfo, unit distanceUnit, locale language.Tag) string {
 	progressBar := ProgressBar(fuelInfo.FuelLevel, 10)
 	// Calculate EV range as difference between total and fuel-only
 	// batteryInfo.RangeKm represents the fuel-only range for PHEVs
 	evRange := fuelInfo.RangeKm - batteryInfo.RangeKm
 	if evRange > 0.5 { // Only show EV range if meaningful (> 0.5 km)
-		return fmt.Sprintf("FUEL: %s (%.0f km EV + %.0f km fuel = %.0f km total)",
-			progressBar, evRange, batteryInfo.RangeKm, fuelInfo.RangeKm)
+		return fmt.Sprintf("FUEL: %s (%s %s EV + %s %s fuel = %s %s total)",
+			progressBar,
+			formatNumber(unit.fromKm(evRange), 0, locale), unit,
+			formatNumber(unit.fromKm(batteryInfo.RangeKm), 0, locale), unit,
+			formatNumber(unit.fromKm(fuelInfo.RangeKm), 0, locale), unit) + lowFuelWarning(fuelInfo)
 	}
 
-	return fmt.Sprintf("FUEL: %s (%.1f km range)", progressBar, fuelInfo.RangeKm)
+	return fmt.Sprintf("FUEL: %s (%s %s range)", progressBar, formatNumber(unit.fromKm(fuelInfo.RangeKm), 1, locale), unit) + lowFuelWarning(fuelInfo)
 }
 
-// formatLocationStatus formats location status for display.
-func formatLocationStatus(locationInfo api.LocationInfo, jsonOutput bool) (string, error) {
+// formatLocationStatus formats location status for display. A non-empty address,
+// from status location --address, and the nearest saved place, if any, are shown
+// beneath the coordinates and added to JSON output.
+func formatLocationStatus(locationInfo api.LocationInfo, format outputFormat, address string, place *placeProximity) (string, error) {
 	mapsURL := fmt.Sprintf("https://maps.google.com/?q=%f,%f", locationInfo.Latitude, locationInfo.Longitude)
-	if jsonOutput {
-		return toJSON(locationInfoToMap(locationInfo))
+	if format.isJSON() {
+		data := locationInfoToMap(locationInfo)
+		if address != "" {
+			data["address"] = address
+		}
+		if place != nil {
+			data["place"] = place.toMap()
+		}
+
+		return toJSON(data, format)
+	}
+
+	output := fmt.Sprintf("LOCATION: %.6f, %.6f\n", locationInfo.Latitude, locationInfo.Longitude)
+	if address != "" {
+		output += "  " + address + "\n"
+	}
+	if place != nil {
+		output += "  " + place.String() + "\n"
+	}
+	if motion := formatMotion(locationInfo); motion != "" {
+		output += "  " + motion + "\n"
+	}
+
+	return output + "  " + mapsURL, nil
+}
+
+// formatMotion formats heading and speed (e.g. "heading 135°, 0 km/h — parked"),
+// leaving out whichever the vehicle didn't report.
+func formatMotion(locationInfo api.LocationInfo) string {
+	var parts []string
+	if locationInfo.Heading != nil {
+		parts = append(parts, fmt.Sprintf("heading %.0f°", *locationInfo.Heading))
+	}
+	if locationInfo.SpeedKmh != nil {
+		parts = append(parts, fmt.Sprintf("%.0f km/h", *locationInfo.SpeedKmh))
 	}
 
-	return fmt.Sprintf("LOCATION: %.6f, %.6f\n  %s", locationInfo.Latitude, locationInfo.Longitude, mapsURL), nil
+	motion := strings.Join(parts, ", ")
+	if locationInfo.SpeedKmh != nil && *locationInfo.SpeedKmh == 0 {
+		motion += " — parked"
+	}
+
+	return motion
 }
 
-// formatTiresStatus formats tire status for display.
-func formatTiresStatus(tireInfo api.TireInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(tireInfoToMap(tireInfo))
+// formatTiresStatus formats tire status for display. When the vehicle reports
+// tire temperatures they are shown after each pressure in tempUnit.
+func formatTiresStatus(tireInfo api.TireInfo, format outputFormat, tempUnit api.TemperatureUnit) (string, error) {
+	if format.isJSON() {
+		return toJSON(tireInfoToMap(tireInfo), format)
 	}
 
 	// Color code each tire pressure based on deviation from recommended (36 PSI for Mazda CX-90)
@@ -180,51 +490,151 @@ func formatTiresStatus(tireInfo api.TireInfo, jsonOutput bool) (string, error) {
 	rl := ColorPressure(tireInfo.RearLeftPsi, target)
 	rr := ColorPressure(tireInfo.RearRightPsi, target)
 
-	return fmt.Sprintf("TIRES: FL:%s FR:%s RL:%s RR:%s PSI", fl, fr, rl, rr), nil
+	if !tireInfo.HasTemperatures() {
+		return fmt.Sprintf("TIRES: FL:%s FR:%s RL:%s RR:%s PSI", fl, fr, rl, rr), nil
+	}
+
+	return fmt.Sprintf("TIRES: FL:%s FR:%s RL:%s RR:%s",
+		formatTire(fl, tireInfo.FrontLeftTempC, tempUnit),
+		formatTire(fr, tireInfo.FrontRightTempC, tempUnit),
+		formatTire(rl, tireInfo.RearLeftTempC, tempUnit),
+		formatTire(rr, tireInfo.RearRightTempC, tempUnit)), nil
 }
 
-// doorPosition describes a single door position for status checking.
-type doorPosition struct {
-	name     string
-	isOpen   bool
-	isLocked bool
-	hasLock  bool // trunk/hood/fuel lid don't have locks
+// formatTire formats one tire as "32.5psi 28°C", leaving out an unreported temperature.
+func formatTire(pressure string, tempC *float64, tempUnit api.TemperatureUnit) string {
+	if tempC == nil {
+		return pressure + "psi"
+	}
+
+	return pressure + "psi " + formatTemperature(*tempC, tempUnit)
 }
 
-// formatDoorsStatus formats door status for display.
-func formatDoorsStatus(doorStatus api.DoorStatus, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(doorStatusToMap(doorStatus))
+// tirePressureTargets are the recommended pressures given to mcs status tires.
+type tirePressureTargets struct {
+	frontPSI float64 // recommended front pressure; 0 if not given
+	rearPSI  float64 // recommended rear pressure; 0 if not given
+}
+
+// validate rejects negative pressures.
+func (t tirePressureTargets) validate() error {
+	if t.frontPSI < 0 {
+		return fmt.Errorf("--front-psi must not be negative, got %g", t.frontPSI)
+	}
+	if t.rearPSI < 0 {
+		return fmt.Errorf("--rear-psi must not be negative, got %g", t.rearPSI)
 	}
 
-	// If all locked and closed, show simple message
-	if doorStatus.AllLocked {
-		return "DOORS: " + Green("All locked"), nil
+	return nil
+}
+
+// tirePosition is one tire as shown by mcs status tires.
+type tirePosition struct {
+	label  string   // e.g. "FL"
+	key    string   // JSON key prefix, e.g. "front_left"
+	psi    float64  // reported pressure
+	tempC  *float64 // reported temperature; nil if not reported
+	target float64  // recommended pressure; 0 if not given
+}
+
+// delta formats the difference from the recommended pressure, e.g. "-1.5".
+func (p tirePosition) delta() string {
+	return fmt.Sprintf("%+.1f", p.psi-p.target)
+}
+
+// tirePositions lists the tires in reading order: FL, FR, RL, RR.
+func tirePositions(tireInfo api.TireInfo, targets tirePressureTargets) []tirePosition {
+	return []tirePosition{
+		{label: "FL", key: "front_left", psi: tireInfo.FrontLeftPsi, tempC: tireInfo.FrontLeftTempC, target: targets.frontPSI},
+		{label: "FR", key: "front_right", psi: tireInfo.FrontRightPsi, tempC: tireInfo.FrontRightTempC, target: targets.frontPSI},
+		{label: "RL", key: "rear_left", psi: tireInfo.RearLeftPsi, tempC: tireInfo.RearLeftTempC, target: targets.rearPSI},
+		{label: "RR", key: "rear_right", psi: tireInfo.RearRightPsi, tempC: tireInfo.RearRightTempC, target: targets.rearPSI},
+	}
+}
+
+// formatTiresReport formats the output of mcs status tires: the TIRES line, or a
+// 2×2 table with outputTable, with each tire's difference from the recommended
+// pressure when targets are given.
+func formatTiresReport(tireInfo api.TireInfo, format outputFormat, tempUnit api.TemperatureUnit, targets tirePressureTargets) (string, error) {
+	positions := tirePositions(tireInfo, targets)
+
+	switch {
+	case format.isJSON():
+		data := tireInfoToMap(tireInfo)
+		for _, p := range positions {
+			if p.target > 0 {
+				data[p.key+"_delta_psi"] = p.psi - p.target
+			}
+		}
+
+		return toJSON(data, format)
+	case format == outputTable:
+		return formatTiresTable(positions, tempUnit, tireInfo.HasTemperatures()), nil
 	}
 
-	// Define all door positions to check
-	doors := []doorPosition{
-		{"Driver", doorStatus.DriverOpen, doorStatus.DriverLocked, true},
-		{"Passenger", doorStatus.PassengerOpen, doorStatus.PassengerLocked, true},
-		{"Rear left", doorStatus.RearLeftOpen, doorStatus.RearLeftLocked, true},
-		{"Rear right", doorStatus.RearRightOpen, doorStatus.RearRightLocked, true},
-		{"Trunk", doorStatus.TrunkOpen, false, false},
-		{"Hood", doorStatus.HoodOpen, false, false},
-		{"Fuel lid", doorStatus.FuelLidOpen, false, false},
+	output, err := formatTiresStatus(tireInfo, outputText, tempUnit)
+	if err != nil {
+		return "", err
+	}
+	var deltas []string
+	for _, p := range positions {
+		if p.target > 0 {
+			deltas = append(deltas, p.label+":"+p.delta())
+		}
+	}
+	if len(deltas) > 0 {
+		output += "\nVS RECOMMENDED: " + strings.Join(deltas, " ") + " PSI"
 	}
 
-	// Build a list of issues
-	var issues []string
+	return output, nil
+}
 
-	for _, door := range doors {
-		// Check unlocked doors (closed but not locked)
-		if door.hasLock && !door.isLocked && !door.isOpen {
-			issues = append(issues, Yellow(door.name+" unlocked"))
+// formatTiresTable lays the tires out as they sit on the car, front row first,
+// with columns for the delta and temperature only when there is something to show.
+func formatTiresTable(positions []tirePosition, tempUnit api.TemperatureUnit, hasTemps bool) string {
+	cells := func(p tirePosition) []string {
+		row := []string{p.label, fmt.Sprintf("%.1f psi", p.psi)}
+		if positions[0].target > 0 || positions[2].target > 0 {
+			delta := ""
+			if p.target > 0 {
+				delta = "(" + p.delta() + ")"
+			}
+			row = append(row, delta)
+		}
+		if hasTemps {
+			temp := "-"
+			if p.tempC != nil {
+				temp = formatTemperature(*p.tempC, tempUnit)
+			}
+			row = append(row, temp)
 		}
 
-		// Check open doors/trunk/hood/fuel lid
-		if door.isOpen {
-			issues = append(issues, Red(door.name+" open"))
+		return row
+	}
+
+	return formatTable([][]string{
+		append(cells(positions[0]), cells(positions[1])...),
+		append(cells(positions[2]), cells(positions[3])...),
+	})
+}
+
+// formatDoorsStatus formats door status for display.
+func formatDoorsStatus(doorStatus api.DoorStatus, format outputFormat, th theme) (string, error) {
+	if format.isJSON() {
+		return toJSON(doorStatusToMap(doorStatus), format)
+	}
+
+	// If all locked and closed, show simple message
+	if doorStatus.AllLocked {
+		return "DOORS: " + Green(withSymbol(th.locked, "All locked")), nil
+	}
+
+	var issues []string
+	for _, issue := range doorStatus.Issues() {
+		if issue.Open {
+			issues = append(issues, Red(withSymbol(th.open, issue.String())))
+		} else {
+			issues = append(issues, Yellow(withSymbol(th.unlocked, issue.String())))
 		}
 	}
 
@@ -236,45 +646,95 @@ func formatDoorsStatus(doorStatus api.DoorStatus, jsonOutput bool) (string, erro
 }
 
 // formatOdometerStatus formats odometer status for display.
-func formatOdometerStatus(odometerInfo api.OdometerInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(odometerInfoToMap(odometerInfo))
+func formatOdometerStatus(odometerInfo api.OdometerInfo, format outputFormat, unit distanceUnit, locale language.Tag) (string, error) {
+	if format.isJSON() {
+		return toJSON(odometerInfoToMap(odometerInfo, unit), format)
+	}
+	if format == outputPlain {
+		return formatPlainValue(unit.fromKm(odometerInfo.OdometerKm)), nil
+	}
+
+	return fmt.Sprintf("ODOMETER: %s %s", formatNumber(unit.fromKm(odometerInfo.OdometerKm), 1, locale), unit), nil
+}
+
+// formatTripStatus formats the trip meter for display, listing only the values
+// the vehicle reports. Averages are shown in the vehicle's own display units.
+func formatTripStatus(trip *api.TripInfo, unit distanceUnit, locale language.Tag) string {
+	if trip == nil {
+		return "TRIP: Not reported by this vehicle"
+	}
+
+	var parts []string
+	if trip.DistanceKm != nil {
+		parts = append(parts, fmt.Sprintf("%s %s", formatNumber(unit.fromKm(*trip.DistanceKm), 1, locale), unit))
+	}
+	if trip.DriveTimeMinutes != nil {
+		if duration := formatMinutesDuration(*trip.DriveTimeMinutes); duration != "" {
+			parts = append(parts, duration+" driving")
+		}
+	}
+	if trip.FuelUsedLiters != nil {
+		parts = append(parts, formatNumber(*trip.FuelUsedLiters, 1, locale)+" L fuel used")
+	}
+	if trip.AvgFuelEconomy != nil {
+		parts = append(parts, "avg fuel economy "+formatNumber(*trip.AvgFuelEconomy, 1, locale))
+	}
+	if trip.AvgEnergyEconomy != nil {
+		parts = append(parts, "avg energy economy "+formatNumber(*trip.AvgEnergyEconomy, 1, locale))
+	}
+	if len(parts) == 0 {
+		return "TRIP: Not reported by this vehicle"
 	}
 
-	return fmt.Sprintf("ODOMETER: %s km", formatThousands(odometerInfo.OdometerKm)), nil
+	return "TRIP: " + strings.Join(parts, ", ")
 }
 
-// formatHvacStatus formats HVAC status for display.
-func formatHvacStatus(hvacInfo api.HVACInfo, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(hvacInfoToMap(hvacInfo))
+// formatHvacStatus formats HVAC status for display. Temperatures are shown in
+// tempUnit; the interior temperature is rounded to whole degrees, while a Celsius
+// target keeps its half degree (e.g. 21.5°C) in the locale's decimal format.
+func formatHvacStatus(hvacInfo api.HVACInfo, format outputFormat, tempUnit api.TemperatureUnit, locale language.Tag) (string, error) {
+	if format.isJSON() {
+		return toJSON(hvacInfoToMap(hvacInfo), format)
 	}
 
+	interior := formatTemperature(hvacInfo.InteriorTempC, tempUnit)
 	var status string
 	if hvacInfo.HVACOn {
 		// Show current temp → target temp when HVAC is on and temps differ
 		if hvacInfo.TargetTempC > 0 && hvacInfo.TargetTempC != hvacInfo.InteriorTempC {
-			status = fmt.Sprintf("CLIMATE: On, %.0f°C → %.0f°C", hvacInfo.InteriorTempC, hvacInfo.TargetTempC)
+			status = fmt.Sprintf("CLIMATE: On, %s → %s", interior, formatTargetTemperature(hvacInfo.TargetTempC, tempUnit, locale))
 		} else {
-			status = fmt.Sprintf("CLIMATE: On, %.0f°C", hvacInfo.InteriorTempC)
+			status = "CLIMATE: On, " + interior
 		}
 	} else {
-		status = fmt.Sprintf("CLIMATE: Off, %.0f°C", hvacInfo.InteriorTempC)
+		status = "CLIMATE: Off, " + interior
 	}
 
 	// Build defroster status
 	var defrosters []string
-	if hvacInfo.FrontDefroster {
+	if hvacInfo.FrontDefrosterOn() {
 		defrosters = append(defrosters, "front")
 	}
-	if hvacInfo.RearDefroster {
+	if hvacInfo.RearDefrosterOn() {
 		defrosters = append(defrosters, "rear")
 	}
 
+	var notes []string
+	if hvacInfo.RemainingMinutes != nil {
+		if remaining := formatMinutesDuration(*hvacInfo.RemainingMinutes); remaining != "" {
+			notes = append(notes, "~"+remaining+" remaining")
+		}
+	}
+	if mode := hvacInfo.Mode(); mode != api.HVACModeIdle {
+		notes = append(notes, string(mode))
+	}
 	if len(defrosters) == 2 {
-		status += " (front and rear defrosters on)"
+		notes = append(notes, "front and rear defrosters on")
 	} else if len(defrosters) == 1 {
-		status += fmt.Sprintf(" (%s defroster on)", defrosters[0])
+		notes = append(notes, defrosters[0]+" defroster on")
+	}
+	if len(notes) > 0 {
+		status += " (" + strings.Join(notes, ", ") + ")"
 	}
 
 	return status, nil
@@ -315,27 +775,66 @@ func formatRelativeTime(t time.Time) string {
 	}
 }
 
+// Styles accepted by --timestamp-format.
+const (
+	timestampFormatDefault = "default"
+	timestampFormatISO8601 = "iso8601"
+)
+
+// parseAPITimestamp parses an API timestamp in YYYYMMDDHHmmss format.
+func parseAPITimestamp(timestamp string) (time.Time, bool) {
+	if len(timestamp) != 14 {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse("20060102150405", timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
 // formatTimestamp converts timestamp from API format to readable format with relative time.
 func formatTimestamp(timestamp string) string {
 	// API returns timestamp in format: YYYYMMDDHHmmss
 	// Convert to: YYYY-MM-DD HH:mm:ss (X ago)
-	if len(timestamp) != 14 {
+	t, ok := parseAPITimestamp(timestamp)
+	if !ok {
 		return timestamp
 	}
 
-	t, err := time.Parse("20060102150405", timestamp)
-	if err != nil {
+	return fmt.Sprintf("%s (%s)", t.Format("2006-01-02 15:04:05"), formatRelativeTime(t))
+}
+
+// formatTimestampRFC3339 converts an API timestamp to RFC3339 for structured output,
+// returning the raw value unchanged if it isn't a valid API timestamp.
+func formatTimestampRFC3339(timestamp string) string {
+	t, ok := parseAPITimestamp(timestamp)
+	if !ok {
 		return timestamp
 	}
 
-	return fmt.Sprintf("%s (%s)", t.Format("2006-01-02 15:04:05"), formatRelativeTime(t))
+	return t.Format(time.RFC3339)
 }
 
-// formatThousands formats a float with comma separators for thousands.
-func formatThousands(value float64) string {
-	p := message.NewPrinter(language.English)
+// formatTimestampStyle formats an API timestamp in a --timestamp-format style.
+func formatTimestampStyle(timestamp, style string) string {
+	if style == timestampFormatISO8601 {
+		return formatTimestampRFC3339(timestamp)
+	}
+
+	return formatTimestamp(timestamp)
+}
 
-	return p.Sprintf("%.1f", value)
+// validateTimestampFormat rejects unknown --timestamp-format values.
+func validateTimestampFormat(style string) error {
+	switch style {
+	case timestampFormatDefault, timestampFormatISO8601:
+		return nil
+	default:
+		return fmt.Errorf("--timestamp-format must be %q or %q, got %q", timestampFormatDefault, timestampFormatISO8601, style)
+	}
 }
 
 // formatMinutesDuration formats minutes as "Xh Ym" or "Xm".
@@ -392,9 +891,13 @@ type windowPosition struct {
 }
 
 // formatWindowsStatus formats window status for display.
-func formatWindowsStatus(windowsInfo api.WindowStatus, jsonOutput bool) (string, error) {
-	if jsonOutput {
-		return toJSON(windowStatusToMap(windowsInfo))
+func formatWindowsStatus(windowsInfo api.WindowStatus, format outputFormat) (string, error) {
+	if format.isJSON() {
+		return toJSON(windowStatusToMap(windowsInfo), format)
+	}
+
+	if !windowsInfo.AnyOpen() {
+		return "WINDOWS: " + Green("All closed"), nil
 	}
 
 	// Define all windows to check
@@ -413,9 +916,5 @@ func formatWindowsStatus(windowsInfo api.WindowStatus, jsonOutput bool) (string,
 		}
 	}
 
-	if len(openWindows) == 0 {
-		return "WINDOWS: " + Green("All closed"), nil
-	}
-
 	return "WINDOWS: " + strings.Join(openWindows, ", "), nil
 }
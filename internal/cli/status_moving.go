@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+)
+
+// defaultMovingSampleSeconds is how long status location --poll-moving waits
+// between its two location reads, unless --poll-moving-interval is given.
+const defaultMovingSampleSeconds = 10
+
+// movingThresholdKmh is the estimated speed below which the vehicle is reported
+// stationary, so that GPS jitter on a parked vehicle isn't taken for movement.
+const movingThresholdKmh = 3.0
+
+// locationSample is one location read and when it was made.
+type locationSample struct {
+	location api.LocationInfo
+	readAt   time.Time
+}
+
+// motionEstimate is the movement inferred from two location samples.
+type motionEstimate struct {
+	distanceKm float64
+	elapsed    time.Duration
+	// noNewPosition is set when the second read returned the same position as the
+	// first, as it does for a parked vehicle, which doesn't report new positions.
+	noNewPosition bool
+	positionAt    time.Time // when that position was reported; zero if unknown
+}
+
+// speedKmh returns the average speed between the two samples.
+func (m motionEstimate) speedKmh() float64 {
+	if m.noNewPosition {
+		return 0
+	}
+
+	return m.distanceKm / m.elapsed.Hours()
+}
+
+// moving reports whether the vehicle appears to be in motion.
+func (m motionEstimate) moving() bool {
+	return m.speedKmh() >= movingThresholdKmh
+}
+
+// estimateMotion infers movement from two location samples. The elapsed time is
+// taken from the positions' own timestamps when both are reported, and from when
+// they were read otherwise. A position that didn't change between the reads is
+// taken as stationary, whether or not it has a timestamp.
+func estimateMotion(first, second locationSample) motionEstimate {
+	estimate := motionEstimate{
+		distanceKm: haversineKm(first.location.Latitude, first.location.Longitude, second.location.Latitude, second.location.Longitude),
+		elapsed:    second.readAt.Sub(first.readAt),
+	}
+	firstAt, firstOK := parseAPITimestamp(first.location.Timestamp)
+	secondAt, secondOK := parseAPITimestamp(second.location.Timestamp)
+	if firstOK && secondOK {
+		estimate.elapsed = secondAt.Sub(firstAt)
+		estimate.noNewPosition = !secondAt.After(firstAt)
+		estimate.positionAt = secondAt
+	} else {
+		estimate.noNewPosition = first.location.Latitude == second.location.Latitude && first.location.Longitude == second.location.Longitude
+	}
+
+	return estimate
+}
+
+// formatMotionEstimate formats an estimate for display, e.g. "appears to be
+// moving (~45 km/h)", "stationary", or "stationary (no new position since
+// 2025-01-15 12:00:00)".
+func formatMotionEstimate(estimate motionEstimate, format outputFormat) (string, error) {
+	if format.isJSON() {
+		data := map[string]any{
+			"moving":              estimate.moving(),
+			"estimated_speed_kmh": math.Round(estimate.speedKmh()*10) / 10,
+			"distance_km":         math.Round(estimate.distanceKm*1000) / 1000,
+			"elapsed_seconds":     estimate.elapsed.Seconds(),
+			"new_position":        !estimate.noNewPosition,
+		}
+		if !estimate.positionAt.IsZero() {
+			data["position_timestamp"] = estimate.positionAt.Format(time.RFC3339)
+		}
+
+		return toJSON(data, format)
+	}
+
+	switch {
+	case estimate.noNewPosition && !estimate.positionAt.IsZero():
+		return "stationary (no new position since " + estimate.positionAt.Format("2006-01-02 15:04:05") + ")", nil
+	case estimate.noNewPosition:
+		return "stationary (no new position)", nil
+	case !estimate.moving():
+		return "stationary", nil
+	}
+
+	return fmt.Sprintf("appears to be moving (~%.0f km/h)", estimate.speedKmh()), nil
+}
+
+// runPollMoving reads the location twice, interval apart, and prints whether
+// the vehicle appears to be moving.
+func runPollMoving(ctx context.Context, out, errOut io.Writer, client vehicleStatusGetter, internalVIN api.InternalVIN, format outputFormat, interval time.Duration) error {
+	sample := func() (locationSample, error) {
+		vehicleStatus, err := client.GetVehicleStatus(ctx, internalVIN)
+		if err != nil {
+			return locationSample{}, fmt.Errorf("failed to get vehicle status: %w", err)
+		}
+		locationInfo, err := vehicleStatus.GetLocationInfo()
+		if err != nil {
+			return locationSample{}, fmt.Errorf("failed to get location info: %w", err)
+		}
+
+		return locationSample{location: locationInfo, readAt: time.Now()}, nil
+	}
+
+	first, err := sample()
+	if err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(infoWriter(ctx, errOut), "Sampling the location again in %s...\n", interval)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+	}
+
+	second, err := sample()
+	if err != nil {
+		return err
+	}
+
+	output, err := formatMotionEstimate(estimateMotion(first, second), format)
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, output)
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRunPollMoving tests the speed estimate from two location reads.
+func TestRunPollMoving(t *testing.T) {
+	t.Parallel()
+	// 0.01° of latitude is about 1.112 km, so covering it in a minute is about 67 km/h.
+	statusAt := func(latitude float64, timestamp string) *api.VehicleStatusResponse {
+		vehicleStatus := apitest.NewVehicleStatus().Build()
+		vehicleStatus.AlertInfos[0].PositionInfo.Latitude = latitude
+		vehicleStatus.AlertInfos[0].PositionInfo.Longitude = 0
+		vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = timestamp
+
+		return vehicleStatus
+	}
+	clientFor := func(statuses ...*api.VehicleStatusResponse) *mockClientForConfirm {
+		calls := 0
+
+		return &mockClientForConfirm{
+			getVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.VehicleStatusResponse, error) {
+				status := statuses[min(calls, len(statuses)-1)]
+				calls++
+
+				return status, nil
+			},
+		}
+	}
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{Quiet: true})
+
+	t.Run("moving", func(t *testing.T) {
+		t.Parallel()
+		client := clientFor(statusAt(0, "20250115120000"), statusAt(0.01, "20250115120100"))
+		var out bytes.Buffer
+
+		require.NoError(t, runPollMoving(ctx, &out, &bytes.Buffer{}, client, "test-vin", outputText, time.Millisecond))
+		assert.Equal(t, "appears to be moving (~67 km/h)\n", out.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		client := clientFor(statusAt(0, "20250115120000"), statusAt(0.01, "20250115120100"))
+		var out bytes.Buffer
+
+		require.NoError(t, runPollMoving(ctx, &out, &bytes.Buffer{}, client, "test-vin", outputJSON, time.Millisecond))
+		data := parseJSONToMap(t, out.String())
+		assert.Equal(t, true, data["moving"])
+		assert.InDelta(t, 66.7, data["estimated_speed_kmh"], 0.001)
+		assert.InDelta(t, 1.112, data["distance_km"], 0.001)
+		assert.InDelta(t, 60.0, data["elapsed_seconds"], 0.001)
+	})
+
+	t.Run("stationary", func(t *testing.T) {
+		t.Parallel()
+		// About 1 m of GPS jitter in a minute.
+		client := clientFor(statusAt(0, "20250115120000"), statusAt(0.00001, "20250115120100"))
+		var out bytes.Buffer
+
+		require.NoError(t, runPollMoving(ctx, &out, &bytes.Buffer{}, client, "test-vin", outputText, time.Millisecond))
+		assert.Equal(t, "stationary\n", out.String())
+	})
+
+	t.Run("no new position", func(t *testing.T) {
+		t.Parallel()
+		// A parked vehicle returns the same snapshot to both reads.
+		client := clientFor(statusAt(0, "20250115120000"))
+		var out bytes.Buffer
+
+		require.NoError(t, runPollMoving(ctx, &out, &bytes.Buffer{}, client, "test-vin", outputText, time.Millisecond))
+		assert.Equal(t, "stationary (no new position since 2025-01-15 12:00:00)\n", out.String())
+	})
+
+	t.Run("no new position JSON", func(t *testing.T) {
+		t.Parallel()
+		client := clientFor(statusAt(0, "20250115120000"))
+		var out bytes.Buffer
+
+		require.NoError(t, runPollMoving(ctx, &out, &bytes.Buffer{}, client, "test-vin", outputJSON, time.Millisecond))
+		data := parseJSONToMap(t, out.String())
+		assert.Equal(t, false, data["moving"])
+		assert.Equal(t, false, data["new_position"])
+		assert.Equal(t, "2025-01-15T12:00:00Z", data["position_timestamp"])
+		assert.InDelta(t, 0.0, data["estimated_speed_kmh"], 0.001)
+	})
+}
+
+// TestEstimateMotion_ReadTimes tests falling back to when the samples were read
+// when the positions have no timestamps.
+func TestEstimateMotion_ReadTimes(t *testing.T) {
+	t.Parallel()
+	readAt := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	first := locationSample{location: api.LocationInfo{Latitude: 0}, readAt: readAt}
+	second := locationSample{location: api.LocationInfo{Latitude: 0.01}, readAt: readAt.Add(30 * time.Second)}
+
+	estimate := estimateMotion(first, second)
+	assert.Equal(t, 30*time.Second, estimate.elapsed)
+	assert.InDelta(t, 133.4, estimate.speedKmh(), 0.1)
+	assert.True(t, estimate.moving())
+
+	// The same position with no timestamps is reported like an unchanged timestamp.
+	unchanged := estimateMotion(first, locationSample{location: first.location, readAt: readAt.Add(30 * time.Second)})
+	assert.True(t, unchanged.noNewPosition)
+	assert.False(t, unchanged.moving())
+	output, err := formatMotionEstimate(unchanged, outputText)
+	require.NoError(t, err)
+	assert.Equal(t, "stationary (no new position)", output)
+}
+
+// TestStatusLocation_FlagValidation tests that --poll-moving-interval and
+// --near-threshold are checked before logging in.
+func TestStatusLocation_FlagValidation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "without --poll-moving", args: []string{"--poll-moving-interval", "30"}, wantErr: "--poll-moving-interval requires --poll-moving"},
+		{name: "too short", args: []string{"--poll-moving", "--poll-moving-interval", "5"}, wantErr: "--poll-moving-interval must be between 10 and 600 seconds, got 5"},
+		{name: "zero near threshold", args: []string{"--near-threshold", "0"}, wantErr: "--near-threshold must be greater than 0, got 0"},
+		{name: "negative near threshold", args: []string{"--near-threshold", "-50"}, wantErr: "--near-threshold must be greater than 0, got -50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := testCLIConfig()
+			cfg.CacheFile = t.TempDir() + "/token.json"
+			rootCmd := NewRootCmd(cfg)
+			rootCmd.AddCommand(NewStatusCmd())
+			rootCmd.SetArgs(append([]string{"--config", t.TempDir() + "/missing.toml", "status", "location"}, tt.args...))
+			rootCmd.SetOut(&bytes.Buffer{})
+			rootCmd.SetErr(&bytes.Buffer{})
+
+			require.EqualError(t, rootCmd.Execute(), tt.wantErr)
+		})
+	}
+}
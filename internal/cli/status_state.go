@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/cache"
+)
+
+// errStatusUnchanged is returned by --fail-if-unchanged when nothing new was reported.
+var errStatusUnchanged = errors.New("no change since last check")
+
+// statusStateFor extracts the freshness markers from a pair of status responses.
+// Missing data yields empty markers rather than an error.
+func statusStateFor(vehicleStatus *api.VehicleStatusResponse, evStatus *api.EVVehicleStatusResponse) cache.StatusState {
+	occurrenceDate, _ := evStatus.GetOccurrenceDate()
+	locationInfo, _ := vehicleStatus.GetLocationInfo()
+
+	return cache.StatusState{
+		OccurrenceDate:    occurrenceDate,
+		PositionTimestamp: locationInfo.Timestamp,
+	}
+}
+
+// statusStateKey returns the key under which a vehicle's state is stored.
+// Prefers the public VIN, falling back to the internal VIN.
+func statusStateKey(vehicleInfo VehicleInfo) string {
+	if vehicleInfo.VIN != "" {
+		return vehicleInfo.VIN
+	}
+
+	return string(vehicleInfo.InternalVIN)
+}
+
+// recordStatusState compares the current state with the last recorded state for key,
+// stores the current state, and reports whether it changed.
+func recordStatusState(ctx context.Context, key string, current cache.StatusState) (bool, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	store, err := cache.LoadStateFrom(paths.StateFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load status state: %w", err)
+	}
+
+	if previous, ok := store[key]; ok && previous == current {
+		return false, nil
+	}
+
+	store[key] = current
+	if err := cache.SaveStateTo(store, paths.StateFile); err != nil {
+		return false, fmt.Errorf("failed to save status state: %w", err)
+	}
+
+	return true, nil
+}
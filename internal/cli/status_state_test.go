@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/cv/mcs/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStatusStateFor tests extracting freshness markers from status responses.
+func TestStatusStateFor(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = "20250115115900"
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	evStatus.ResultData[0].OccurrenceDate = "20250115120000"
+
+	state := statusStateFor(vehicleStatus, evStatus)
+	assert.Equal(t, cache.StatusState{OccurrenceDate: "20250115120000", PositionTimestamp: "20250115115900"}, state)
+}
+
+// TestStatusStateKey tests that state is keyed by VIN with an internal VIN fallback.
+func TestStatusStateKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "JM3XXXXXXXXXX1234", statusStateKey(VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3XXXXXXXXXX1234", InternalVIN: "12345"}}))
+	assert.Equal(t, "12345", statusStateKey(VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "12345"}}))
+}
+
+// TestRecordStatusState tests change detection across successive checks.
+func TestRecordStatusState(t *testing.T) {
+	t.Parallel()
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{StateFile: stateFile})
+
+	first := cache.StatusState{OccurrenceDate: "20250115120000", PositionTimestamp: "20250115115900"}
+	second := cache.StatusState{OccurrenceDate: "20250115130000", PositionTimestamp: "20250115115900"}
+
+	changed, err := recordStatusState(ctx, "VIN1", first)
+	require.NoError(t, err)
+	assert.True(t, changed, "first check should count as a change")
+
+	changed, err = recordStatusState(ctx, "VIN1", first)
+	require.NoError(t, err)
+	assert.False(t, changed, "identical state should not count as a change")
+
+	changed, err = recordStatusState(ctx, "VIN2", first)
+	require.NoError(t, err)
+	assert.True(t, changed, "state is tracked per VIN")
+
+	changed, err = recordStatusState(ctx, "VIN1", second)
+	require.NoError(t, err)
+	assert.True(t, changed, "new occurrence date should count as a change")
+
+	store, err := cache.LoadStateFrom(stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, second, store["VIN1"])
+	assert.Equal(t, first, store["VIN2"])
+}
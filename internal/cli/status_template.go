@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// statusTemplate is satisfied by both text/template and html/template templates.
+type statusTemplate interface {
+	Execute(w io.Writer, data any) error
+}
+
+// loadStatusTemplate reads and parses the template file at path. Files ending in
+// .html or .htm are parsed with html/template so status values are escaped; anything
+// else uses text/template. Missing map keys are errors rather than "<no value>".
+func loadStatusTemplate(path string) (statusTemplate, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	name := filepath.Base(path)
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".html", ".htm":
+		tmpl, err := htmltemplate.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+
+		return tmpl, nil
+	default:
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse template: %w", err)
+		}
+
+		return tmpl, nil
+	}
+}
+
+// renderStatusTemplate executes tmpl against the status data used for JSON output.
+func renderStatusTemplate(tmpl statusTemplate, data map[string]any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTemplate writes content to a file called name in a temporary directory.
+func writeTemplate(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestRenderStatusTemplate(t *testing.T) {
+	t.Parallel()
+	data := buildAllStatusData(
+		apitest.NewVehicleStatus().Build(),
+		apitest.NewEVVehicleStatus().Build(),
+		VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456", Nickname: "<Family> Car"}},
+		distanceKm,
+	)
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "text template",
+			file:     "status.tmpl",
+			content:  "{{.vehicle.nickname}}: {{.battery.battery_level}}% battery, {{.battery.range_km}} km\n",
+			expected: "<Family> Car: 80% battery, 200 km\n",
+		},
+		{
+			name:     "html template escapes values",
+			file:     "status.HTML",
+			content:  "<h1>{{.vehicle.nickname}}</h1><p>{{.battery.battery_level}}%</p>",
+			expected: "<h1>&lt;Family&gt; Car</h1><p>80%</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := loadStatusTemplate(writeTemplate(t, tt.file, tt.content))
+			require.NoError(t, err)
+
+			output, err := renderStatusTemplate(tmpl, data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, output)
+		})
+	}
+}
+
+func TestStatusTemplate_Errors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadStatusTemplate(filepath.Join(t.TempDir(), "missing.tmpl"))
+		require.ErrorContains(t, err, "failed to read template")
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		t.Parallel()
+		_, err := loadStatusTemplate(writeTemplate(t, "bad.tmpl", "{{.battery"))
+		require.ErrorContains(t, err, "failed to parse template")
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		t.Parallel()
+		tmpl, err := loadStatusTemplate(writeTemplate(t, "typo.tmpl", "{{.batery}}"))
+		require.NoError(t, err)
+
+		_, err = renderStatusTemplate(tmpl, map[string]any{"battery": map[string]any{}})
+		require.ErrorContains(t, err, "failed to render template")
+		assert.ErrorContains(t, err, "batery")
+	})
+}
@@ -2,12 +2,17 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
 )
 
 // withColorsDisabled acquires the color mutex and disables colors for the test.
@@ -65,6 +70,17 @@ func TestStatusCommand_NoSubcommand(t *testing.T) {
 
 }
 
+// TestStatusCommand_Subcommands tests status subcommands.
+func TestStatusCommand_Subcommands(t *testing.T) {
+	t.Parallel()
+	cmd := NewStatusCmd()
+	assertSubcommandsExist(t, cmd, []string{"battery", "windows", "fuel"})
+
+	batteryCmd, _, err := cmd.Find([]string{"battery"})
+	require.NoError(t, err)
+	assertFlagExists(t, batteryCmd, FlagAssertion{Name: "health", DefaultValue: "false"})
+}
+
 // TestStatusCommand_JSONFlag tests the JSON output flag.
 func TestStatusCommand_JSONFlag(t *testing.T) {
 	t.Parallel()
@@ -80,6 +96,12 @@ func TestStatusCommand_JSONFlag(t *testing.T) {
 			expectedType: "bool",
 			shouldExist:  true,
 		},
+		{
+			name:         "json-compact flag exists",
+			flagName:     "json-compact",
+			expectedType: "bool",
+			shouldExist:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -113,6 +135,7 @@ func TestFormatBatteryStatus(t *testing.T) {
 		chargeTimeQBCMin float64
 		pluggedIn        bool
 		charging         bool
+		chargeRate       api.ChargeRate
 		expectedOutput   string
 	}{
 		{
@@ -145,6 +168,28 @@ func TestFormatBatteryStatus(t *testing.T) {
 			charging:         true,
 			expectedOutput:   "BATTERY: [████░░░░░░] 45% (120.0 km range) [charging]",
 		},
+		{
+			name:             "charging at a reported AC rate",
+			batteryLevel:     66,
+			range_:           245.5,
+			chargeTimeACMin:  180,
+			chargeTimeQBCMin: 45,
+			pluggedIn:        true,
+			charging:         true,
+			chargeRate:       api.ChargeRate{Type: "AC", PowerKW: 6.6},
+			expectedOutput:   "BATTERY: [██████░░░░] 66% (245.5 km range) [charging at 6.6 kW (AC), ~3h to full]",
+		},
+		{
+			name:             "DC charging without power",
+			batteryLevel:     66,
+			range_:           245.5,
+			chargeTimeACMin:  180,
+			chargeTimeQBCMin: 45,
+			pluggedIn:        true,
+			charging:         true,
+			chargeRate:       api.ChargeRate{Type: "DC"},
+			expectedOutput:   "BATTERY: [██████░░░░] 66% (245.5 km range) [charging (DC), ~45m to full]",
+		},
 		{
 			name:             "plugged not charging",
 			batteryLevel:     100,
@@ -178,8 +223,9 @@ func TestFormatBatteryStatus(t *testing.T) {
 				Charging:         tt.charging,
 				HeaterOn:         false,
 				HeaterAuto:       false,
+				ChargeRate:       tt.chargeRate,
 			}
-			result, err := formatBatteryStatus(batteryInfo, false)
+			result, err := formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 			assert.Equal(t, tt.expectedOutput, result)
 		})
@@ -215,12 +261,28 @@ func TestFormatBatteryStatus_JSON(t *testing.T) {
 				"charge_time_qbc_minutes": float64(45),
 			},
 		},
+		{
+			name: "not charging keeps the estimate fields",
+			batteryInfo: api.BatteryInfo{
+				BatteryLevel:    80,
+				RangeKm:         60,
+				ChargeTimeACMin: 90,
+				PluggedIn:       true,
+				Charging:        false,
+				ChargeState:     api.ChargeStateInterrupted,
+			},
+			expectedJSON: map[string]any{
+				"charging":                false,
+				"charge_time_ac_minutes":  float64(90),
+				"charge_time_qbc_minutes": nil,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := formatBatteryStatus(tt.batteryInfo, true)
+			result, err := formatBatteryStatus(tt.batteryInfo, outputJSON, defaultBarWidth, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			data := parseJSONToMap(t, result)
@@ -301,13 +363,171 @@ func TestFormatBatteryStatus_WithHeater(t *testing.T) {
 					HeaterAuto:       tt.heaterAuto,
 				}
 			}
-			result, err := formatBatteryStatus(batteryInfo, false)
+			result, err := formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+// TestFormatBatteryStatus_BarWidth tests that --bar-width changes the number of segments.
+func TestFormatBatteryStatus_BarWidth(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+
+	batteryInfo := api.BatteryInfo{BatteryLevel: 50, RangeKm: 100}
+
+	result, err := formatBatteryStatus(batteryInfo, outputText, 4, distanceKm, language.AmericanEnglish)
+	require.NoError(t, err)
+	assert.Equal(t, "BATTERY: [██░░] 50% (100.0 km range)", result)
+
+	assert.Equal(t, "BATTERY: [██████░░░░░░] 50%", formatBatteryStatusCompact(batteryInfo, 12))
+}
+
+// TestValidateBarWidth tests --bar-width bounds.
+func TestValidateBarWidth(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateBarWidth(1))
+	require.NoError(t, validateBarWidth(defaultBarWidth))
+	require.NoError(t, validateBarWidth(maxBarWidth))
+	require.Error(t, validateBarWidth(0))
+	require.Error(t, validateBarWidth(-3))
+	require.Error(t, validateBarWidth(maxBarWidth+1))
+}
+
+// TestFormatBatteryHealth tests battery state of health formatting.
+func TestFormatBatteryHealth(t *testing.T) {
+	t.Parallel()
+	soh := 93.5
+	withHealth := apitest.NewEVVehicleStatus().Build()
+	withHealth.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.BatterySOH = &soh
+	withoutHealth := apitest.NewEVVehicleStatus().Build()
+
+	tests := []struct {
+		name     string
+		status   *api.EVVehicleStatusResponse
+		format   outputFormat
+		contains []string
+	}{
+		{
+			name:     "health reported",
+			status:   withHealth,
+			contains: []string{"BATTERY HEALTH: 94% (estimated)", "not a diagnostic measurement"},
+		},
+		{
+			name:     "health not reported",
+			status:   withoutHealth,
+			contains: []string{"BATTERY HEALTH: health data not reported by this vehicle"},
+		},
+		{
+			name:     "health reported JSON",
+			status:   withHealth,
+			format:   outputJSON,
+			contains: []string{`"available": true`, `"state_of_health_percent": 93.5`, `"estimated": true`},
+		},
+		{
+			name:     "health not reported JSON",
+			status:   withoutHealth,
+			format:   outputJSON,
+			contains: []string{`"available": false`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := formatBatteryHealth(tt.status, tt.format)
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, result, want)
+			}
+		})
+	}
+}
+
+// TestFormatBatteryHealth_NoData tests that missing EV data is an error, not "not reported".
+func TestFormatBatteryHealth_NoData(t *testing.T) {
+	t.Parallel()
+	_, err := formatBatteryHealth(&api.EVVehicleStatusResponse{}, outputText)
+	require.Error(t, err)
+}
+
+// TestFormatFuelReport tests the low-fuel warning and fill estimate of the fuel view.
+func TestFormatFuelReport(t *testing.T) {
+	withColorsDisabled(t)
+	fuelInfo := api.FuelInfo{FuelLevel: 12, RangeKm: 80}
+
+	tests := []struct {
+		name     string
+		opts     fuelEstimateOptions
+		wantText string
+		wantJSON map[string]any
+		absent   []string
+	}{
+		{
+			name:     "no options matches formatFuelStatus",
+			wantText: "FUEL: [█░░░░░░░░░] 12% (80.0 km range)",
+			wantJSON: map[string]any{"low_fuel": false},
+			absent:   []string{"liters_to_fill", "cost_to_fill"},
+		},
+		{
+			name:     "at warning threshold",
+			opts:     fuelEstimateOptions{warnLevel: 12},
+			wantText: "FUEL: [█░░░░░░░░░] 12% (80.0 km range) LOW FUEL",
+			wantJSON: map[string]any{"low_fuel": true},
+		},
+		{
+			name:     "above warning threshold",
+			opts:     fuelEstimateOptions{warnLevel: 10},
+			wantText: "FUEL: [█░░░░░░░░░] 12% (80.0 km range)",
+			wantJSON: map[string]any{"low_fuel": false},
+		},
+		{
+			name:     "tank size only",
+			opts:     fuelEstimateOptions{tankLiters: 50},
+			wantText: "FUEL: [█░░░░░░░░░] 12% (80.0 km range)\nTO FILL: 44.0 L",
+			wantJSON: map[string]any{"liters_to_fill": 44.0},
+			absent:   []string{"cost_to_fill"},
+		},
+		{
+			name:     "tank size and price",
+			opts:     fuelEstimateOptions{warnLevel: 15, tankLiters: 50, pricePerLiter: 2},
+			wantText: "FUEL: [█░░░░░░░░░] 12% (80.0 km range) LOW FUEL\nTO FILL: 44.0 L (about 88.00)",
+			wantJSON: map[string]any{"low_fuel": true, "liters_to_fill": 44.0, "cost_to_fill": 88.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := formatFuelReport(fuelInfo, outputText, distanceKm, language.AmericanEnglish, tt.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantText, text)
+
+			jsonOut, err := formatFuelReport(fuelInfo, outputJSON, distanceKm, language.AmericanEnglish, tt.opts)
+			require.NoError(t, err)
+			data := parseJSONToMap(t, jsonOut)
+			assertMapValue(t, data, "fuel_level", float64(12))
+			for key, expected := range tt.wantJSON {
+				assertMapValue(t, data, key, expected)
+			}
+			for _, key := range tt.absent {
+				assert.NotContains(t, data, key)
+			}
+		})
+	}
+}
+
+// TestFuelEstimateOptions_Validate tests fuel flag validation.
+func TestFuelEstimateOptions_Validate(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, fuelEstimateOptions{}.validate())
+	require.NoError(t, fuelEstimateOptions{warnLevel: 15, tankLiters: 56, pricePerLiter: 1.85}.validate())
+	require.ErrorContains(t, fuelEstimateOptions{warnLevel: 120}.validate(), "--fuel-warn")
+	require.ErrorContains(t, fuelEstimateOptions{tankLiters: -1}.validate(), "--tank-size")
+	require.ErrorContains(t, fuelEstimateOptions{tankLiters: 50, pricePerLiter: -1}.validate(), "--fuel-price")
+	require.ErrorContains(t, fuelEstimateOptions{pricePerLiter: 1.85}.validate(), "requires --tank-size")
+}
+
 // TestFormatFuelStatus tests fuel status formatting.
 func TestFormatFuelStatus(t *testing.T) {
 	t.Parallel()
@@ -317,7 +537,8 @@ func TestFormatFuelStatus(t *testing.T) {
 		name           string
 		fuelLevel      float64
 		rangeKm        float64
-		asJSON         bool
+		apiLowFuel     *bool
+		format         outputFormat
 		expectedOutput string
 		expectedJSON   map[string]any
 	}{
@@ -325,35 +546,58 @@ func TestFormatFuelStatus(t *testing.T) {
 			name:           "fuel status text format",
 			fuelLevel:      92,
 			rangeKm:        630.0,
-			asJSON:         false,
+			format:         outputText,
 			expectedOutput: "FUEL: [█████████░] 92% (630.0 km range)",
 		},
 		{
 			name:      "fuel status JSON format",
 			fuelLevel: 92,
 			rangeKm:   630.0,
-			asJSON:    true,
+			format:    outputJSON,
 			expectedJSON: map[string]any{
 				"fuel_level": float64(92),
 				"range_km":   630.0,
 			},
 		},
+		{
+			name:           "vehicle low fuel warning text format",
+			fuelLevel:      12,
+			rangeKm:        70.0,
+			apiLowFuel:     boolPtr(true),
+			format:         outputText,
+			expectedOutput: "FUEL: [█░░░░░░░░░] 12% (70.0 km range) ⚠ low",
+		},
+		{
+			name:       "vehicle low fuel warning JSON format",
+			fuelLevel:  12,
+			rangeKm:    70.0,
+			apiLowFuel: boolPtr(true),
+			format:     outputJSON,
+			expectedJSON: map[string]any{
+				"fuel_level":       float64(12),
+				"low_fuel_warning": true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fuelInfo := api.FuelInfo{
-				FuelLevel: tt.fuelLevel,
-				RangeKm:   tt.rangeKm,
+				FuelLevel:  tt.fuelLevel,
+				RangeKm:    tt.rangeKm,
+				APILowFuel: tt.apiLowFuel,
 			}
-			result, err := formatFuelStatus(fuelInfo, tt.asJSON)
+			result, err := formatFuelStatus(fuelInfo, tt.format, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 
-			if tt.asJSON {
+			if tt.format.isJSON() {
 				data := parseJSONToMap(t, result)
 				for key, expected := range tt.expectedJSON {
 					assertMapValue(t, data, key, expected)
 				}
+				if tt.apiLowFuel == nil {
+					assert.NotContains(t, data, "low_fuel_warning", "an unreported lamp should be left out")
+				}
 			} else {
 				assert.Equal(t, tt.expectedOutput, result)
 			}
@@ -439,13 +683,88 @@ func TestFormatDoorsStatus(t *testing.T) {
 			},
 			expectedOutput: "DOORS: Driver unlocked, Passenger open, Trunk open",
 		},
+		{
+			name: "fuel lid open and rear doors unlocked",
+			doorStatus: api.DoorStatus{
+				FuelLidOpen:     true,
+				DriverLocked:    true,
+				PassengerLocked: true,
+			},
+			expectedOutput: "DOORS: Rear left unlocked, Rear right unlocked, Fuel lid open",
+		},
+		{
+			name: "locked but not reported as all locked",
+			doorStatus: api.DoorStatus{
+				DriverLocked:    true,
+				PassengerLocked: true,
+				RearLeftLocked:  true,
+				RearRightLocked: true,
+			},
+			expectedOutput: "DOORS: Status unknown",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := formatDoorsStatus(tt.doorStatus, false)
+			result, err := formatDoorsStatus(tt.doorStatus, outputText, theme{})
 			require.NoError(t, err, "Unexpected error: %v")
 			assert.Equal(t, tt.expectedOutput, result)
+			// The JSON summary is the same phrase, without color or symbols.
+			assert.Equal(t, tt.expectedOutput, "DOORS: "+tt.doorStatus.Summary())
+		})
+	}
+}
+
+// TestFormatBatteryStatus_LastCharged tests the "last charged" line, shown only when reported.
+func TestFormatBatteryStatus_LastCharged(t *testing.T) {
+	withColorsDisabled(t)
+	batteryInfo := api.BatteryInfo{
+		BatteryLevel:  60,
+		RangeKm:       40,
+		LastChargedAt: time.Now().UTC().Add(-50 * time.Hour).Format("20060102150405"),
+	}
+
+	result, err := formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, distanceKm, language.AmericanEnglish)
+	require.NoError(t, err)
+	assert.Equal(t, "BATTERY: [██████░░░░] 60% (40.0 km range)\n  Last charged 2 days ago", result)
+
+	batteryInfo.LastChargedAt = ""
+	result, err = formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, distanceKm, language.AmericanEnglish)
+	require.NoError(t, err)
+	assert.NotContains(t, result, "Last charged")
+}
+
+// TestFormatBatteryStatus_ChargeState tests the charger flag for each charge state
+// while plugged in.
+func TestFormatBatteryStatus_ChargeState(t *testing.T) {
+	withColorsDisabled(t)
+	tests := []struct {
+		name        string
+		state       api.ChargeState
+		want        string
+		wantSummary string
+	}{
+		{name: "not charging", state: api.ChargeStateNotCharging, want: "[plugged in, not charging]", wantSummary: "plugged, not charging"},
+		{name: "scheduled", state: api.ChargeStateScheduled, want: "[plugged in, waiting for scheduled charge]", wantSummary: "plugged, waiting to charge"},
+		{name: "complete", state: api.ChargeStateComplete, want: "[plugged in, charge complete]", wantSummary: "plugged, charge complete"},
+		{name: "interrupted", state: api.ChargeStateInterrupted, want: "[plugged in, charge interrupted]", wantSummary: "plugged, charge interrupted"},
+		{name: "charging", state: api.ChargeStateCharging, want: "[charging]", wantSummary: "plugged, charging"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batteryInfo := api.BatteryInfo{
+				BatteryLevel: 100,
+				RangeKm:      300,
+				PluggedIn:    true,
+				Charging:     tt.state == api.ChargeStateCharging,
+				ChargeState:  tt.state,
+			}
+
+			result, err := formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, distanceKm, language.AmericanEnglish)
+			require.NoError(t, err)
+			assert.Equal(t, "BATTERY: [██████████] 100% (300.0 km range) "+tt.want, result)
+			assert.Equal(t, tt.wantSummary, summaryChargeState(batteryInfo))
 		})
 	}
 }
@@ -483,7 +802,7 @@ func TestFormatTiresStatus(t *testing.T) {
 				RearLeftPsi:   tt.rearLeftPsi,
 				RearRightPsi:  tt.rearRightPsi,
 			}
-			result, err := formatTiresStatus(tireInfo, false)
+			result, err := formatTiresStatus(tireInfo, outputText, api.Celsius)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			assert.Contains(t, result, tt.expectedPart)
@@ -491,6 +810,101 @@ func TestFormatTiresStatus(t *testing.T) {
 	}
 }
 
+// TestFormatTiresStatus_Temperatures tests that reported tire temperatures are shown in the chosen unit.
+func TestFormatTiresStatus_Temperatures(t *testing.T) {
+	withColorsDisabled(t)
+	fl, fr, rl := 28.0, 27.0, 25.0
+	tireInfo := api.TireInfo{
+		FrontLeftPsi:    32.5,
+		FrontRightPsi:   32.0,
+		RearLeftPsi:     31.5,
+		RearRightPsi:    31.8,
+		FrontLeftTempC:  &fl,
+		FrontRightTempC: &fr,
+		RearLeftTempC:   &rl,
+	}
+
+	celsius, err := formatTiresStatus(tireInfo, outputText, api.Celsius)
+	require.NoError(t, err)
+	assert.Equal(t, "TIRES: FL:32.5psi 28°C FR:32.0psi 27°C RL:31.5psi 25°C RR:31.8psi", celsius)
+
+	fahrenheit, err := formatTiresStatus(tireInfo, outputText, api.Fahrenheit)
+	require.NoError(t, err)
+	assert.Equal(t, "TIRES: FL:32.5psi 82°F FR:32.0psi 81°F RL:31.5psi 77°F RR:31.8psi", fahrenheit)
+}
+
+// TestFormatTiresReport tests the status tires output, with and without recommended pressures.
+func TestFormatTiresReport(t *testing.T) {
+	withColorsDisabled(t)
+	fl, fr, rl := 28.0, 27.0, 25.0
+	tireInfo := api.TireInfo{FrontLeftPsi: 35.0, FrontRightPsi: 36.5, RearLeftPsi: 33.0, RearRightPsi: 31.5}
+	withTemps := tireInfo
+	withTemps.FrontLeftTempC, withTemps.FrontRightTempC, withTemps.RearLeftTempC = &fl, &fr, &rl
+	targets := tirePressureTargets{frontPSI: 36, rearPSI: 33}
+
+	tests := []struct {
+		name     string
+		tireInfo api.TireInfo
+		format   outputFormat
+		targets  tirePressureTargets
+		want     string
+	}{
+		{
+			name:     "text without targets",
+			tireInfo: tireInfo,
+			format:   outputText,
+			want:     "TIRES: FL:35.0 FR:36.5 RL:33.0 RR:31.5 PSI",
+		},
+		{
+			name:     "text with targets",
+			tireInfo: tireInfo,
+			format:   outputText,
+			targets:  targets,
+			want:     "TIRES: FL:35.0 FR:36.5 RL:33.0 RR:31.5 PSI\nVS RECOMMENDED: FL:-1.0 FR:+0.5 RL:+0.0 RR:-1.5 PSI",
+		},
+		{
+			name:     "table without targets",
+			tireInfo: tireInfo,
+			format:   outputTable,
+			want: "FL  35.0 psi  FR  36.5 psi\n" +
+				"RL  33.0 psi  RR  31.5 psi",
+		},
+		{
+			name:     "table with targets and temperatures",
+			tireInfo: withTemps,
+			format:   outputTable,
+			targets:  targets,
+			want: "FL  35.0 psi  (-1.0)  28°C  FR  36.5 psi  (+0.5)  27°C\n" +
+				"RL  33.0 psi  (+0.0)  25°C  RR  31.5 psi  (-1.5)  -",
+		},
+		{
+			name:     "table with front target only",
+			tireInfo: tireInfo,
+			format:   outputTable,
+			targets:  tirePressureTargets{frontPSI: 36},
+			want: "FL  35.0 psi  (-1.0)  FR  36.5 psi  (+0.5)\n" +
+				"RL  33.0 psi          RR  31.5 psi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := formatTiresReport(tt.tireInfo, tt.format, api.Celsius, tt.targets)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("JSON deltas", func(t *testing.T) {
+		got, err := formatTiresReport(tireInfo, outputJSON, api.Celsius, targets)
+		require.NoError(t, err)
+		data := parseJSONToMap(t, got)
+		assertMapValue(t, data, "front_left_delta_psi", -1.0)
+		assertMapValue(t, data, "rear_right_delta_psi", -1.5)
+		assertMapValue(t, data, "front_left_psi", 35.0)
+	})
+}
+
 // TestFormatLocationStatus tests location status formatting.
 func TestFormatLocationStatus(t *testing.T) {
 	t.Parallel()
@@ -522,7 +936,7 @@ func TestFormatLocationStatus(t *testing.T) {
 				Longitude: tt.longitude,
 				Timestamp: tt.timestamp,
 			}
-			result, err := formatLocationStatus(locationInfo, false)
+			result, err := formatLocationStatus(locationInfo, outputText, "", nil)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			for _, expected := range tt.expectedContains {
@@ -532,6 +946,75 @@ func TestFormatLocationStatus(t *testing.T) {
 	}
 }
 
+// TestFormatLocationStatus_HeadingSpeed tests heading and speed in location output.
+func TestFormatLocationStatus_HeadingSpeed(t *testing.T) {
+	t.Parallel()
+	heading := 135.0
+	parked := 0.0
+	moving := 48.0
+	tests := []struct {
+		name           string
+		heading        *float64
+		speed          *float64
+		wantText       string
+		wantNotText    []string
+		wantJSONFields map[string]any
+		absentJSON     []string
+	}{
+		{
+			name:           "parked",
+			heading:        &heading,
+			speed:          &parked,
+			wantText:       "heading 135°, 0 km/h — parked",
+			wantJSONFields: map[string]any{"heading_deg": 135.0, "speed_kmh": 0.0},
+		},
+		{
+			name:           "moving",
+			heading:        &heading,
+			speed:          &moving,
+			wantText:       "heading 135°, 48 km/h",
+			wantNotText:    []string{"parked"},
+			wantJSONFields: map[string]any{"heading_deg": 135.0, "speed_kmh": 48.0},
+		},
+		{
+			name:        "not reported",
+			wantNotText: []string{"heading", "km/h"},
+			absentJSON:  []string{"heading_deg", "speed_kmh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			locationInfo := api.LocationInfo{
+				Latitude:  37.7749,
+				Longitude: 122.4194,
+				Heading:   tt.heading,
+				SpeedKmh:  tt.speed,
+			}
+
+			text, err := formatLocationStatus(locationInfo, outputText, "", nil)
+			require.NoError(t, err)
+			if tt.wantText != "" {
+				assert.Contains(t, text, tt.wantText)
+			}
+			for _, notWanted := range tt.wantNotText {
+				assert.NotContains(t, text, notWanted)
+			}
+
+			jsonOutput, err := formatLocationStatus(locationInfo, outputJSON, "", nil)
+			require.NoError(t, err)
+			data := parseJSONToMap(t, jsonOutput)
+			for key, want := range tt.wantJSONFields {
+				assertMapValue(t, data, key, want)
+			}
+			for _, key := range tt.absentJSON {
+				assert.NotContains(t, data, key)
+			}
+		})
+	}
+}
+
 // TestGetInternalVIN tests getting internal VIN from vehicle base info.
 func TestGetInternalVIN(t *testing.T) {
 	t.Parallel()
@@ -654,7 +1137,25 @@ func TestFormatHvacStatus(t *testing.T) {
 			rearDefroster:  false,
 			interiorTempC:  18,
 			targetTempC:    22,
-			expectedOutput: "CLIMATE: On, 18°C → 22°C",
+			expectedOutput: "CLIMATE: On, 18°C → 22°C (heating)",
+		},
+		{
+			name:           "hvac on cooling",
+			hvacOn:         true,
+			frontDefroster: false,
+			rearDefroster:  false,
+			interiorTempC:  30,
+			targetTempC:    21,
+			expectedOutput: "CLIMATE: On, 30°C → 21°C (cooling)",
+		},
+		{
+			name:           "hvac on heating with front defroster",
+			hvacOn:         true,
+			frontDefroster: true,
+			rearDefroster:  false,
+			interiorTempC:  5,
+			targetTempC:    22,
+			expectedOutput: "CLIMATE: On, 5°C → 22°C (heating, front defroster on)",
 		},
 		{
 			name:           "hvac off",
@@ -672,32 +1173,77 @@ func TestFormatHvacStatus(t *testing.T) {
 			t.Parallel()
 			hvacInfo := api.HVACInfo{
 				HVACOn:         tt.hvacOn,
-				FrontDefroster: tt.frontDefroster,
-				RearDefroster:  tt.rearDefroster,
+				FrontDefroster: &tt.frontDefroster,
+				RearDefroster:  &tt.rearDefroster,
 				InteriorTempC:  tt.interiorTempC,
 				TargetTempC:    tt.targetTempC,
 			}
-			result, err := formatHvacStatus(hvacInfo, false)
+			result, err := formatHvacStatus(hvacInfo, outputText, api.Celsius, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 			assert.Equal(t, tt.expectedOutput, result)
 		})
 	}
 }
 
+// TestFormatHvacStatus_HalfDegreeTarget tests that a half-degree target is kept
+// in Celsius and rounded to a whole degree in Fahrenheit.
+func TestFormatHvacStatus_HalfDegreeTarget(t *testing.T) {
+	t.Parallel()
+	hvacInfo := api.HVACInfo{HVACOn: true, InteriorTempC: 18.4, TargetTempC: 21.5}
+	tests := []struct {
+		name     string
+		unit     api.TemperatureUnit
+		locale   language.Tag
+		expected string
+	}{
+		{name: "celsius", unit: api.Celsius, locale: language.AmericanEnglish, expected: "CLIMATE: On, 18°C → 21.5°C (heating)"},
+		{name: "celsius de-DE", unit: api.Celsius, locale: language.MustParse("de-DE"), expected: "CLIMATE: On, 18°C → 21,5°C (heating)"},
+		{name: "fahrenheit", unit: api.Fahrenheit, locale: language.AmericanEnglish, expected: "CLIMATE: On, 65°F → 71°F (heating)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := formatHvacStatus(hvacInfo, outputText, tt.unit, tt.locale)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// TestFormatHvacStatus_RemainingTime tests the time left before climate auto-shutoff.
+func TestFormatHvacStatus_RemainingTime(t *testing.T) {
+	t.Parallel()
+	remaining := 12.0
+	hvacInfo := api.HVACInfo{HVACOn: true, InteriorTempC: 18, TargetTempC: 22, RemainingMinutes: &remaining}
+
+	result, err := formatHvacStatus(hvacInfo, outputText, api.Celsius, language.AmericanEnglish)
+	require.NoError(t, err)
+	assert.Equal(t, "CLIMATE: On, 18°C → 22°C (~12m remaining, heating)", result)
+
+	data := hvacInfoToMap(hvacInfo)
+	assertMapValue(t, data, "remaining_minutes", float64(12))
+
+	hvacInfo.RemainingMinutes = nil
+	assert.NotContains(t, hvacInfoToMap(hvacInfo), "remaining_minutes")
+}
+
 // TestFormatHvacStatus_JSON tests HVAC status JSON formatting.
 func TestFormatHvacStatus_JSON(t *testing.T) {
 	t.Parallel()
+	on, off := true, false
 	tests := []struct {
 		name         string
 		hvacInfo     api.HVACInfo
 		expectedJSON map[string]any
+		absentKeys   []string
 	}{
 		{
 			name: "HVAC status JSON format",
 			hvacInfo: api.HVACInfo{
 				HVACOn:         true,
-				FrontDefroster: true,
-				RearDefroster:  false,
+				FrontDefroster: &on,
+				RearDefroster:  &off,
 				InteriorTempC:  21,
 				TargetTempC:    22,
 			},
@@ -707,6 +1253,42 @@ func TestFormatHvacStatus_JSON(t *testing.T) {
 				"rear_defroster":         false,
 				"interior_temperature_c": float64(21),
 				"target_temperature_c":   float64(22),
+				"mode":                   "heating",
+			},
+		},
+		{
+			name: "cooling",
+			hvacInfo: api.HVACInfo{
+				HVACOn:        true,
+				InteriorTempC: 28,
+				TargetTempC:   22,
+			},
+			expectedJSON: map[string]any{"mode": "cooling"},
+		},
+		{
+			name: "defrosters not reported",
+			hvacInfo: api.HVACInfo{
+				HVACOn:        false,
+				InteriorTempC: 21,
+				TargetTempC:   22,
+			},
+			expectedJSON: map[string]any{
+				"hvac_on":                false,
+				"interior_temperature_c": float64(21),
+				"mode":                   "idle",
+			},
+			absentKeys: []string{"front_defroster", "rear_defroster"},
+		},
+		{
+			name: "half-degree target",
+			hvacInfo: api.HVACInfo{
+				HVACOn:        true,
+				InteriorTempC: 18.4,
+				TargetTempC:   21.5,
+			},
+			expectedJSON: map[string]any{
+				"interior_temperature_c": 18.4,
+				"target_temperature_c":   21.5,
 			},
 		},
 	}
@@ -714,13 +1296,16 @@ func TestFormatHvacStatus_JSON(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := formatHvacStatus(tt.hvacInfo, true)
+			result, err := formatHvacStatus(tt.hvacInfo, outputJSON, api.Celsius, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			data := parseJSONToMap(t, result)
 			for key, expected := range tt.expectedJSON {
 				assertMapValue(t, data, key, expected)
 			}
+			for _, key := range tt.absentKeys {
+				assert.NotContains(t, data, key)
+			}
 		})
 	}
 }
@@ -728,6 +1313,7 @@ func TestFormatHvacStatus_JSON(t *testing.T) {
 // TestGetHvacInfo tests extracting HVAC info from EV status.
 func TestGetHvacInfo(t *testing.T) {
 	t.Parallel()
+	rawOn, rawOff := 1.0, 0.0
 	tests := []struct {
 		name              string
 		response          *api.EVVehicleStatusResponse
@@ -750,8 +1336,8 @@ func TestGetHvacInfo(t *testing.T) {
 								ChargeInfo: api.ChargeInfo{},
 								RemoteHvacInfo: &api.RemoteHvacInfo{
 									HVAC:           1,
-									FrontDefroster: 1,
-									RearDefogger:   0,
+									FrontDefroster: &rawOn,
+									RearDefogger:   &rawOff,
 									InCarTeDC:      21.5,
 									TargetTemp:     22.0,
 								},
@@ -797,8 +1383,8 @@ func TestGetHvacInfo(t *testing.T) {
 			} else {
 				require.NoError(t, err, "Unexpected error: %v")
 				assert.Equal(t, tt.expectedHVACOn, hvacInfo.HVACOn)
-				assert.Equal(t, tt.expectedFrontDefr, hvacInfo.FrontDefroster)
-				assert.Equal(t, tt.expectedRearDefr, hvacInfo.RearDefroster)
+				assert.Equal(t, tt.expectedFrontDefr, hvacInfo.FrontDefrosterOn())
+				assert.Equal(t, tt.expectedRearDefr, hvacInfo.RearDefrosterOn())
 				assert.InDelta(t, tt.expectedInteriorC, hvacInfo.InteriorTempC, 0.0001)
 				assert.InDelta(t, tt.expectedTargetC, hvacInfo.TargetTempC, 0.0001)
 			}
@@ -809,6 +1395,7 @@ func TestGetHvacInfo(t *testing.T) {
 // TestExtractHvacData tests extracting HVAC data for JSON output.
 func TestExtractHvacData(t *testing.T) {
 	t.Parallel()
+	rawOn, rawOff := 1.0, 0.0
 	tests := []struct {
 		name         string
 		response     *api.EVVehicleStatusResponse
@@ -826,8 +1413,8 @@ func TestExtractHvacData(t *testing.T) {
 								ChargeInfo: api.ChargeInfo{},
 								RemoteHvacInfo: &api.RemoteHvacInfo{
 									HVAC:           1,
-									FrontDefroster: 0,
-									RearDefogger:   1,
+									FrontDefroster: &rawOff,
+									RearDefogger:   &rawOn,
 									InCarTeDC:      18,
 									TargetTemp:     22,
 								},
@@ -892,7 +1479,7 @@ func TestFormatOdometerStatus(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			odometerInfo := api.OdometerInfo{OdometerKm: tt.odometerKm}
-			result, err := formatOdometerStatus(odometerInfo, false)
+			result, err := formatOdometerStatus(odometerInfo, outputText, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 			assert.Equal(t, tt.expectedOutput, result)
 		})
@@ -920,7 +1507,7 @@ func TestFormatOdometerStatus_JSON(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			odometerInfo := api.OdometerInfo{OdometerKm: tt.odometerKm}
-			result, err := formatOdometerStatus(odometerInfo, true)
+			result, err := formatOdometerStatus(odometerInfo, outputJSON, distanceKm, language.AmericanEnglish)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			data := parseJSONToMap(t, result)
@@ -931,6 +1518,49 @@ func TestFormatOdometerStatus_JSON(t *testing.T) {
 	}
 }
 
+// TestPlainOutput tests that --plain prints only each subcommand's primary value.
+func TestPlainOutput(t *testing.T) {
+	t.Parallel()
+	locale := language.German // --plain ignores the locale's grouping and decimal separator
+
+	t.Run("battery", func(t *testing.T) {
+		t.Parallel()
+		result, err := formatBatteryStatus(api.BatteryInfo{BatteryLevel: 80, RangeKm: 60, PluggedIn: true}, outputPlain, defaultBarWidth, distanceKm, locale)
+		require.NoError(t, err)
+		assert.Equal(t, "80", result)
+	})
+
+	t.Run("fuel", func(t *testing.T) {
+		t.Parallel()
+		result, err := formatFuelReport(api.FuelInfo{FuelLevel: 66.5, RangeKm: 400}, outputPlain, distanceKm, locale, fuelEstimateOptions{warnLevel: 70, tankLiters: 56})
+		require.NoError(t, err)
+		assert.Equal(t, "66.5", result)
+	})
+
+	t.Run("odometer", func(t *testing.T) {
+		t.Parallel()
+		result, err := formatOdometerStatus(api.OdometerInfo{OdometerKm: 12345.6}, outputPlain, distanceKm, locale)
+		require.NoError(t, err)
+		assert.Equal(t, "12345.6", result)
+	})
+
+	t.Run("odometer in miles", func(t *testing.T) {
+		t.Parallel()
+		result, err := formatOdometerStatus(api.OdometerInfo{OdometerKm: 12345.6}, outputPlain, distanceMi, locale)
+		require.NoError(t, err)
+		assert.Equal(t, "7671.2", result)
+	})
+}
+
+// TestStatusCommand_PlainRequiresSubcommand tests that --plain on the full status
+// fails and points at the alternatives.
+func TestStatusCommand_PlainRequiresSubcommand(t *testing.T) {
+	t.Parallel()
+	err := runStatus(&cobra.Command{}, statusOptions{refreshWait: 90, barWidth: defaultBarWidth, timestampFormat: timestampFormatDefault, plain: true})
+	require.ErrorContains(t, err, "mcs status battery --plain")
+	require.ErrorContains(t, err, "--json")
+}
+
 // TestGetOdometerInfo tests extracting odometer info from vehicle status.
 func TestGetOdometerInfo(t *testing.T) {
 	t.Parallel()
@@ -995,7 +1625,7 @@ func TestExtractOdometerData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			data := extractOdometerData(tt.response)
+			data := extractOdometerData(tt.response, distanceKm)
 
 			for key, expected := range tt.expectedData {
 				assertMapValue(t, data, key, expected)
@@ -1077,45 +1707,66 @@ func TestFormatVehicleHeader(t *testing.T) {
 		{
 			name: "full info with nickname",
 			info: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				Nickname:  "My CX-90",
-				ModelName: "CX-90 PHEV",
-				ModelYear: "2024",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					Nickname:  "My CX-90",
+					ModelName: "CX-90 PHEV",
+					ModelYear: "2024",
+				},
 			},
 			expected: "CX-90 PHEV (2024) \"My CX-90\"\nVIN: JM3KKEHC1R0123456\n",
 		},
 		{
 			name: "model without nickname",
 			info: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				ModelName: "CX-90 PHEV",
-				ModelYear: "2024",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					ModelName: "CX-90 PHEV",
+					ModelYear: "2024",
+				},
 			},
 			expected: "CX-90 PHEV (2024)\nVIN: JM3KKEHC1R0123456\n",
 		},
 		{
 			name: "model without year",
 			info: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				ModelName: "CX-90 PHEV",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					ModelName: "CX-90 PHEV",
+				},
 			},
 			expected: "CX-90 PHEV\nVIN: JM3KKEHC1R0123456\n",
 		},
 		{
 			name: "only nickname",
 			info: VehicleInfo{
-				VIN:      "JM3KKEHC1R0123456",
-				Nickname: "My Car",
+				VehicleInfo: api.VehicleInfo{
+					VIN:      "JM3KKEHC1R0123456",
+					Nickname: "My Car",
+				},
 			},
 			expected: "\"My Car\"\nVIN: JM3KKEHC1R0123456\n",
 		},
 		{
 			name: "only VIN",
 			info: VehicleInfo{
-				VIN: "JM3KKEHC1R0123456",
+				VehicleInfo: api.VehicleInfo{
+					VIN: "JM3KKEHC1R0123456",
+				},
 			},
 			expected: "VIN: JM3KKEHC1R0123456\n",
 		},
+		{
+			name: "masked VIN",
+			info: VehicleInfo{
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					ModelName: "CX-90 PHEV",
+				},
+				MaskVIN: true,
+			},
+			expected: "CX-90 PHEV\nVIN: ...123456\n",
+		},
 		{
 			name:     "empty info",
 			info:     VehicleInfo{},
@@ -1132,6 +1783,72 @@ func TestFormatVehicleHeader(t *testing.T) {
 	}
 }
 
+// TestFormatVehicleDetails tests the extended vehicle details shown by --verbose.
+func TestFormatVehicleDetails(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		info     VehicleInfo
+		expected string
+	}{
+		{
+			name: "all details",
+			info: VehicleInfo{
+				VehicleInfo: api.VehicleInfo{
+					Carline:       "CX-90 PHEV Premium Plus",
+					ModelCode:     "KKEH",
+					ExteriorColor: "Rhodium White Metallic",
+					InteriorColor: "Tan Nappa",
+					Transmission:  "A",
+					EconnectType:  api.EconnectTypeStandard,
+				},
+			},
+			expected: "Trim: CX-90 PHEV Premium Plus (KKEH)\nColor: Rhodium White Metallic / Tan Nappa interior\nTransmission: A\nConnectivity: standard\n",
+		},
+		{
+			name:     "model code only",
+			info:     VehicleInfo{VehicleInfo: api.VehicleInfo{ModelCode: "KKEH", ExteriorColor: "Soul Red Crystal"}},
+			expected: "Model code: KKEH\nColor: Soul Red Crystal\n",
+		},
+		{
+			name:     "no details",
+			info:     VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, formatVehicleDetails(tt.info))
+		})
+	}
+}
+
+// TestDisplayAllStatus_Verbose tests that --verbose adds details to the header.
+func TestDisplayAllStatus_Verbose(t *testing.T) {
+	t.Parallel()
+	vehicleInfo := VehicleInfo{
+		VehicleInfo: api.VehicleInfo{
+			VIN:           "JM3KKEHC1R0123456",
+			ModelName:     "CX-90 PHEV",
+			ModelYear:     "2024",
+			Carline:       "CX-90 PHEV Premium Plus",
+			ExteriorColor: "Rhodium White Metallic",
+		},
+	}
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+
+	plain, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{})
+	require.NoError(t, err)
+	assert.NotContains(t, plain, "Trim:")
+
+	verbose, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{verbose: true})
+	require.NoError(t, err)
+	assert.Contains(t, verbose, "VIN: JM3KKEHC1R0123456\nTrim: CX-90 PHEV Premium Plus\nColor: Rhodium White Metallic\n\nStatus as of")
+}
+
 // TestExtractVehicleInfoData tests vehicle info extraction for JSON.
 func TestExtractVehicleInfoData(t *testing.T) {
 	t.Parallel()
@@ -1143,10 +1860,12 @@ func TestExtractVehicleInfoData(t *testing.T) {
 		{
 			name: "complete vehicle info extraction",
 			vehicleInfo: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				Nickname:  "My CX-90",
-				ModelName: "CX-90 PHEV",
-				ModelYear: "2024",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					Nickname:  "My CX-90",
+					ModelName: "CX-90 PHEV",
+					ModelYear: "2024",
+				},
 			},
 			expectedData: map[string]any{
 				"vin":        "JM3KKEHC1R0123456",
@@ -1155,6 +1874,32 @@ func TestExtractVehicleInfoData(t *testing.T) {
 				"model_year": "2024",
 			},
 		},
+		{
+			name:         "masked VIN",
+			vehicleInfo:  VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}, MaskVIN: true},
+			expectedData: map[string]any{"vin": "...123456"},
+		},
+		{
+			name: "extended details extraction",
+			vehicleInfo: VehicleInfo{
+				VehicleInfo: api.VehicleInfo{
+					VIN:           "JM3KKEHC1R0123456",
+					ModelName:     "CX-90 PHEV",
+					Carline:       "CX-90 PHEV Premium Plus",
+					ModelCode:     "KKEH",
+					ExteriorColor: "Rhodium White Metallic",
+					InteriorColor: "Tan Nappa",
+					Transmission:  "A",
+				},
+			},
+			expectedData: map[string]any{
+				"carline":        "CX-90 PHEV Premium Plus",
+				"model_code":     "KKEH",
+				"exterior_color": "Rhodium White Metallic",
+				"interior_color": "Tan Nappa",
+				"transmission":   "A",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1311,6 +2056,42 @@ func TestFormatTimestamp(t *testing.T) {
 	}
 }
 
+// TestFormatTimestampRFC3339 tests conversion of API timestamps to RFC3339.
+func TestFormatTimestampRFC3339(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		timestamp string
+		want      string
+	}{
+		{name: "valid timestamp", timestamp: "20250115120000", want: "2025-01-15T12:00:00Z"},
+		{name: "another valid timestamp", timestamp: "20241225093045", want: "2024-12-25T09:30:45Z"},
+		{name: "invalid length falls back to raw", timestamp: "2025011512", want: "2025011512"},
+		{name: "invalid digits fall back to raw", timestamp: "202513451200xx", want: "202513451200xx"},
+		{name: "empty timestamp", timestamp: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatTimestampRFC3339(tt.timestamp))
+		})
+	}
+}
+
+// TestFormatTimestampStyle tests the --timestamp-format styles.
+func TestFormatTimestampStyle(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "2025-01-15T12:00:00Z", formatTimestampStyle("20250115120000", timestampFormatISO8601))
+	assert.Contains(t, formatTimestampStyle("20250115120000", timestampFormatDefault), "2025-01-15 12:00:00 (")
+	assert.Contains(t, formatTimestampStyle("20250115120000", ""), "2025-01-15 12:00:00 (")
+	assert.Equal(t, "bogus", formatTimestampStyle("bogus", timestampFormatISO8601))
+
+	require.NoError(t, validateTimestampFormat(timestampFormatDefault))
+	require.NoError(t, validateTimestampFormat(timestampFormatISO8601))
+	require.ErrorContains(t, validateTimestampFormat("unix"), "--timestamp-format")
+}
+
 // TestFormatWindowsStatus tests the formatWindowsStatus function.
 func TestFormatWindowsStatus(t *testing.T) {
 	t.Parallel()
@@ -1321,7 +2102,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 	tests := []struct {
 		name           string
 		windowsInfo    api.WindowStatus
-		jsonOutput     bool
+		format         outputFormat
 		expectedOutput string
 		expectJSON     bool
 	}{
@@ -1333,7 +2114,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  api.WindowClosed,
 				RearRightPosition: api.WindowClosed,
 			},
-			jsonOutput:     false,
+			format:         outputText,
 			expectedOutput: "WINDOWS: All closed",
 		},
 		{
@@ -1344,7 +2125,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  api.WindowClosed,
 				RearRightPosition: api.WindowClosed,
 			},
-			jsonOutput:     false,
+			format:         outputText,
 			expectedOutput: "WINDOWS: Driver 25%",
 		},
 		{
@@ -1355,7 +2136,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  api.WindowClosed,
 				RearRightPosition: api.WindowClosed,
 			},
-			jsonOutput:     false,
+			format:         outputText,
 			expectedOutput: "WINDOWS: Driver 50%, Passenger 75%",
 		},
 		{
@@ -1366,7 +2147,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  api.WindowFullyOpen,
 				RearRightPosition: api.WindowFullyOpen,
 			},
-			jsonOutput:     false,
+			format:         outputText,
 			expectedOutput: "WINDOWS: Driver 100%, Passenger 100%, Rear left 100%, Rear right 100%",
 		},
 		{
@@ -1377,7 +2158,7 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  30,
 				RearRightPosition: 40,
 			},
-			jsonOutput:     false,
+			format:         outputText,
 			expectedOutput: "WINDOWS: Rear left 30%, Rear right 40%",
 		},
 		{
@@ -1388,14 +2169,14 @@ func TestFormatWindowsStatus(t *testing.T) {
 				RearLeftPosition:  75,
 				RearRightPosition: 100,
 			},
-			jsonOutput: true,
+			format:     outputJSON,
 			expectJSON: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := formatWindowsStatus(tt.windowsInfo, tt.jsonOutput)
+			result, err := formatWindowsStatus(tt.windowsInfo, tt.format)
 			require.NoError(t, err, "Unexpected error: %v")
 
 			if tt.expectJSON {
@@ -1414,12 +2195,13 @@ func TestFormatWindowsStatus(t *testing.T) {
 // TestDisplayAllStatus tests the displayAllStatus function.
 func TestDisplayAllStatus(t *testing.T) {
 	t.Parallel()
+	defrosterOff := float64(api.DefrosterOff)
 	tests := []struct {
 		name           string
 		vehicleStatus  *api.VehicleStatusResponse
 		evStatus       *api.EVVehicleStatusResponse
 		vehicleInfo    VehicleInfo
-		jsonOutput     bool
+		format         outputFormat
 		expectedOutput []string
 		expectJSON     bool
 	}{
@@ -1474,8 +2256,8 @@ func TestDisplayAllStatus(t *testing.T) {
 								},
 								RemoteHvacInfo: &api.RemoteHvacInfo{
 									HVAC:           float64(api.HVACStatusOff),
-									FrontDefroster: float64(api.DefrosterOff),
-									RearDefogger:   float64(api.DefrosterOff),
+									FrontDefroster: &defrosterOff,
+									RearDefogger:   &defrosterOff,
 									InCarTeDC:      20.0,
 									TargetTemp:     22.0,
 								},
@@ -1485,11 +2267,13 @@ func TestDisplayAllStatus(t *testing.T) {
 				},
 			},
 			vehicleInfo: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				ModelName: "CX-90 PHEV",
-				ModelYear: "2024",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					ModelName: "CX-90 PHEV",
+					ModelYear: "2024",
+				},
 			},
-			jsonOutput: false,
+			format: outputText,
 			expectedOutput: []string{
 				"CX-90 PHEV (2024)",
 				"VIN: JM3KKEHC1R0123456",
@@ -1544,25 +2328,29 @@ func TestDisplayAllStatus(t *testing.T) {
 					},
 				},
 			},
-			evStatus: NewMockEVVehicleStatus().Build(),
+			evStatus: apitest.NewEVVehicleStatus().Build(),
 			vehicleInfo: VehicleInfo{
-				VIN: "JM3KKEHC1R0123456",
+				VehicleInfo: api.VehicleInfo{
+					VIN: "JM3KKEHC1R0123456",
+				},
 			},
-			jsonOutput: false,
+			format: outputText,
 			expectedOutput: []string{
 				"HAZARDS: On",
 			},
 		},
 		{
 			name:          "JSON output",
-			vehicleStatus: NewMockVehicleStatus().Build(),
-			evStatus:      NewMockEVVehicleStatus().Build(),
+			vehicleStatus: apitest.NewVehicleStatus().Build(),
+			evStatus:      apitest.NewEVVehicleStatus().Build(),
 			vehicleInfo: VehicleInfo{
-				VIN:       "JM3KKEHC1R0123456",
-				ModelName: "CX-90 PHEV",
-				ModelYear: "2024",
+				VehicleInfo: api.VehicleInfo{
+					VIN:       "JM3KKEHC1R0123456",
+					ModelName: "CX-90 PHEV",
+					ModelYear: "2024",
+				},
 			},
-			jsonOutput: true,
+			format:     outputJSON,
 			expectJSON: true,
 		},
 	}
@@ -1570,7 +2358,7 @@ func TestDisplayAllStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := displayAllStatus(tt.vehicleStatus, tt.evStatus, tt.vehicleInfo, tt.jsonOutput)
+			result, err := displayAllStatus(tt.vehicleStatus, tt.evStatus, tt.vehicleInfo, statusDisplayOptions{format: tt.format})
 			require.NoError(t, err, "Unexpected error: %v")
 
 			if tt.expectJSON {
@@ -1589,6 +2377,464 @@ func TestDisplayAllStatus(t *testing.T) {
 	}
 }
 
+// TestDisplayAllStatus_FuelLid tests that an open fuel lid gets its own line and JSON field.
+func TestDisplayAllStatus_FuelLid(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}
+
+	tests := []struct {
+		name    string
+		lidOpen bool
+	}{
+		{name: "open", lidOpen: true},
+		{name: "closed", lidOpen: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicleStatus := apitest.NewVehicleStatus().WithDoorStatus(api.DoorStatus{FuelLidOpen: tt.lidOpen}).Build()
+
+			text, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText})
+			require.NoError(t, err)
+			if tt.lidOpen {
+				assert.Contains(t, text, "FUEL LID: Open\n")
+			} else {
+				assert.NotContains(t, text, "FUEL LID")
+			}
+
+			result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+			require.NoError(t, err)
+			assert.Equal(t, tt.lidOpen, parseJSONToMap(t, result)["fuel_lid_open"])
+		})
+	}
+}
+
+// TestDisplayAllStatus_Capabilities tests the top-level powertrain and capabilities in JSON output.
+func TestDisplayAllStatus_Capabilities(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+
+	tests := []struct {
+		name             string
+		vehicleInfo      VehicleInfo
+		wantPowertrain   string
+		wantCapabilities []any
+	}{
+		{
+			name:             "PHEV",
+			vehicleInfo:      VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456", ModelCode: "KKEH", Powertrain: api.PowertrainPHEV, EconnectType: api.EconnectTypeStandard}},
+			wantPowertrain:   "PHEV",
+			wantCapabilities: []any{"remote_commands", "remote_engine", "remote_refresh", "charging", "power_windows", "sunroof"},
+		},
+		{
+			name:             "MX-30 EV",
+			vehicleInfo:      VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM1DRADA0M0123456", ModelCode: "DRAD", Powertrain: api.PowertrainEV, EconnectType: api.EconnectTypeStandard}},
+			wantPowertrain:   "EV",
+			wantCapabilities: []any{"remote_commands", "remote_refresh", "charging", "power_windows", "sunroof"},
+		},
+		{
+			name:             "ICE",
+			vehicleInfo:      VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KFBCM1R0123456", ModelCode: "KF", Powertrain: api.PowertrainICE, EconnectType: api.EconnectTypeStandard}},
+			wantPowertrain:   "ICE",
+			wantCapabilities: []any{"remote_commands", "remote_engine", "power_windows", "sunroof"},
+		},
+		{
+			name:             "not classified",
+			vehicleInfo:      VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}},
+			wantPowertrain:   "unknown",
+			wantCapabilities: []any{"remote_commands", "remote_engine", "remote_refresh", "power_windows", "sunroof"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := displayAllStatus(vehicleStatus, evStatus, tt.vehicleInfo, statusDisplayOptions{format: outputJSON})
+			require.NoError(t, err)
+			data := parseJSONToMap(t, result)
+
+			assert.Equal(t, tt.wantPowertrain, data["powertrain"])
+			assert.Equal(t, tt.wantCapabilities, data["capabilities"])
+		})
+	}
+}
+
+// TestDisplayAllStatus_AuxBattery tests that the 12V battery is shown only when reported.
+func TestDisplayAllStatus_AuxBattery(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}
+
+	tests := []struct {
+		name     string
+		voltage  float64
+		wantText string
+		wantJSON map[string]any
+	}{
+		{name: "ok", voltage: 12.4, wantText: "12V: 12.4V (OK)\n", wantJSON: map[string]any{"voltage": 12.4, "low": false}},
+		{name: "low", voltage: 11.8, wantText: "12V: 11.8V (Low)\n", wantJSON: map[string]any{"voltage": 11.8, "low": true}},
+		{name: "not reported"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicleStatus := apitest.NewVehicleStatus().Build()
+			if tt.voltage > 0 {
+				vehicleStatus.RemoteInfos[0].BatteryStatus = &api.AuxBatteryStatus{BatteryVoltage: tt.voltage}
+			}
+
+			text, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText})
+			require.NoError(t, err)
+			result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+			require.NoError(t, err)
+			data := parseJSONToMap(t, result)
+
+			if tt.wantText == "" {
+				assert.NotContains(t, text, "12V")
+				assert.NotContains(t, data, "aux_battery")
+
+				return
+			}
+			assert.Contains(t, text, tt.wantText)
+			assert.Equal(t, tt.wantJSON, data["aux_battery"])
+		})
+	}
+}
+
+// TestCheckStatusComplete tests that --strict lists the sections the vehicle didn't report.
+func TestCheckStatusComplete(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+
+	require.NoError(t, checkStatusComplete(vehicleStatus, apitest.NewEVVehicleStatus().Build()))
+
+	// No EV result data, so the battery and climate getters fail.
+	err := checkStatusComplete(vehicleStatus, &api.EVVehicleStatusResponse{ResultCode: api.ResultCodeSuccess})
+	require.EqualError(t, err, "incomplete status: no data for battery, climate")
+	assert.Equal(t, ExitCodeError, ExitCode(err))
+}
+
+// TestDisplayAllStatus_Timestamps tests that position and EV status acquisition times are reported.
+func TestDisplayAllStatus_Timestamps(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = "20250115115900"
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	evStatus.ResultData[0].OccurrenceDate = "20250115120000"
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+		require.NoError(t, err)
+
+		data := parseJSONToMap(t, result)
+		assert.Equal(t, formatTimestampRFC3339("20250115115900"), data["status_timestamp"])
+		assert.Equal(t, formatTimestampRFC3339("20250115120000"), data["ev_status_timestamp"])
+	})
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText})
+		require.NoError(t, err)
+
+		assert.Regexp(t, `Status as of 2025-01-15 12:00:00 \(\d+ (sec|min|hours?|days?) ago\)\n`, result)
+		assert.Contains(t, result, "Position as of 2025-01-15 11:59:00 (")
+	})
+
+	t.Run("omitted when not reported", func(t *testing.T) {
+		t.Parallel()
+		status := apitest.NewVehicleStatus().Build()
+		status.AlertInfos[0].PositionInfo.AcquisitionDatetime = ""
+		result, err := displayAllStatus(status, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+		require.NoError(t, err)
+
+		data := parseJSONToMap(t, result)
+		assert.NotContains(t, data, "status_timestamp")
+		assert.Contains(t, data, "ev_status_timestamp")
+	})
+}
+
+// TestDisplayAllStatus_StaleVehicleStatus tests that the full status shows when
+// both the EV and the vehicle status were reported, when they are hours apart.
+func TestDisplayAllStatus_StaleVehicleStatus(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	vehicleStatus.AlertInfos[0].OccurrenceDate = "20250115090000"
+	vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = "20250115090000"
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	evStatus.ResultData[0].OccurrenceDate = "20250115120000"
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText, timestampFormat: timestampFormatISO8601})
+		require.NoError(t, err)
+
+		assert.Contains(t, result, "Status as of "+formatTimestampRFC3339("20250115120000")+"\n")
+		assert.Contains(t, result, "Doors, windows, fuel, tires, and odometer as of "+formatTimestampRFC3339("20250115090000")+"\n")
+		assert.NotContains(t, result, "Position as of", "the position time is the vehicle status time already shown")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+		require.NoError(t, err)
+
+		data := parseJSONToMap(t, result)
+		assert.Equal(t, formatTimestampRFC3339("20250115090000"), data["vehicle_status_timestamp"])
+		assert.Equal(t, formatTimestampRFC3339("20250115120000"), data["ev_status_timestamp"])
+	})
+}
+
+// TestDisplayAllStatus_ShowRawFlags tests that --show-raw-flags adds the raw door,
+// lock, window, and hazard codes, and that they are absent otherwise.
+func TestDisplayAllStatus_ShowRawFlags(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().WithWindowPositions(0, 0, 12.5, 0).Build()
+	vehicleStatus.AlertInfos[0].Door.DrStatTrnkLg = 1
+	vehicleStatus.AlertInfos[0].HazardLamp.HazardSw = 1
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText, showRawFlags: true})
+		require.NoError(t, err)
+
+		assert.Contains(t, result, "RAW: DrStatDrv=0 ")
+		assert.Contains(t, result, " DrStatTrnkLg=1 ")
+		assert.Contains(t, result, " PwPosRl=12.5 ")
+		assert.Contains(t, result, " HazardSw=1\n")
+	})
+
+	t.Run("text without flag", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText})
+		require.NoError(t, err)
+
+		assert.NotContains(t, result, "RAW:")
+		assert.NotContains(t, result, "DrStatTrnkLg")
+		assert.NotContains(t, result, "HazardSw")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON, showRawFlags: true})
+		require.NoError(t, err)
+
+		data := parseJSONToMap(t, result)
+		rawFlags, ok := data["raw_flags"].(map[string]any)
+		require.True(t, ok, "raw_flags should be an object")
+		assert.InDelta(t, 1.0, rawFlags["DrStatTrnkLg"], 0.001)
+		assert.InDelta(t, 12.5, rawFlags["PwPosRl"], 0.001)
+		assert.InDelta(t, 1.0, rawFlags["HazardSw"], 0.001)
+	})
+
+	t.Run("JSON without flag", func(t *testing.T) {
+		t.Parallel()
+		result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON})
+		require.NoError(t, err)
+
+		assert.NotContains(t, parseJSONToMap(t, result), "raw_flags")
+	})
+}
+
+// TestStatusTimestamps tests choosing the "Status as of" time and the older one to show.
+func TestStatusTimestamps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name                string
+		evDate, vehicleDate string
+		wantNewest          string
+		wantOlder           string
+		wantSections        string
+	}{
+		{name: "vehicle status stale", evDate: "20250115120000", vehicleDate: "20250115090000", wantNewest: "20250115120000", wantOlder: "20250115090000", wantSections: "Doors, windows, fuel, tires, and odometer"},
+		{name: "EV status stale", evDate: "20250115090000", vehicleDate: "20250115120000", wantNewest: "20250115120000", wantOlder: "20250115090000", wantSections: "Battery and climate"},
+		{name: "within the threshold", evDate: "20250115120000", vehicleDate: "20250115115600", wantNewest: "20250115120000"},
+		{name: "newer vehicle status within the threshold", evDate: "20250115120000", vehicleDate: "20250115120400", wantNewest: "20250115120400"},
+		{name: "vehicle status not reported", evDate: "20250115120000", wantNewest: "20250115120000"},
+		{name: "EV status not reported", vehicleDate: "20250115120000", wantNewest: "20250115120000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			newest, older, sections := statusTimestamps(tt.evDate, tt.vehicleDate)
+			assert.Equal(t, tt.wantNewest, newest)
+			assert.Equal(t, tt.wantOlder, older)
+			assert.Equal(t, tt.wantSections, sections)
+		})
+	}
+}
+
+// TestCheckStatusAge tests --max-age with and without --acknowledge-stale.
+func TestCheckStatusAge(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = "20250115110000"
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	evStatus.ResultData[0].OccurrenceDate = "20250115120000"
+	now := time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)
+
+	stale, err := checkStatusAge(vehicleStatus, evStatus, time.Hour, false, now)
+	require.NoError(t, err)
+	assert.False(t, stale, "30 minutes old is within an hour")
+
+	_, err = checkStatusAge(vehicleStatus, evStatus, 10*time.Minute, false, now)
+	require.EqualError(t, err, "status is 30m0s old, older than --max-age 10m0s (use --acknowledge-stale to show it anyway)")
+
+	stale, err = checkStatusAge(vehicleStatus, evStatus, 10*time.Minute, true, now)
+	require.NoError(t, err)
+	assert.True(t, stale)
+
+	// Without an EV status timestamp, the position time is used.
+	noOccurrence := apitest.NewEVVehicleStatus().Build()
+	noOccurrence.ResultData[0].OccurrenceDate = ""
+	age, ok := statusAge(vehicleStatus, noOccurrence, now)
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Minute, age)
+
+	// Status without any timestamp can't be judged, so it isn't stale.
+	vehicleStatus.AlertInfos[0].PositionInfo.AcquisitionDatetime = ""
+	stale, err = checkStatusAge(vehicleStatus, noOccurrence, time.Minute, false, now)
+	require.NoError(t, err)
+	assert.False(t, stale)
+}
+
+// TestValidateMaxAge tests --max-age and --acknowledge-stale validation.
+func TestValidateMaxAge(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, validateMaxAge(0, false))
+	require.NoError(t, validateMaxAge(time.Hour, true))
+	require.EqualError(t, validateMaxAge(0, true), "--acknowledge-stale needs --max-age")
+	require.ErrorContains(t, validateMaxAge(-time.Hour, false), "--max-age must not be negative")
+}
+
+// TestDisplayAllStatus_Stale tests that stale status is marked in text and JSON,
+// and fresh status isn't.
+func TestDisplayAllStatus_Stale(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+	vehicleStatus := apitest.NewVehicleStatus().Build()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456", ModelName: "CX-90 PHEV", ModelYear: "2024"}}
+
+	tests := []struct {
+		name  string
+		stale bool
+	}{
+		{name: "fresh", stale: false},
+		{name: "stale", stale: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputText, stale: tt.stale})
+			require.NoError(t, err)
+			result, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: outputJSON, stale: tt.stale})
+			require.NoError(t, err)
+			data := parseJSONToMap(t, result)
+
+			if tt.stale {
+				assert.Regexp(t, `^\[STALE\] CX-90 PHEV`, text)
+				assert.Equal(t, true, data["stale"])
+			} else {
+				assert.NotContains(t, text, "[STALE]")
+				assert.NotContains(t, data, "stale")
+			}
+		})
+	}
+}
+
+// TestDisplayAllStatus_NoHeader tests that --no-header leaves only the status lines.
+func TestDisplayAllStatus_NoHeader(t *testing.T) {
+	t.Parallel()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456", ModelName: "CX-90 PHEV", ModelYear: "2024"}}
+	result, err := displayAllStatus(apitest.NewVehicleStatus().Build(), apitest.NewEVVehicleStatus().Build(), vehicleInfo, statusDisplayOptions{
+		format:   outputText,
+		verbose:  true,
+		noHeader: true,
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, result, "CX-90 PHEV")
+	assert.NotContains(t, result, "JM3KKEHC1R0123456")
+	assert.NotContains(t, result, "Status as of")
+	assert.Regexp(t, `^BATTERY:`, result)
+}
+
+// TestRunStatusOdometer tests the odometer view with and without the trip meter.
+func TestRunStatusOdometer(t *testing.T) {
+	t.Parallel()
+	distance, driveTime, avgFuel := 152.3, 125.0, 6.4
+	withTrip := apitest.NewVehicleStatus().Build()
+	withTrip.RemoteInfos[0].DriveInformation = api.DriveInformation{
+		OdoDispValue: 12345.6,
+		Drv1Distnc:   &distance,
+		Drv1DrvTm:    &driveTime,
+		Drv1AvlFuelG: &avgFuel,
+	}
+	withoutTrip := apitest.NewVehicleStatus().Build()
+	withoutTrip.RemoteInfos[0].DriveInformation.OdoDispValue = 12345.6
+
+	tests := []struct {
+		name     string
+		status   *api.VehicleStatusResponse
+		trip     bool
+		expected string
+	}{
+		{
+			name:     "odometer only",
+			status:   withTrip,
+			expected: "ODOMETER: 12,345.6 km\n",
+		},
+		{
+			name:     "with trip",
+			status:   withTrip,
+			trip:     true,
+			expected: "ODOMETER: 12,345.6 km\nTRIP: 152.3 km, 2h 5m driving, avg fuel economy 6.4\n",
+		},
+		{
+			name:     "trip not reported",
+			status:   withoutTrip,
+			trip:     true,
+			expected: "ODOMETER: 12,345.6 km\nTRIP: Not reported by this vehicle\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			require.NoError(t, runStatusOdometer(&out, tt.status, outputText, distanceKm, language.AmericanEnglish, tt.trip))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		require.NoError(t, runStatusOdometer(&out, withTrip, outputJSON, distanceMi, language.AmericanEnglish, false))
+		data := parseJSONToMap(t, out.String())
+		trip, ok := data["trip"].(map[string]any)
+		require.True(t, ok, "expected a trip object, got %v", data["trip"])
+		assert.InDelta(t, 152.3*kmToMiles, trip["distance_mi"], 0.0001)
+		assert.InDelta(t, 125.0, trip["drive_time_minutes"], 0.0001)
+		assert.InDelta(t, 6.4, trip["average_fuel_economy"], 0.0001)
+		assert.NotContains(t, trip, "fuel_used_liters")
+
+		out.Reset()
+		require.NoError(t, runStatusOdometer(&out, withoutTrip, outputJSON, distanceKm, language.AmericanEnglish, true))
+		assert.NotContains(t, parseJSONToMap(t, out.String()), "trip")
+	})
+}
+
 // TestDisplayAllStatus_ErrorHandling tests error cases in displayAllStatus.
 func TestDisplayAllStatus_ErrorHandling(t *testing.T) {
 	t.Parallel()
@@ -1601,22 +2847,26 @@ func TestDisplayAllStatus_ErrorHandling(t *testing.T) {
 	}{
 		{
 			name:          "missing occurrence date",
-			vehicleStatus: NewMockVehicleStatus().Build(),
+			vehicleStatus: apitest.NewVehicleStatus().Build(),
 			evStatus: &api.EVVehicleStatusResponse{
 				ResultCode: api.ResultCodeSuccess,
 				ResultData: []api.EVResultData{},
 			},
 			vehicleInfo: VehicleInfo{
-				VIN: "JM3KKEHC1R0123456",
+				VehicleInfo: api.VehicleInfo{
+					VIN: "JM3KKEHC1R0123456",
+				},
 			},
 			expectError: true,
 		},
 		{
 			name:          "missing HVAC info",
-			vehicleStatus: NewMockVehicleStatus().Build(),
-			evStatus:      NewMockEVVehicleStatus().WithoutHVAC().Build(),
+			vehicleStatus: apitest.NewVehicleStatus().Build(),
+			evStatus:      apitest.NewEVVehicleStatus().WithoutHVAC().Build(),
 			vehicleInfo: VehicleInfo{
-				VIN: "JM3KKEHC1R0123456",
+				VehicleInfo: api.VehicleInfo{
+					VIN: "JM3KKEHC1R0123456",
+				},
 			},
 			expectError: true,
 		},
@@ -1625,7 +2875,7 @@ func TestDisplayAllStatus_ErrorHandling(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			_, err := displayAllStatus(tt.vehicleStatus, tt.evStatus, tt.vehicleInfo, false)
+			_, err := displayAllStatus(tt.vehicleStatus, tt.evStatus, tt.vehicleInfo, statusDisplayOptions{})
 			if tt.expectError {
 				require.Error(t, err, "Expected error, got nil")
 			} else {
@@ -1634,3 +2884,344 @@ func TestDisplayAllStatus_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+// TestRefreshAndWaitForStatus_UnsupportedVehicle tests that ICE vehicles skip the refresh request.
+func TestRefreshAndWaitForStatus_UnsupportedVehicle(t *testing.T) {
+	t.Parallel()
+	client := &mockClientForConfirm{}
+	evStatus := apitest.NewEVVehicleStatus().Build()
+
+	var out bytes.Buffer
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-5", Powertrain: api.PowertrainICE}}
+	result, err := refreshAndWaitForStatus(context.Background(), &out, "status", client, vehicleInfo, evStatus, refreshWaitOptions{refreshWait: 90, pollInterval: refreshPollInterval})
+	require.NoError(t, err)
+	assert.Same(t, evStatus, result)
+	assert.Equal(t, 0, client.refreshVehicleStatusCalls)
+	assert.Contains(t, out.String(), "Refresh not supported on this vehicle (ICE)")
+
+	_, err = refreshAndWaitForStatus(context.Background(), &out, "status", client, vehicleInfo, evStatus, refreshWaitOptions{refreshWait: 90, pollInterval: refreshPollInterval, requireFresh: true})
+	require.ErrorContains(t, err, "--wait-fresh can't get fresh status")
+	assert.Equal(t, 0, client.refreshVehicleStatusCalls)
+}
+
+// TestRefreshAndWaitForStatus_CX5 tests that a CX-5, whose name has no powertrain
+// marker, is recognized as ICE by its model code and returns without refreshing.
+func TestRefreshAndWaitForStatus_CX5(t *testing.T) {
+	t.Parallel()
+	client := &mockClientForConfirm{}
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	details := api.VehicleDetails{
+		EconnectType:           api.EconnectTypeStandard,
+		OtherInformationParsed: api.OtherInformationParsed{ModelName: "CX-5", CarlineName: "CX-5 2.5 S Premium", ModelCode: "KF2P"},
+	}
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-5", ModelCode: "KF2P", Powertrain: details.Powertrain(), EconnectType: details.EconnectType}}
+
+	var out bytes.Buffer
+	start := time.Now()
+	result, err := refreshAndWaitForStatus(context.Background(), &out, "status", client, vehicleInfo, evStatus, refreshWaitOptions{refreshWait: 90, pollInterval: refreshPollInterval})
+	require.NoError(t, err)
+	assert.Same(t, evStatus, result)
+	assert.Less(t, time.Since(start), time.Second, "should return without waiting for --refresh-wait")
+	assert.Equal(t, 0, client.refreshVehicleStatusCalls)
+	assert.Contains(t, out.String(), "Refresh not supported on this vehicle (ICE)")
+}
+
+// TestRefreshAndWaitForStatus_Timeout tests that stale status is a warning with --refresh
+// and a timeout error with --wait-fresh.
+func TestRefreshAndWaitForStatus_Timeout(t *testing.T) {
+	t.Parallel()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-90 PHEV", Powertrain: api.PowertrainPHEV}}
+
+	tests := []struct {
+		name         string
+		requireFresh bool
+	}{
+		{name: "refresh returns stale status", requireFresh: false},
+		{name: "wait-fresh fails", requireFresh: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			client := &mockClientForConfirm{}
+			var out bytes.Buffer
+
+			// The parent deadline expires long before the first 30 second poll.
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+
+			result, err := refreshAndWaitForStatus(ctx, &out, "status", client, vehicleInfo, evStatus, refreshWaitOptions{refreshWait: 10, pollInterval: refreshPollInterval, requireFresh: tt.requireFresh})
+			assert.Equal(t, 1, client.refreshVehicleStatusCalls)
+			if tt.requireFresh {
+				require.ErrorContains(t, err, "status did not update within 10s")
+				assert.Equal(t, ExitCodeTimeout, ExitCode(err))
+				assert.NotContains(t, out.String(), "Warning")
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Same(t, evStatus, result)
+			assert.Contains(t, out.String(), "Warning: status did not update within timeout period")
+		})
+	}
+}
+
+// TestRefreshAndWaitForStatus_InProgress tests that with retryOnInProgress a refresh
+// that is already running is waited on, and that it fails otherwise.
+func TestRefreshAndWaitForStatus_InProgress(t *testing.T) {
+	t.Parallel()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-90 PHEV", Powertrain: api.PowertrainPHEV}}
+	newClient := func() *mockClientForConfirm {
+		return &mockClientForConfirm{
+			refreshVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) error {
+				return api.NewRequestInProgressError()
+			},
+			getEVVehicleStatusFunc: func(ctx context.Context, internalVIN api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+				freshStatus := apitest.NewEVVehicleStatus().Build()
+				freshStatus.ResultData[0].OccurrenceDate = "20250115121000"
+
+				return freshStatus, nil
+			},
+		}
+	}
+
+	t.Run("waits for the running refresh", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		opts := refreshWaitOptions{refreshWait: 90, pollInterval: time.Millisecond, requireFresh: true, retryOnInProgress: true}
+
+		result, err := refreshAndWaitForStatus(context.Background(), &out, "status", newClient(), vehicleInfo, evStatus, opts)
+		require.NoError(t, err)
+		timestamp, err := result.GetOccurrenceDate()
+		require.NoError(t, err)
+		assert.Equal(t, "20250115121000", timestamp)
+		assert.Contains(t, out.String(), "A refresh is already in progress; waiting for it to finish")
+	})
+
+	t.Run("fails without retryOnInProgress", func(t *testing.T) {
+		t.Parallel()
+		opts := refreshWaitOptions{refreshWait: 90, pollInterval: time.Millisecond}
+
+		_, err := refreshAndWaitForStatus(context.Background(), &bytes.Buffer{}, "status", newClient(), vehicleInfo, evStatus, opts)
+		require.ErrorContains(t, err, "failed to refresh vehicle status")
+		assert.Equal(t, ExitCodeVehicleUnavailable, ExitCode(err))
+	})
+}
+
+// TestRefreshAndWaitForStatus_Quiet tests that progress messages go to the info writer,
+// which is io.Discard with --quiet.
+func TestRefreshAndWaitForStatus_Quiet(t *testing.T) {
+	t.Parallel()
+	client := &mockClientForConfirm{}
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin", ModelName: "CX-5", Powertrain: api.PowertrainICE}}
+
+	var out bytes.Buffer
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{Quiet: true})
+	result, err := refreshAndWaitForStatus(ctx, infoWriter(ctx, &out), "status", client, vehicleInfo, evStatus, refreshWaitOptions{refreshWait: 90, pollInterval: refreshPollInterval})
+	require.NoError(t, err)
+	assert.Same(t, evStatus, result)
+	assert.Empty(t, out.String())
+}
+
+// TestRunStatusWindows tests the windows subcommand output and --check exit behavior.
+func TestRunStatusWindows(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+
+	tests := []struct {
+		name          string
+		status        *api.VehicleStatusResponse
+		check         bool
+		expectError   string
+		expectedInOut string
+	}{
+		{
+			name:          "all closed with check",
+			status:        apitest.NewVehicleStatus().Build(),
+			check:         true,
+			expectedInOut: "WINDOWS: All closed",
+		},
+		{
+			name:          "one open with check",
+			status:        apitest.NewVehicleStatus().WithWindowPositions(0, 0, 40, 0).Build(),
+			check:         true,
+			expectError:   "1 window(s) open",
+			expectedInOut: "WINDOWS: Rear left 40%",
+		},
+		{
+			name:          "all open with check",
+			status:        apitest.NewVehicleStatus().WithWindowPositions(10, 20, 30, 40).Build(),
+			check:         true,
+			expectError:   "4 window(s) open",
+			expectedInOut: "Driver 10%",
+		},
+		{
+			name:          "open without check",
+			status:        apitest.NewVehicleStatus().WithWindowPositions(10, 0, 0, 0).Build(),
+			expectedInOut: "WINDOWS: Driver 10%",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := runStatusWindows(&out, tt.status, outputText, tt.check)
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Contains(t, out.String(), tt.expectedInOut)
+		})
+	}
+}
+
+// TestNewOutputFormat tests resolving the --json, --json-compact, and --no-pretty flags.
+func TestNewOutputFormat(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, outputText, newOutputFormat(context.Background(), false, false))
+	assert.Equal(t, outputJSON, newOutputFormat(context.Background(), true, false))
+	assert.Equal(t, outputJSONCompact, newOutputFormat(context.Background(), false, true))
+	assert.Equal(t, outputJSONCompact, newOutputFormat(context.Background(), true, true))
+
+	noPretty := ContextWithConfig(context.Background(), &CLIConfig{NoPretty: true})
+	assert.Equal(t, outputText, newOutputFormat(noPretty, false, false))
+	assert.Equal(t, outputJSONCompact, newOutputFormat(noPretty, true, false))
+
+	data := map[string]any{"battery": map[string]any{"level": 80}}
+	pretty, err := toJSON(data, newOutputFormat(context.Background(), true, false))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"battery\": {\n    \"level\": 80\n  }\n}", pretty)
+	compact, err := toJSON(data, newOutputFormat(noPretty, true, false))
+	require.NoError(t, err)
+	assert.Equal(t, `{"battery":{"level":80}}`, compact)
+	assert.False(t, outputText.isJSON())
+	assert.True(t, outputJSON.isJSON())
+	assert.True(t, outputJSONCompact.isJSON())
+}
+
+// TestJSONCompact_EquivalentToIndented tests that compact and indented JSON parse to the same data.
+func TestJSONCompact_EquivalentToIndented(t *testing.T) {
+	t.Parallel()
+	vehicleStatus := apitest.NewVehicleStatus().WithWindowPositions(25, 0, 0, 0).Build()
+	evStatus := apitest.NewEVVehicleStatus().Build()
+	vehicleInfo := VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456", ModelName: "CX-90 PHEV", ModelYear: "2024"}}
+
+	windowsInfo, err := vehicleStatus.GetWindowsInfo()
+	require.NoError(t, err)
+	batteryInfo, err := evStatus.GetBatteryInfo()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		render func(format outputFormat) (string, error)
+	}{
+		{
+			name: "full status",
+			render: func(format outputFormat) (string, error) {
+				return displayAllStatus(vehicleStatus, evStatus, vehicleInfo, statusDisplayOptions{format: format})
+			},
+		},
+		{
+			name: "battery",
+			render: func(format outputFormat) (string, error) {
+				return formatBatteryStatus(batteryInfo, format, defaultBarWidth, distanceKm, language.AmericanEnglish)
+			},
+		},
+		{
+			name: "windows",
+			render: func(format outputFormat) (string, error) {
+				return formatWindowsStatus(windowsInfo, format)
+			},
+		},
+		{
+			name: "charge schedule",
+			render: func(format outputFormat) (string, error) {
+				return formatChargeSchedule([]api.ChargeWindow{{StartTime: "2300", EndTime: "0600", Enabled: true}}, format)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			indented, err := tt.render(outputJSON)
+			require.NoError(t, err)
+			compact, err := tt.render(outputJSONCompact)
+			require.NoError(t, err)
+
+			assert.Contains(t, indented, "\n", "Expected indented JSON to span lines")
+			assert.NotContains(t, compact, "\n", "Expected compact JSON on a single line")
+			assert.Equal(t, parseJSONToMap(t, indented), parseJSONToMap(t, compact))
+		})
+	}
+}
+
+// TestFormatStatusSummary tests the one-line --summary sentence.
+func TestFormatStatusSummary(t *testing.T) {
+	t.Parallel()
+	parked := 0.0
+	lockedParked := apitest.NewVehicleStatus().WithDoorStatus(api.DoorStatus{
+		DriverLocked:    true,
+		PassengerLocked: true,
+		RearLeftLocked:  true,
+		RearRightLocked: true,
+	}).Build()
+	lockedParked.AlertInfos[0].PositionInfo.SpeedKmh = &parked
+	phev := VehicleInfo{VehicleInfo: api.VehicleInfo{ModelName: "CX-90 PHEV", Powertrain: api.PowertrainPHEV}}
+
+	tests := []struct {
+		name          string
+		vehicleStatus *api.VehicleStatusResponse
+		evStatus      *api.EVVehicleStatusResponse
+		vehicleInfo   VehicleInfo
+		maxLen        int
+		want          string
+	}{
+		{
+			name:          "charging PHEV",
+			vehicleStatus: lockedParked,
+			evStatus:      apitest.NewEVVehicleStatus().WithPluggedIn(true).WithCharging(true).Build(),
+			vehicleInfo:   phev,
+			maxLen:        maxSummaryLength,
+			want:          "CX-90 PHEV: 80% battery (plugged, charging), all doors locked, parked.",
+		},
+		{
+			name:          "combustion vehicle without speed",
+			vehicleStatus: apitest.NewVehicleStatus().Build(),
+			evStatus:      apitest.NewEVVehicleStatus().Build(),
+			vehicleInfo:   VehicleInfo{VehicleInfo: api.VehicleInfo{Nickname: "Daily", Powertrain: api.PowertrainICE}},
+			maxLen:        maxSummaryLength,
+			want:          "Daily: 0% fuel, doors unlocked.",
+		},
+		{
+			name:          "drops trailing clauses to fit",
+			vehicleStatus: lockedParked,
+			evStatus:      apitest.NewEVVehicleStatus().Build(),
+			vehicleInfo:   phev,
+			maxLen:        40,
+			want:          "CX-90 PHEV: 80% battery (unplugged).",
+		},
+		{
+			name:          "truncates a single long clause",
+			vehicleStatus: lockedParked,
+			evStatus:      apitest.NewEVVehicleStatus().Build(),
+			vehicleInfo:   phev,
+			maxLen:        20,
+			want:          "CX-90 PHEV: 80% bat…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := formatStatusSummary(tt.vehicleStatus, tt.evStatus, tt.vehicleInfo, tt.maxLen)
+			assert.Equal(t, tt.want, got)
+			assert.LessOrEqual(t, utf8.RuneCountInString(got), tt.maxLen)
+		})
+	}
+}
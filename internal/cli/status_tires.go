@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cv/mcs/internal/config"
+)
+
+// savedTireTargets returns the recommended pressures saved for key, overridden
+// by the ones given in targets.
+func savedTireTargets(ctx context.Context, key string, targets tirePressureTargets) (tirePressureTargets, error) {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return targets, err
+	}
+
+	pressures, err := config.LoadTirePressuresFrom(paths.TireFile)
+	if err != nil {
+		return targets, fmt.Errorf("failed to load recommended tire pressures: %w", err)
+	}
+
+	saved := pressures[key]
+	if targets.frontPSI == 0 {
+		targets.frontPSI = saved.FrontPSI
+	}
+	if targets.rearPSI == 0 {
+		targets.rearPSI = saved.RearPSI
+	}
+
+	return targets, nil
+}
+
+// saveTireTargets stores targets as the recommended pressures for key, for
+// later runs of mcs status tires.
+func saveTireTargets(ctx context.Context, key string, targets tirePressureTargets) error {
+	paths, err := resolvePaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	pressures, err := config.LoadTirePressuresFrom(paths.TireFile)
+	if err != nil {
+		return fmt.Errorf("failed to load recommended tire pressures: %w", err)
+	}
+
+	pressures[key] = config.TirePressure{FrontPSI: targets.frontPSI, RearPSI: targets.rearPSI}
+	if err := config.SaveTirePressuresTo(pressures, paths.TireFile); err != nil {
+		return fmt.Errorf("failed to save recommended tire pressures: %w", err)
+	}
+
+	return nil
+}
+
+// promptTireTargets asks for the recommended front and rear pressures. An empty
+// answer leaves that pressure at 0, so the one saved for the vehicle applies.
+func promptTireTargets(in io.Reader, out io.Writer) (tirePressureTargets, error) {
+	reader := bufio.NewReader(in)
+	ask := func(axle string) (float64, error) {
+		_, _ = fmt.Fprintf(out, "Recommended %s tire pressure (PSI, empty to keep the saved one): ", axle)
+
+		line, err := reader.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			return 0, fmt.Errorf("failed to read %s tire pressure: %w", axle, err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return 0, nil
+		}
+
+		psi, err := strconv.ParseFloat(line, 64)
+		if err != nil || psi <= 0 {
+			return 0, fmt.Errorf("invalid %s tire pressure %q: must be a positive number", axle, line)
+		}
+
+		return psi, nil
+	}
+
+	var targets tirePressureTargets
+	var err error
+	if targets.frontPSI, err = ask("front"); err != nil {
+		return targets, err
+	}
+	if targets.rearPSI, err = ask("rear"); err != nil {
+		return targets, err
+	}
+
+	return targets, nil
+}
+
+// formatTireTargets describes saved recommended pressures, e.g. "36 PSI front,
+// 33 PSI rear".
+func formatTireTargets(targets tirePressureTargets) string {
+	var parts []string
+	if targets.frontPSI > 0 {
+		parts = append(parts, fmt.Sprintf("%g PSI front", targets.frontPSI))
+	}
+	if targets.rearPSI > 0 {
+		parts = append(parts, fmt.Sprintf("%g PSI rear", targets.rearPSI))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSavedTireTargets tests that saved recommended pressures are applied to the
+// tire deltas on a later run, per vehicle, with flags taking precedence.
+func TestSavedTireTargets(t *testing.T) {
+	t.Parallel()
+	tireFile := filepath.Join(t.TempDir(), "tires.json")
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{TireFile: tireFile})
+	tireInfo := api.TireInfo{FrontLeftPsi: 35.0, FrontRightPsi: 36.5, RearLeftPsi: 33.0, RearRightPsi: 31.5}
+
+	require.NoError(t, saveTireTargets(ctx, "VIN1", tirePressureTargets{frontPSI: 36, rearPSI: 33}))
+
+	// A later run without flags compares against the saved pressures.
+	targets, err := savedTireTargets(ctx, "VIN1", tirePressureTargets{})
+	require.NoError(t, err)
+	assert.Equal(t, tirePressureTargets{frontPSI: 36, rearPSI: 33}, targets)
+	got, err := formatTiresReport(tireInfo, outputJSON, api.Celsius, targets)
+	require.NoError(t, err)
+	data := parseJSONToMap(t, got)
+	assertMapValue(t, data, "front_left_delta_psi", -1.0)
+	assertMapValue(t, data, "rear_right_delta_psi", -1.5)
+
+	// A flag overrides its saved pressure only.
+	targets, err = savedTireTargets(ctx, "VIN1", tirePressureTargets{frontPSI: 38})
+	require.NoError(t, err)
+	assert.Equal(t, tirePressureTargets{frontPSI: 38, rearPSI: 33}, targets)
+
+	// Other vehicles have nothing saved.
+	targets, err = savedTireTargets(ctx, "VIN2", tirePressureTargets{})
+	require.NoError(t, err)
+	assert.Equal(t, tirePressureTargets{}, targets)
+
+	pressures, err := config.LoadTirePressuresFrom(tireFile)
+	require.NoError(t, err)
+	assert.Equal(t, config.TirePressures{"VIN1": {FrontPSI: 36, RearPSI: 33}}, pressures)
+}
+
+// TestPromptTireTargets tests asking for the recommended pressures to save.
+func TestPromptTireTargets(t *testing.T) {
+	t.Parallel()
+
+	t.Run("blank leaves the saved value", func(t *testing.T) {
+		t.Parallel()
+		var out bytes.Buffer
+		targets, err := promptTireTargets(strings.NewReader("36\n\n"), &out)
+		require.NoError(t, err)
+		assert.Equal(t, tirePressureTargets{frontPSI: 36}, targets)
+		assert.Equal(t, "Recommended front tire pressure (PSI, empty to keep the saved one): "+
+			"Recommended rear tire pressure (PSI, empty to keep the saved one): ", out.String())
+	})
+
+	t.Run("invalid pressure", func(t *testing.T) {
+		t.Parallel()
+		_, err := promptTireTargets(strings.NewReader("-5\n"), &bytes.Buffer{})
+		require.EqualError(t, err, `invalid front tire pressure "-5": must be a positive number`)
+	})
+
+	t.Run("no input", func(t *testing.T) {
+		t.Parallel()
+		_, err := promptTireTargets(strings.NewReader(""), &bytes.Buffer{})
+		require.ErrorContains(t, err, "failed to read front tire pressure")
+	})
+}
+
+// TestFormatTireTargets tests the description of saved recommended pressures.
+func TestFormatTireTargets(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "36 PSI front, 33 PSI rear", formatTireTargets(tirePressureTargets{frontPSI: 36, rearPSI: 33}))
+	assert.Equal(t, "35.5 PSI rear", formatTireTargets(tirePressureTargets{rearPSI: 35.5}))
+	assert.Equal(t, "none", formatTireTargets(tirePressureTargets{}))
+}
+
+// TestStatusTires_CompareRecommendedNonInteractive tests that
+// --compare-recommended-tire without pressures fails before logging in when
+// there's no terminal to ask on.
+func TestStatusTires_CompareRecommendedNonInteractive(t *testing.T) {
+	t.Parallel()
+	cfg := testCLIConfig()
+	cfg.CacheFile = t.TempDir() + "/token.json"
+	rootCmd := NewRootCmd(cfg)
+	rootCmd.AddCommand(NewStatusCmd())
+	rootCmd.SetArgs([]string{"--config", t.TempDir() + "/missing.toml", "status", "tires", "--compare-recommended-tire"})
+	rootCmd.SetIn(strings.NewReader("36\n33\n"))
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetErr(&bytes.Buffer{})
+
+	require.EqualError(t, rootCmd.Execute(), "--compare-recommended-tire needs --front-psi or --rear-psi when not run interactively")
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// statusSectionLabels maps each top-level status data section to the label of
+// the text status line that shows it.
+func statusSectionLabels() map[string]string {
+	return map[string]string{
+		"battery":       "BATTERY:",
+		"fuel":          "FUEL:",
+		"climate":       "CLIMATE:",
+		"doors":         "DOORS:",
+		"windows":       "WINDOWS:",
+		"hazards":       "HAZARDS:",
+		"fuel_lid_open": "FUEL LID:",
+		"tires":         "TIRES:",
+		"location":      "LOCATION:",
+		"odometer":      "ODOMETER:",
+	}
+}
+
+// changedStatusFields returns the sorted keys of two flattened status maps whose
+// values differ, including keys present in only one of them.
+func changedStatusFields(previous, current map[string]any) []string {
+	var changed []string
+	for key, value := range current {
+		if old, ok := previous[key]; !ok || !reflect.DeepEqual(old, value) {
+			changed = append(changed, key)
+		}
+	}
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			changed = append(changed, key)
+		}
+	}
+	slices.Sort(changed)
+
+	return changed
+}
+
+// highlightChangedLines colors the label of every status line whose data is
+// among the changed flattened keys, e.g. "BATTERY:" when battery.battery_level
+// changed. Nothing is highlighted when colors are disabled.
+func highlightChangedLines(output string, changed []string) string {
+	labels := map[string]bool{}
+	sectionLabels := statusSectionLabels()
+	for _, key := range changed {
+		section, _, _ := strings.Cut(key, ".")
+		if label, ok := sectionLabels[section]; ok {
+			labels[label] = true
+		}
+	}
+	if len(labels) == 0 {
+		return output
+	}
+
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		for _, label := range slices.Sorted(maps.Keys(labels)) {
+			if strings.HasPrefix(line, label) {
+				lines[i] = Yellow(label) + line[len(label):]
+
+				break
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// runStatusWatch prints the full status every interval until ctx is canceled,
+// refreshing it first with --refresh or --wait-fresh. With --no-initial-fetch
+// the first status is fetched after one interval rather than straight away.
+// From the second status on, the lines whose values changed since the previous
+// one are highlighted. Errors fetching a status are reported and retried at
+// the next interval.
+func runStatusWatch(ctx context.Context, cmd *cobra.Command, client vehicleStatusGetter, vehicleInfo VehicleInfo, opts statusOptions, displayOpts statusDisplayOptions, interval time.Duration) error {
+	var previous map[string]any
+	wait := opts.noInitialFetch
+	for {
+		if wait {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+		wait = true
+
+		output, current, err := fetchStatusForWatch(ctx, cmd, client, vehicleInfo, opts, displayOpts)
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case err != nil:
+			_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+		default:
+			if previous != nil {
+				output = highlightChangedLines(output, changedStatusFields(previous, current))
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), output)
+			_, _ = fmt.Fprintln(cmd.OutOrStdout())
+			previous = current
+		}
+	}
+}
+
+// fetchStatusForWatch fetches, and optionally refreshes, the status, returning
+// its text rendering and flattened data.
+func fetchStatusForWatch(ctx context.Context, cmd *cobra.Command, client vehicleStatusGetter, vehicleInfo VehicleInfo, opts statusOptions, displayOpts statusDisplayOptions) (string, map[string]any, error) {
+	evStatus, err := client.GetEVVehicleStatus(ctx, vehicleInfo.InternalVIN)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get EV status: %w", err)
+	}
+	if opts.refresh || opts.waitFresh {
+		evStatus, err = refreshAndWaitForStatus(ctx, infoWriter(ctx, cmd.OutOrStdout()), commandAction(cmd), client, vehicleInfo, evStatus, opts.refreshWaitOptions())
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	vehicleStatus, err := client.GetVehicleStatus(ctx, vehicleInfo.InternalVIN)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get vehicle status: %w", err)
+	}
+
+	output, err := displayAllStatus(vehicleStatus, evStatus, vehicleInfo, displayOpts)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return output, flattenStatusMap(buildAllStatusData(vehicleStatus, evStatus, vehicleInfo, displayOpts.distanceUnit)), nil
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestChangedStatusFields tests which flattened keys differ between two statuses.
+func TestChangedStatusFields(t *testing.T) {
+	t.Parallel()
+	previous := map[string]any{
+		"battery.battery_level": float64(61),
+		"battery.charging":      true,
+		"doors.all_locked":      true,
+		"location.latitude":     float64(37.1),
+	}
+	current := map[string]any{
+		"battery.battery_level": float64(62),
+		"battery.charging":      true,
+		"doors.all_locked":      true,
+		"odometer.odometer_km":  float64(100),
+	}
+
+	assert.Equal(t, []string{"battery.battery_level", "location.latitude", "odometer.odometer_km"}, changedStatusFields(previous, current))
+	assert.Empty(t, changedStatusFields(current, current))
+}
+
+// TestHighlightChangedLines tests that only the labels of changed lines are
+// colored, and nothing is when colors are off.
+func TestHighlightChangedLines(t *testing.T) {
+	t.Parallel()
+	colorTestMutex.Lock()
+	defer colorTestMutex.Unlock()
+	oldColorEnabled := IsColorEnabled()
+	defer SetColorEnabled(oldColorEnabled)
+
+	output := "BATTERY: 62%\nFUEL: 75%\nFUEL LID: Open\nDOORS: All locked"
+	changed := []string{"battery.battery_level", "fuel_lid_open", "ev_status_timestamp"}
+
+	SetColorEnabled(true)
+	assert.Equal(t, colorYellow+"BATTERY:"+colorReset+" 62%\nFUEL: 75%\n"+colorYellow+"FUEL LID:"+colorReset+" Open\nDOORS: All locked",
+		highlightChangedLines(output, changed))
+
+	SetColorEnabled(false)
+	assert.Equal(t, output, highlightChangedLines(output, changed))
+}
+
+// TestRunStatusWatch tests that the status is printed on every poll until the
+// context is canceled.
+func TestRunStatusWatch(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	polls := 0
+	client := &mockClientForConfirm{
+		getEVVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+			polls++
+			if polls == 3 {
+				cancel()
+			}
+
+			return apitest.NewEVVehicleStatus().WithCharging(polls == 2).Build(), nil
+		},
+		getVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.VehicleStatusResponse, error) {
+			return apitest.NewVehicleStatus().Build(), nil
+		},
+	}
+
+	var out, errOut bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	err := runStatusWatch(ctx, cmd, client, VehicleInfo{VehicleInfo: api.VehicleInfo{VIN: "JM3KKEHC1R0123456"}}, statusOptions{}, statusDisplayOptions{format: outputText}, time.Millisecond)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, 2, strings.Count(out.String(), "BATTERY:"), "the canceled third poll isn't printed")
+	assert.Empty(t, errOut.String())
+}
+
+// TestRunStatusWatch_NoInitialFetch tests that --no-initial-fetch delays the first
+// status check by one interval.
+func TestRunStatusWatch_NoInitialFetch(t *testing.T) {
+	t.Parallel()
+	withColorsDisabled(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	const interval = 50 * time.Millisecond
+	var firstFetch time.Duration
+	start := time.Now()
+	client := &mockClientForConfirm{
+		getEVVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+			firstFetch = time.Since(start)
+			cancel()
+
+			return apitest.NewEVVehicleStatus().Build(), nil
+		},
+		getVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.VehicleStatusResponse, error) {
+			return apitest.NewVehicleStatus().Build(), nil
+		},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := runStatusWatch(ctx, cmd, client, VehicleInfo{}, statusOptions{noInitialFetch: true}, statusDisplayOptions{format: outputText}, interval)
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, firstFetch, interval)
+}
+
+// TestStatusCommand_NoInitialFetchRequiresWatch tests that --no-initial-fetch is
+// rejected without --watch.
+func TestStatusCommand_NoInitialFetchRequiresWatch(t *testing.T) {
+	t.Parallel()
+	err := runStatus(&cobra.Command{}, statusOptions{refreshWait: 90, barWidth: defaultBarWidth, timestampFormat: timestampFormatDefault, noInitialFetch: true})
+	require.EqualError(t, err, "--no-initial-fetch requires --watch")
+}
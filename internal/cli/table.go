@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// Values of the --output flag.
+const (
+	outputModeText  = "text"
+	outputModeTable = "table"
+)
+
+// addOutputFlag registers the --output flag on a command that can print a table.
+func addOutputFlag(cmd *cobra.Command, output *string) {
+	cmd.Flags().StringVar(output, "output", outputModeText, "output style: text or table (aligned columns)")
+}
+
+// applyOutputMode resolves the --output flag on top of the format chosen by the
+// JSON flags.
+func applyOutputMode(format outputFormat, mode string) (outputFormat, error) {
+	switch mode {
+	case "", outputModeText:
+		return format, nil
+	case outputModeTable:
+		return outputTable, nil
+	default:
+		return format, fmt.Errorf("invalid --output %q: must be %s or %s", mode, outputModeText, outputModeTable)
+	}
+}
+
+// formatTable aligns rows into columns separated by two spaces, without trailing
+// spaces after an empty last cell. Cells must be plain text: color codes and emoji
+// would throw off the column widths.
+func formatTable(rows [][]string) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		_, _ = fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	_ = w.Flush()
+
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTable(t *testing.T) {
+	t.Parallel()
+	output := formatTable([][]string{
+		{"VIN", "NICKNAME", "YEAR"},
+		{"JM3KKEHC1R0000001", "-", "2024"},
+		{"JM3KKEHC1R0000002", "Weekend Car", "2025"},
+	})
+
+	want := "VIN                NICKNAME     YEAR\n" +
+		"JM3KKEHC1R0000001  -            2024\n" +
+		"JM3KKEHC1R0000002  Weekend Car  2025"
+	assert.Equal(t, want, output)
+}
+
+func TestApplyOutputMode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		mode    string
+		format  outputFormat
+		want    outputFormat
+		wantErr string
+	}{
+		{mode: "", format: outputJSON, want: outputJSON},
+		{mode: "text", format: outputText, want: outputText},
+		{mode: "table", format: outputText, want: outputTable},
+		{mode: "csv", format: outputText, wantErr: `invalid --output "csv": must be text or table`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			t.Parallel()
+			got, err := applyOutputMode(tt.format, tt.mode)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Theme names accepted by --theme.
+const (
+	themeNameASCII = "ascii"
+	themeNameEmoji = "emoji"
+)
+
+// theme holds the symbols prefixed to status text. The zero value is the plain
+// ASCII theme, which adds no symbols.
+type theme struct {
+	locked   string
+	unlocked string
+	open     string
+}
+
+// emojiTheme returns the theme used by --theme emoji.
+func emojiTheme() theme {
+	return theme{
+		locked:   "🔒",
+		unlocked: "🔓",
+		open:     "🚪",
+	}
+}
+
+// parseTheme parses a --theme value. An empty value means ASCII.
+func parseTheme(name string) (theme, error) {
+	switch name {
+	case "", themeNameASCII:
+		return theme{}, nil
+	case themeNameEmoji:
+		return emojiTheme(), nil
+	default:
+		return theme{}, fmt.Errorf("--theme must be %s or %s, got %q", themeNameASCII, themeNameEmoji, name)
+	}
+}
+
+// themeFromContext returns the --theme chosen on the command line. Emoji are
+// only used when out is a terminal, so piped output stays plain.
+func themeFromContext(ctx context.Context, out io.Writer) (theme, error) {
+	cfg := ConfigFromContext(ctx)
+	if cfg == nil {
+		return theme{}, nil
+	}
+
+	th, err := parseTheme(cfg.Theme)
+	if err != nil {
+		return theme{}, err
+	}
+	if !IsTTY(out) {
+		return theme{}, nil
+	}
+
+	return th, nil
+}
+
+// withSymbol prefixes text with symbol, or returns text unchanged when the
+// theme has no symbol for it.
+func withSymbol(symbol, text string) string {
+	if symbol == "" {
+		return text
+	}
+
+	return symbol + " " + text
+}
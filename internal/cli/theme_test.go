@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTheme(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		value   string
+		want    theme
+		wantErr bool
+	}{
+		{name: "empty defaults to ascii", value: "", want: theme{}},
+		{name: "ascii", value: "ascii", want: theme{}},
+		{name: "emoji", value: "emoji", want: emojiTheme()},
+		{name: "unknown theme", value: "unicode", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseTheme(tt.value)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "--theme")
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestThemeFromContext(t *testing.T) {
+	t.Parallel()
+	th, err := themeFromContext(context.Background(), &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, theme{}, th)
+
+	// Emoji fall back to ASCII when output isn't a terminal.
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{Theme: "emoji"})
+	th, err = themeFromContext(ctx, &bytes.Buffer{})
+	require.NoError(t, err)
+	assert.Equal(t, theme{}, th)
+
+	ctx = ContextWithConfig(context.Background(), &CLIConfig{Theme: "sparkles"})
+	_, err = themeFromContext(ctx, &bytes.Buffer{})
+	require.Error(t, err)
+}
+
+func TestFormatDoorsStatus_EmojiTheme(t *testing.T) {
+	withColorsDisabled(t)
+
+	locked, err := formatDoorsStatus(api.DoorStatus{AllLocked: true}, outputText, emojiTheme())
+	require.NoError(t, err)
+	assert.Equal(t, "DOORS: 🔒 All locked", locked)
+
+	issues, err := formatDoorsStatus(api.DoorStatus{
+		PassengerLocked: true,
+		RearLeftLocked:  true,
+		RearRightLocked: true,
+		TrunkOpen:       true,
+	}, outputText, emojiTheme())
+	require.NoError(t, err)
+	assert.Equal(t, "DOORS: 🔓 Driver unlocked, 🚪 Trunk open", issues)
+
+	// JSON output never includes theme symbols.
+	jsonOut, err := formatDoorsStatus(api.DoorStatus{AllLocked: true}, outputJSON, emojiTheme())
+	require.NoError(t, err)
+	assert.NotContains(t, jsonOut, "🔒")
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/cv/mcs/internal/api"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// distanceUnit selects the unit used to display distances. Status data is always
+// in kilometers; conversion happens only when formatting output.
+type distanceUnit string
+
+const (
+	distanceKm distanceUnit = "km"
+	distanceMi distanceUnit = "mi"
+)
+
+// kmToMiles is the number of miles in one kilometer.
+const kmToMiles = 0.621371
+
+// parseDistanceUnit parses a --distance-unit value. An empty value means kilometers.
+func parseDistanceUnit(value string) (distanceUnit, error) {
+	switch distanceUnit(value) {
+	case "", distanceKm:
+		return distanceKm, nil
+	case distanceMi:
+		return distanceMi, nil
+	default:
+		return "", fmt.Errorf("--distance-unit must be km or mi, got %q", value)
+	}
+}
+
+// distanceUnitFromContext returns the --distance-unit chosen on the command line,
+// defaulting to kilometers when no CLI config is attached.
+func distanceUnitFromContext(ctx context.Context) (distanceUnit, error) {
+	cfg := ConfigFromContext(ctx)
+	if cfg == nil {
+		return distanceKm, nil
+	}
+
+	return parseDistanceUnit(cfg.DistanceUnit)
+}
+
+// fromKm converts a distance in kilometers to this unit.
+func (u distanceUnit) fromKm(km float64) float64 {
+	if u == distanceMi {
+		return km * kmToMiles
+	}
+
+	return km
+}
+
+// String returns the unit suffix used in text output ("km" or "mi").
+func (u distanceUnit) String() string {
+	if u == distanceMi {
+		return string(distanceMi)
+	}
+
+	return string(distanceKm)
+}
+
+// key returns a JSON key with the unit appended, e.g. "range_mi".
+func (u distanceUnit) key(base string) string {
+	return base + "_" + u.String()
+}
+
+// earthRadiusKm is the mean radius of the Earth.
+const earthRadiusKm = 6371.0
+
+// haversineKm returns the great-circle distance in kilometers between two points
+// given in decimal degrees.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// temperatureUnitFromContext returns the --temp-unit chosen on the command line,
+// defaulting to Celsius when no CLI config is attached.
+func temperatureUnitFromContext(ctx context.Context) (api.TemperatureUnit, error) {
+	cfg := ConfigFromContext(ctx)
+	if cfg == nil || cfg.TempUnit == "" {
+		return api.Celsius, nil
+	}
+
+	unit, err := api.ParseTemperatureUnit(cfg.TempUnit)
+	if err != nil {
+		return 0, fmt.Errorf("--temp-unit must be c or f, got %q", cfg.TempUnit)
+	}
+
+	return unit, nil
+}
+
+// roundTemperature converts a Celsius temperature to unit and rounds it for
+// display. Fahrenheit is always rounded to whole degrees; Celsius to whole
+// degrees, or to one decimal with oneDecimal so that half-degree climate targets
+// such as 21.5°C aren't hidden. Any unit other than Fahrenheit means Celsius.
+func roundTemperature(celsius float64, unit api.TemperatureUnit, oneDecimal bool) float64 {
+	if unit == api.Fahrenheit {
+		return math.Round(celsius*9/5 + 32)
+	}
+	if oneDecimal {
+		return math.Round(celsius*10) / 10
+	}
+
+	return math.Round(celsius)
+}
+
+// formatTemperature formats a Celsius temperature in unit, rounded to whole
+// degrees, e.g. "28°C" or "82°F".
+func formatTemperature(celsius float64, unit api.TemperatureUnit) string {
+	return temperatureString(roundTemperature(celsius, unit, false), unit, language.Und)
+}
+
+// formatTargetTemperature formats a climate target temperature in unit, keeping
+// one decimal in Celsius when it isn't a whole number, e.g. "21.5°C" ("21,5°C"
+// for de-DE) or "71°F".
+func formatTargetTemperature(celsius float64, unit api.TemperatureUnit, locale language.Tag) string {
+	return temperatureString(roundTemperature(celsius, unit, true), unit, locale)
+}
+
+// temperatureString formats an already converted and rounded temperature with
+// its unit, showing a decimal only when there is one.
+func temperatureString(value float64, unit api.TemperatureUnit, locale language.Tag) string {
+	suffix := "°C"
+	if unit == api.Fahrenheit {
+		suffix = "°F"
+	}
+	decimals := 0
+	if value != math.Trunc(value) {
+		decimals = 1
+	}
+
+	return formatNumber(value, decimals, locale) + suffix
+}
+
+// defaultLocale is the --locale used when none is given.
+const defaultLocale = "en-US"
+
+// localeFromContext returns the --locale chosen on the command line, defaulting
+// to en-US when no CLI config is attached.
+func localeFromContext(ctx context.Context) (language.Tag, error) {
+	cfg := ConfigFromContext(ctx)
+	if cfg == nil || cfg.Locale == "" {
+		return language.AmericanEnglish, nil
+	}
+
+	locale, err := language.Parse(cfg.Locale)
+	if err != nil {
+		return language.Und, fmt.Errorf("--locale must be a language tag such as en-US or de-DE, got %q", cfg.Locale)
+	}
+
+	return locale, nil
+}
+
+// formatNumber formats value with the given number of decimals, using the
+// locale's digit grouping and decimal separator: "12,345.6" for en-US and
+// "12.345,6" for de-DE. The zero Tag means en-US.
+func formatNumber(value float64, decimals int, locale language.Tag) string {
+	if locale == language.Und {
+		locale = language.AmericanEnglish
+	}
+
+	return message.NewPrinter(locale).Sprintf(fmt.Sprintf("%%.%df", decimals), value)
+}
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestParseDistanceUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		value   string
+		want    distanceUnit
+		wantErr bool
+	}{
+		{name: "empty defaults to km", value: "", want: distanceKm},
+		{name: "km", value: "km", want: distanceKm},
+		{name: "mi", value: "mi", want: distanceMi},
+		{name: "unknown unit", value: "miles", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDistanceUnit(tt.value)
+			if tt.wantErr {
+				require.ErrorContains(t, err, "--distance-unit")
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDistanceUnitFromContext(t *testing.T) {
+	t.Parallel()
+	unit, err := distanceUnitFromContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, distanceKm, unit)
+
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{DistanceUnit: "mi"})
+	unit, err = distanceUnitFromContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, distanceMi, unit)
+
+	ctx = ContextWithConfig(context.Background(), &CLIConfig{DistanceUnit: "furlongs"})
+	_, err = distanceUnitFromContext(ctx)
+	require.Error(t, err)
+}
+
+func TestTemperatureUnitFromContext(t *testing.T) {
+	t.Parallel()
+	unit, err := temperatureUnitFromContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, api.Celsius, unit)
+
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{TempUnit: "f"})
+	unit, err = temperatureUnitFromContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, api.Fahrenheit, unit)
+
+	ctx = ContextWithConfig(context.Background(), &CLIConfig{TempUnit: "kelvin"})
+	_, err = temperatureUnitFromContext(ctx)
+	require.ErrorContains(t, err, "--temp-unit")
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	t.Parallel()
+	locale, err := localeFromContext(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, language.AmericanEnglish, locale)
+
+	ctx := ContextWithConfig(context.Background(), &CLIConfig{Locale: "de-DE"})
+	locale, err = localeFromContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, language.MustParse("de-DE"), locale)
+
+	ctx = ContextWithConfig(context.Background(), &CLIConfig{Locale: "not a locale"})
+	_, err = localeFromContext(ctx)
+	require.ErrorContains(t, err, "--locale")
+}
+
+func TestFormatNumber(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		value    float64
+		decimals int
+		locale   language.Tag
+		want     string
+	}{
+		{name: "en-US grouping", value: 12345.6, decimals: 1, locale: language.AmericanEnglish, want: "12,345.6"},
+		{name: "zero tag is en-US", value: 12345.6, decimals: 1, locale: language.Tag{}, want: "12,345.6"},
+		{name: "de-DE grouping", value: 12345.6, decimals: 1, locale: language.MustParse("de-DE"), want: "12.345,6"},
+		{name: "de-DE millions", value: 1234567.89, decimals: 1, locale: language.MustParse("de-DE"), want: "1.234.567,9"},
+		{name: "de-DE no decimals", value: 1234.4, decimals: 0, locale: language.MustParse("de-DE"), want: "1.234"},
+		{name: "fr-FR grouping", value: 12345.6, decimals: 1, locale: language.MustParse("fr-FR"), want: "12\u00a0345,6"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, formatNumber(tt.value, tt.decimals, tt.locale))
+		})
+	}
+}
+
+func TestFormatters_Locale(t *testing.T) {
+	t.Parallel()
+	german := language.MustParse("de-DE")
+
+	odometer, err := formatOdometerStatus(api.OdometerInfo{OdometerKm: 12345.6}, outputText, distanceKm, german)
+	require.NoError(t, err)
+	assert.Equal(t, "ODOMETER: 12.345,6 km", odometer)
+
+	battery, err := formatBatteryStatus(api.BatteryInfo{BatteryLevel: 80, RangeKm: 45.5}, outputText, defaultBarWidth, distanceKm, german)
+	require.NoError(t, err)
+	assert.Contains(t, battery, "(45,5 km range)")
+
+	fuel := formatFuelStatusWithRange(api.FuelInfo{FuelLevel: 50, RangeKm: 1250}, api.BatteryInfo{RangeKm: 1200}, distanceKm, german)
+	assert.Contains(t, fuel, "(50 km EV + 1.200 km fuel = 1.250 km total)")
+
+	// JSON output stays numeric regardless of locale.
+	jsonOut, err := formatOdometerStatus(api.OdometerInfo{OdometerKm: 12345.6}, outputJSON, distanceKm, german)
+	require.NoError(t, err)
+	assert.Contains(t, jsonOut, "12345.6")
+}
+
+func TestDistanceUnit_Conversion(t *testing.T) {
+	t.Parallel()
+	assert.InDelta(t, 100.0, distanceKm.fromKm(100), 0.0001)
+	assert.InDelta(t, 62.1371, distanceMi.fromKm(100), 0.0001)
+	assert.Equal(t, "range_km", distanceKm.key("range"))
+	assert.Equal(t, "odometer_mi", distanceMi.key("odometer"))
+	assert.Equal(t, "km", distanceUnit("").String())
+}
+
+func TestFormatters_DistanceUnit(t *testing.T) {
+	t.Parallel()
+	batteryInfo := api.BatteryInfo{BatteryLevel: 80, RangeKm: 100}
+	fuelInfo := api.FuelInfo{FuelLevel: 50, RangeKm: 500}
+	odometerInfo := api.OdometerInfo{OdometerKm: 10000}
+
+	tests := []struct {
+		name         string
+		unit         distanceUnit
+		wantBattery  string
+		wantFuel     string
+		wantOdometer string
+		wantKeys     []string
+		absentKeys   []string
+	}{
+		{
+			name:         "kilometers",
+			unit:         distanceKm,
+			wantBattery:  "(100.0 km range)",
+			wantFuel:     "(500.0 km range)",
+			wantOdometer: "ODOMETER: 10,000.0 km",
+			wantKeys:     []string{"range_km"},
+			absentKeys:   []string{"range_mi"},
+		},
+		{
+			name:         "miles",
+			unit:         distanceMi,
+			wantBattery:  "(62.1 mi range)",
+			wantFuel:     "(310.7 mi range)",
+			wantOdometer: "ODOMETER: 6,213.7 mi",
+			wantKeys:     []string{"range_mi"},
+			absentKeys:   []string{"range_km"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			battery, err := formatBatteryStatus(batteryInfo, outputText, defaultBarWidth, tt.unit, language.AmericanEnglish)
+			require.NoError(t, err)
+			assert.Contains(t, battery, tt.wantBattery)
+
+			fuel, err := formatFuelStatus(fuelInfo, outputText, tt.unit, language.AmericanEnglish)
+			require.NoError(t, err)
+			assert.Contains(t, fuel, tt.wantFuel)
+
+			odometer, err := formatOdometerStatus(odometerInfo, outputText, tt.unit, language.AmericanEnglish)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOdometer, odometer)
+
+			batteryData := batteryInfoToMap(batteryInfo, tt.unit)
+			fuelData := fuelInfoToMap(fuelInfo, tt.unit)
+			for _, key := range tt.wantKeys {
+				assert.Contains(t, batteryData, key)
+				assert.Contains(t, fuelData, key)
+			}
+			for _, key := range tt.absentKeys {
+				assert.NotContains(t, batteryData, key)
+				assert.NotContains(t, fuelData, key)
+			}
+			assert.Contains(t, odometerInfoToMap(odometerInfo, tt.unit), tt.unit.key("odometer"))
+		})
+	}
+}
+
+func TestRoundTemperature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		celsius    float64
+		unit       api.TemperatureUnit
+		oneDecimal bool
+		want       float64
+	}{
+		{name: "celsius whole", celsius: 21.5, unit: api.Celsius, oneDecimal: false, want: 22},
+		{name: "celsius half degree kept", celsius: 21.5, unit: api.Celsius, oneDecimal: true, want: 21.5},
+		{name: "celsius float noise", celsius: 21.4999, unit: api.Celsius, oneDecimal: true, want: 21.5},
+		{name: "fahrenheit whole", celsius: 21.5, unit: api.Fahrenheit, oneDecimal: false, want: 71},
+		{name: "fahrenheit ignores decimal", celsius: 21.5, unit: api.Fahrenheit, oneDecimal: true, want: 71},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, roundTemperature(tt.celsius, tt.unit, tt.oneDecimal), 0.0001)
+		})
+	}
+}
+
+func TestFormatTargetTemperature(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "21.5°C", formatTargetTemperature(21.5, api.Celsius, language.AmericanEnglish))
+	assert.Equal(t, "21,5°C", formatTargetTemperature(21.5, api.Celsius, language.MustParse("de-DE")))
+	assert.Equal(t, "22°C", formatTargetTemperature(22, api.Celsius, language.AmericanEnglish))
+	assert.Equal(t, "71°F", formatTargetTemperature(21.5, api.Fahrenheit, language.AmericanEnglish))
+}
+
+func TestHaversineKm(t *testing.T) {
+	t.Parallel()
+	// San Francisco to Los Angeles.
+	assert.InDelta(t, 559.1, haversineKm(37.7749, -122.4194, 34.0522, -118.2437), 0.5)
+	assert.InDelta(t, 0.0, haversineKm(37.7749, -122.4194, 37.7749, -122.4194), 1e-9)
+}
@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// watchFieldAliases maps short, friendly field names to flattened status keys.
+func watchFieldAliases() map[string]string {
+	return map[string]string{
+		"battery":    "battery.battery_level",
+		"charging":   "battery.charging",
+		"plugged_in": "battery.plugged_in",
+		"fuel":       "fuel.fuel_level",
+		"locked":     "doors.all_locked",
+		"hvac":       "climate.hvac_on",
+		"odometer":   "odometer.odometer_km",
+	}
+}
+
+// watchOperators lists supported comparison operators, longest first so that
+// ">=" is matched before ">".
+func watchOperators() []string {
+	return []string{">=", "<=", "==", "!=", ">", "<", "="}
+}
+
+// watchCondition is a parsed "field op value" expression.
+type watchCondition struct {
+	field string
+	op    string
+	value string
+}
+
+// String returns the condition in its canonical "field op value" form.
+func (c watchCondition) String() string {
+	return c.field + c.op + c.value
+}
+
+// parseWatchCondition parses an expression like "battery>=80" or "locked==true".
+func parseWatchCondition(expr string) (watchCondition, error) {
+	expr = strings.TrimSpace(expr)
+	idx := strings.IndexAny(expr, "<>=!")
+	for _, op := range watchOperators() {
+		if idx < 0 || !strings.HasPrefix(expr[idx:], op) {
+			continue
+		}
+
+		field := strings.TrimSpace(expr[:idx])
+		value := strings.TrimSpace(expr[idx+len(op):])
+		if field == "" || value == "" {
+			return watchCondition{}, fmt.Errorf("invalid condition %q: expected 'field op value'", expr)
+		}
+		if op == "=" {
+			op = "=="
+		}
+
+		return watchCondition{field: field, op: op, value: value}, nil
+	}
+
+	return watchCondition{}, fmt.Errorf("invalid condition %q: expected 'field op value' (ops: >=, <=, ==, !=, >, <)", expr)
+}
+
+// flattenStatusMap flattens nested status maps into dotted keys (e.g. "battery.battery_level").
+func flattenStatusMap(data map[string]any) map[string]any {
+	flat := map[string]any{}
+	for key, value := range data {
+		if nested, ok := value.(map[string]any); ok {
+			for nestedKey, nestedValue := range flattenStatusMap(nested) {
+				flat[key+"."+nestedKey] = nestedValue
+			}
+
+			continue
+		}
+		flat[key] = value
+	}
+
+	return flat
+}
+
+// resolveWatchField looks up a field in the flattened status map, honoring aliases.
+func resolveWatchField(flat map[string]any, field string) (any, error) {
+	if alias, ok := watchFieldAliases()[field]; ok {
+		field = alias
+	}
+
+	value, ok := flat[field]
+	if !ok {
+		keys := slices.Sorted(maps.Keys(flat))
+
+		return nil, fmt.Errorf("unknown field %q (available: %s)", field, strings.Join(keys, ", "))
+	}
+
+	return value, nil
+}
+
+// toFloat64 converts numeric status values to float64.
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// compareNumbers applies a comparison operator to two numbers.
+func compareNumbers(actual float64, op string, expected float64) bool {
+	switch op {
+	case ">=":
+		return actual >= expected
+	case "<=":
+		return actual <= expected
+	case ">":
+		return actual > expected
+	case "<":
+		return actual < expected
+	case "!=":
+		return actual != expected
+	default:
+		return actual == expected
+	}
+}
+
+// evaluate checks the condition against a status map.
+func (c watchCondition) evaluate(data map[string]any) (bool, error) {
+	actual, err := resolveWatchField(flattenStatusMap(data), c.field)
+	if err != nil {
+		return false, err
+	}
+
+	if b, ok := actual.(bool); ok {
+		expected, err := strconv.ParseBool(c.value)
+		if err != nil {
+			return false, fmt.Errorf("field %q is boolean, cannot compare with %q", c.field, c.value)
+		}
+		switch c.op {
+		case "==":
+			return b == expected, nil
+		case "!=":
+			return b != expected, nil
+		default:
+			return false, fmt.Errorf("operator %s is not supported for boolean field %q", c.op, c.field)
+		}
+	}
+
+	if n, ok := toFloat64(actual); ok {
+		expected, err := strconv.ParseFloat(c.value, 64)
+		if err != nil {
+			return false, fmt.Errorf("field %q is numeric, cannot compare with %q", c.field, c.value)
+		}
+
+		return compareNumbers(n, c.op, expected), nil
+	}
+
+	return false, fmt.Errorf("field %q is not numeric or boolean", c.field)
+}
+
+// defaultWatchInterval is the default seconds between status checks.
+const defaultWatchInterval = 60
+
+// Bounds for --poll-jitter, in percent of the interval.
+const (
+	defaultPollJitter = 10
+	maxPollJitter     = 50
+)
+
+// jitteredInterval returns a poll interval function that randomizes interval by
+// up to ±percent, so that many watchers started on a schedule don't poll in
+// lockstep. rnd returns values in [0, 1), e.g. rand.Float64. Jittered intervals
+// never drop below minimum, so jitter can't undercut the loop's minimum interval.
+func jitteredInterval(interval time.Duration, percent float64, minimum time.Duration, rnd func() float64) func() time.Duration {
+	return func() time.Duration {
+		offset := (rnd()*2 - 1) * percent / 100
+
+		return max(time.Duration(float64(interval)*(1+offset)), minimum)
+	}
+}
+
+// NewWatchCmd creates the watch command.
+func NewWatchCmd() *cobra.Command {
+	var until string
+	var timeout int
+	var interval int
+	var httpListen string
+	var pollJitter float64
+	var noJitter bool
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch vehicle status until a condition is met",
+		Long: `Repeatedly fetch vehicle status until a condition holds.
+
+Conditions use the form 'field op value' where op is one of >=, <=, ==, !=, > or <.
+Fields are flattened status keys (e.g. battery.battery_level, doors.all_locked)
+or one of the aliases: battery, charging, plugged_in, fuel, locked, hvac, odometer.
+
+Exits 0 once the condition is met, or non-zero if the timeout is reached first.
+
+The interval is randomized by up to ±10% (--poll-jitter) so that watchers started
+at the same time don't hit the API in lockstep; --no-jitter turns this off.
+
+With --http-listen, an HTTP server runs for as long as the watch does, serving
+/healthz (200 while a poll succeeded within the last 3 intervals, else 503) and
+/metrics (the latest status as Prometheus gauges).`,
+		Example: `  # Block until the battery reaches 80%
+  mcs watch --until 'battery>=80'
+
+  # Block until the car is locked, checking every 30 seconds for up to 10 minutes
+  mcs watch --until 'locked==true' --interval 30 --timeout 600
+
+  # Serve /healthz and /metrics on port 8080 while watching
+  mcs watch --until 'battery>=80' --http-listen :8080`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			condition, err := parseWatchCondition(until)
+			if err != nil {
+				return err
+			}
+			if cfg := ConfigFromContext(cmd.Context()); cfg != nil {
+				interval = resolveSetting(interval, cmd.Flags().Changed("interval"), cfg.PollInterval, defaultWatchInterval)
+			}
+			interval = clampPollInterval(cmd.ErrOrStderr(), "interval", interval, false)
+			if pollJitter < 0 || pollJitter > maxPollJitter {
+				return fmt.Errorf("--poll-jitter must be between 0 and %d, got %g", maxPollJitter, pollJitter)
+			}
+			if noJitter {
+				pollJitter = 0
+			}
+			nextInterval := jitteredInterval(time.Duration(interval)*time.Second, pollJitter, MinPollSeconds*time.Second, rand.Float64)
+
+			return withVehicleClientEx(cmd.Context(), func(ctx context.Context, client *api.Client, vehicleInfo VehicleInfo) error {
+				var state *watchState
+				if httpListen != "" {
+					// Stop the server once the watch ends.
+					serverCtx, cancel := context.WithCancel(ctx)
+					defer cancel()
+
+					state = &watchState{}
+					maxAge := watchHealthIntervals * time.Duration(interval) * time.Second
+					addr, err := startWatchServer(serverCtx, httpListen, newWatchHandler(state, maxAge, time.Now), cmd.ErrOrStderr())
+					if err != nil {
+						return err
+					}
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "Serving /healthz and /metrics on %s\n", addr)
+				}
+
+				return runWatchUntil(ctx, cmd.OutOrStdout(), &clientAdapter{Client: client}, vehicleInfo, condition,
+					time.Duration(timeout)*time.Second, nextInterval, state)
+			})
+		},
+		SilenceUsage: true,
+	}
+
+	cmd.Flags().StringVar(&until, "until", "", "condition to wait for, e.g. 'battery>=80' (required)")
+	cmd.Flags().IntVar(&timeout, "timeout", 3600, "max seconds to wait before giving up")
+	cmd.Flags().IntVar(&interval, "interval", defaultWatchInterval, "seconds between status checks")
+	cmd.Flags().Float64Var(&pollJitter, "poll-jitter", defaultPollJitter, "randomize the interval by up to this percent either way")
+	cmd.Flags().BoolVar(&noJitter, "no-jitter", false, "poll at exactly --interval, without jitter")
+	cmd.Flags().StringVar(&httpListen, "http-listen", "", "serve /healthz and /metrics on this address while watching, e.g. :8080")
+	_ = cmd.MarkFlagRequired("until")
+	cmd.MarkFlagsMutuallyExclusive("poll-jitter", "no-jitter")
+
+	return cmd
+}
+
+// runWatchUntil polls vehicle status until the condition is met or the timeout expires,
+// waiting nextInterval() between polls.
+// Each poll's outcome is recorded in state, if given, for the watch HTTP server.
+func runWatchUntil(
+	ctx context.Context,
+	out io.Writer,
+	client vehicleStatusGetter,
+	vehicleInfo VehicleInfo,
+	condition watchCondition,
+	timeout time.Duration,
+	nextInterval func() time.Duration,
+	state *watchState,
+) error {
+	// Field and type errors won't fix themselves, so remember them and stop polling.
+	var evalErr error
+
+	checkFunc := func() (bool, error) {
+		data, err := fetchWatchStatus(ctx, client, vehicleInfo)
+		if err != nil {
+			if state != nil {
+				state.recordFailure(err)
+			}
+
+			return false, err
+		}
+		if state != nil {
+			state.recordSuccess(data, time.Now())
+		}
+
+		met, err := condition.evaluate(data)
+		if err != nil {
+			evalErr = err
+
+			return true, nil
+		}
+
+		return met, nil
+	}
+
+	label := "condition " + condition.String()
+	result := pollUntilConditionWithProgress(ctx, infoWriter(ctx, out), checkFunc, timeout, nextInterval, label, "Waiting for "+condition.String())
+	if evalErr != nil {
+		return evalErr
+	}
+	if result.err != nil {
+		return result.err
+	}
+	if !result.success {
+		return &timeoutError{message: label + " not met before timeout"}
+	}
+
+	_, _ = fmt.Fprintf(out, "Condition met: %s\n", condition)
+
+	return nil
+}
+
+// fetchWatchStatus fetches the vehicle and EV status and combines them into the
+// status data that watch conditions are evaluated against.
+func fetchWatchStatus(ctx context.Context, client vehicleStatusGetter, vehicleInfo VehicleInfo) (map[string]any, error) {
+	vehicleStatus, err := client.GetVehicleStatus(ctx, vehicleInfo.InternalVIN)
+	if err != nil {
+		return nil, err
+	}
+	evStatus, err := client.GetEVVehicleStatus(ctx, vehicleInfo.InternalVIN)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildAllStatusData(vehicleStatus, evStatus, vehicleInfo, distanceKm), nil
+}
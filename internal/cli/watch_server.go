@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// watchHealthIntervals is how many poll intervals may pass after the last
+// successful poll before /healthz reports unhealthy.
+const watchHealthIntervals = 3
+
+// watchPoll is the outcome of the latest polls.
+type watchPoll struct {
+	lastSuccess time.Time      // zero until a poll succeeds
+	lastErr     error          // error of the latest poll, nil if it succeeded
+	data        map[string]any // status data from the last successful poll
+}
+
+// watchState holds the latest poll outcome served by the watch HTTP server.
+type watchState struct {
+	mu   sync.Mutex
+	poll watchPoll
+}
+
+// recordSuccess stores the status data from a successful poll.
+func (s *watchState) recordSuccess(data map[string]any, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.poll = watchPoll{lastSuccess: at, data: data}
+}
+
+// recordFailure notes a failed poll, keeping the last good status data.
+func (s *watchState) recordFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.poll.lastErr = err
+}
+
+// snapshot returns a copy of the latest poll outcome.
+func (s *watchState) snapshot() watchPoll {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.poll
+}
+
+// newWatchHandler serves /healthz, which is 200 while the last successful poll is
+// no older than maxAge and 503 otherwise, and /metrics, the latest status in
+// Prometheus text format.
+func newWatchHandler(state *watchState, maxAge time.Duration, now func() time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		poll := state.snapshot()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+		switch age := now().Sub(poll.lastSuccess); {
+		case poll.lastSuccess.IsZero():
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintln(w, "no successful poll yet")
+		case age > maxAge:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = fmt.Fprintf(w, "last successful poll %s ago\n", age.Round(time.Second))
+			if poll.lastErr != nil {
+				_, _ = fmt.Fprintf(w, "last error: %v\n", poll.lastErr)
+			}
+		default:
+			_, _ = fmt.Fprintln(w, "ok")
+		}
+	})
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		poll := state.snapshot()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = io.WriteString(w, formatPrometheusMetrics(poll.data, poll.lastSuccess))
+	})
+
+	return mux
+}
+
+// formatPrometheusMetrics formats the numeric and boolean status fields as
+// Prometheus gauges named after their flattened keys, e.g.
+// mcs_battery_battery_level. Booleans are 1 or 0. Every gauge is labeled with
+// the VIN when known.
+func formatPrometheusMetrics(data map[string]any, lastSuccess time.Time) string {
+	var b strings.Builder
+	flat := flattenStatusMap(data)
+
+	labels := ""
+	if vin, ok := flat["vehicle.vin"].(string); ok && vin != "" {
+		labels = fmt.Sprintf("{vin=%q}", vin)
+	}
+
+	writeGauge := func(name string, value float64) {
+		_, _ = fmt.Fprintf(&b, "# TYPE %s gauge\n%s%s %s\n", name, name, labels, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+
+	if !lastSuccess.IsZero() {
+		writeGauge("mcs_last_poll_success_timestamp_seconds", float64(lastSuccess.Unix()))
+	}
+	for _, key := range slices.Sorted(maps.Keys(flat)) {
+		name := "mcs_" + strings.ReplaceAll(key, ".", "_")
+		switch value := flat[key].(type) {
+		case bool:
+			gauge := 0.0
+			if value {
+				gauge = 1
+			}
+			writeGauge(name, gauge)
+		default:
+			if n, ok := toFloat64(value); ok {
+				writeGauge(name, n)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// startWatchServer serves handler on addr until ctx is done, then shuts the server
+// down. It returns the address actually listened on, e.g. for ":0".
+func startWatchServer(ctx context.Context, addr string, handler http.Handler, errOut io.Writer) (net.Addr, error) {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			_, _ = fmt.Fprintf(errOut, "Warning: watch HTTP server stopped: %v\n", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	return listener.Addr(), nil
+}
@@ -0,0 +1,269 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cv/mcs/internal/api"
+	"github.com/cv/mcs/internal/api/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWatchCommand tests the watch command structure.
+func TestWatchCommand(t *testing.T) {
+	t.Parallel()
+	cmd := NewWatchCmd()
+
+	assertCommandBasics(t, cmd, "watch")
+	assertFlagExists(t, cmd, FlagAssertion{Name: "until"})
+	assertFlagExists(t, cmd, FlagAssertion{Name: "timeout", DefaultValue: "3600"})
+	assertFlagExists(t, cmd, FlagAssertion{Name: "interval", DefaultValue: "60"})
+	assertFlagExists(t, cmd, FlagAssertion{Name: "http-listen", DefaultValue: ""})
+	assertFlagExists(t, cmd, FlagAssertion{Name: "poll-jitter", DefaultValue: "10"})
+	assertFlagExists(t, cmd, FlagAssertion{Name: "no-jitter", DefaultValue: "false"})
+}
+
+// TestParseWatchCondition tests parsing of watch condition expressions.
+func TestParseWatchCondition(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		expr        string
+		expected    watchCondition
+		expectError bool
+	}{
+		{name: "greater or equal", expr: "battery>=80", expected: watchCondition{field: "battery", op: ">=", value: "80"}},
+		{name: "less than", expr: "fuel<25", expected: watchCondition{field: "fuel", op: "<", value: "25"}},
+		{name: "not equal", expr: "charging!=true", expected: watchCondition{field: "charging", op: "!=", value: "true"}},
+		{name: "single equals", expr: "locked=true", expected: watchCondition{field: "locked", op: "==", value: "true"}},
+		{name: "spaces", expr: " battery.battery_level > 50 ", expected: watchCondition{field: "battery.battery_level", op: ">", value: "50"}},
+		{name: "missing operator", expr: "battery", expectError: true},
+		{name: "missing field", expr: ">=80", expectError: true},
+		{name: "missing value", expr: "battery>=", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseWatchCondition(tt.expr)
+			if tt.expectError {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestWatchConditionEvaluate tests evaluating conditions against status data.
+func TestWatchConditionEvaluate(t *testing.T) {
+	t.Parallel()
+	data := map[string]any{
+		"battery": map[string]any{"battery_level": 80.0, "charging": true},
+		"doors":   map[string]any{"all_locked": false},
+	}
+
+	tests := []struct {
+		name        string
+		expr        string
+		expected    bool
+		expectError bool
+	}{
+		{name: "numeric met", expr: "battery>=80", expected: true},
+		{name: "numeric not met", expr: "battery>80", expected: false},
+		{name: "dotted key", expr: "battery.battery_level<=80", expected: true},
+		{name: "boolean equal", expr: "charging==true", expected: true},
+		{name: "boolean not equal", expr: "locked!=false", expected: false},
+		{name: "unknown field", expr: "altitude>1", expectError: true},
+		{name: "boolean with ordering op", expr: "locked>=true", expectError: true},
+		{name: "numeric with bool value", expr: "battery==yes", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cond, err := parseWatchCondition(tt.expr)
+			require.NoError(t, err)
+
+			met, err := cond.evaluate(data)
+			if tt.expectError {
+				assert.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, met)
+		})
+	}
+}
+
+// TestFlattenStatusMap tests flattening of nested status maps.
+func TestFlattenStatusMap(t *testing.T) {
+	t.Parallel()
+	flat := flattenStatusMap(map[string]any{
+		"hazards": true,
+		"battery": map[string]any{"battery_level": 66.0},
+	})
+
+	assertMapValue(t, flat, "hazards", true)
+	assertMapValue(t, flat, "battery.battery_level", 66.0)
+	assert.Len(t, flat, 2)
+}
+
+// TestRunWatchUntil tests polling until a condition is met or times out.
+func TestRunWatchUntil(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name          string
+		levels        []float64
+		expr          string
+		expectError   bool
+		errorContains string
+		exitCode      int
+	}{
+		{name: "met immediately", levels: []float64{85}, expr: "battery>=80"},
+		{name: "met after polling", levels: []float64{70, 75, 80}, expr: "battery>=80"},
+		{name: "never met", levels: []float64{50}, expr: "battery>=80", expectError: true, errorContains: "not met before timeout", exitCode: ExitCodeTimeout},
+		{name: "unknown field", levels: []float64{50}, expr: "altitude>1", expectError: true, errorContains: "unknown field", exitCode: ExitCodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			client := &mockClientForConfirm{
+				getVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.VehicleStatusResponse, error) {
+					return apitest.NewVehicleStatus().Build(), nil
+				},
+				getEVVehicleStatusFunc: func(_ context.Context, _ api.InternalVIN) (*api.EVVehicleStatusResponse, error) {
+					level := tt.levels[min(calls, len(tt.levels)-1)]
+					calls++
+					status := apitest.NewEVVehicleStatus().Build()
+					status.ResultData[0].PlusBInformation.VehicleInfo.ChargeInfo.SmaphSOC = level
+
+					return status, nil
+				},
+			}
+
+			cond, err := parseWatchCondition(tt.expr)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			err = runWatchUntil(context.Background(), &out, client, VehicleInfo{VehicleInfo: api.VehicleInfo{InternalVIN: "test-vin"}}, cond,
+				200*time.Millisecond, fixedInterval(10*time.Millisecond), nil)
+			if tt.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Equal(t, tt.exitCode, ExitCode(err))
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "Condition met: "+tt.expr)
+		})
+	}
+}
+
+// TestWatchServer_Healthz tests /healthz through the server after a simulated poll.
+func TestWatchServer_Healthz(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	state := &watchState{}
+	addr, err := startWatchServer(ctx, "127.0.0.1:0", newWatchHandler(state, time.Minute, time.Now), io.Discard)
+	require.NoError(t, err)
+
+	getHealthz := func() (int, string) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr.String()+"/healthz", nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := getHealthz()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "no successful poll yet\n", body)
+
+	state.recordSuccess(map[string]any{"battery": map[string]any{"battery_level": 80.0}}, time.Now())
+	status, body = getHealthz()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "ok\n", body)
+}
+
+// TestWatchHandler tests /healthz staleness and the /metrics output.
+func TestWatchHandler(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	state := &watchState{}
+	state.recordSuccess(map[string]any{
+		"vehicle": map[string]any{"vin": "JM3KKEHC1R0123456", "model_name": "CX-90 PHEV"},
+		"battery": map[string]any{"battery_level": 80.0, "charging": true},
+		"doors":   map[string]any{"all_locked": false},
+	}, now.Add(-5*time.Minute))
+	state.recordFailure(errors.New("connection reset"))
+
+	serve := func(handler http.Handler, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequestWithContext(context.Background(), http.MethodGet, path, nil))
+
+		return rec
+	}
+
+	stale := serve(newWatchHandler(state, 3*time.Minute, func() time.Time { return now }), "/healthz")
+	assert.Equal(t, http.StatusServiceUnavailable, stale.Code)
+	assert.Equal(t, "last successful poll 5m0s ago\nlast error: connection reset\n", stale.Body.String())
+
+	fresh := serve(newWatchHandler(state, 10*time.Minute, func() time.Time { return now }), "/healthz")
+	assert.Equal(t, http.StatusOK, fresh.Code)
+
+	metrics := serve(newWatchHandler(state, time.Minute, time.Now), "/metrics")
+	assert.Equal(t, http.StatusOK, metrics.Code)
+	assert.Equal(t, `# TYPE mcs_last_poll_success_timestamp_seconds gauge
+mcs_last_poll_success_timestamp_seconds{vin="JM3KKEHC1R0123456"} 1736942100
+# TYPE mcs_battery_battery_level gauge
+mcs_battery_battery_level{vin="JM3KKEHC1R0123456"} 80
+# TYPE mcs_battery_charging gauge
+mcs_battery_charging{vin="JM3KKEHC1R0123456"} 1
+# TYPE mcs_doors_all_locked gauge
+mcs_doors_all_locked{vin="JM3KKEHC1R0123456"} 0
+`, metrics.Body.String())
+}
+
+// TestJitteredInterval tests that jittered intervals stay within ±percent of the interval.
+func TestJitteredInterval(t *testing.T) {
+	t.Parallel()
+	const interval = 60 * time.Second
+
+	// The extremes of the random source map to the bounds.
+	assert.Equal(t, 54*time.Second, jitteredInterval(interval, 10, 0, func() float64 { return 0 })())
+	assert.Equal(t, 60*time.Second, jitteredInterval(interval, 10, 0, func() float64 { return 0.5 })())
+	assert.Equal(t, 60*time.Second, jitteredInterval(interval, 0, 0, func() float64 { return 0.99 })())
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	next := jitteredInterval(interval, 10, 0, rng.Float64)
+	for range 1000 {
+		got := next()
+		assert.GreaterOrEqual(t, got, 54*time.Second)
+		assert.Less(t, got, 66*time.Second)
+	}
+
+	// Jitter never takes an interval at the minimum below it.
+	assert.Equal(t, 10*time.Second, jitteredInterval(10*time.Second, 50, 10*time.Second, func() float64 { return 0 })())
+	assert.Equal(t, 12*time.Second, jitteredInterval(10*time.Second, 50, 10*time.Second, func() float64 { return 0.7 })())
+}
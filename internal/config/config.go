@@ -10,6 +10,68 @@ import (
 	"github.com/spf13/viper"
 )
 
+// DefaultProfile is the profile used when no --profile is given. It keeps the
+// original, unnamespaced file locations.
+const DefaultProfile = "default"
+
+// Paths holds the file locations used by a profile.
+type Paths struct {
+	ConfigFile   string
+	TokenCache   string
+	StateFile    string
+	AddressCache string
+	SnapshotFile string
+	TireFile     string
+	PlacesFile   string
+}
+
+// ValidateProfile checks that a profile name is usable as a directory name.
+func ValidateProfile(profile string) error {
+	if profile == "" || profile == DefaultProfile {
+		return nil
+	}
+	// Profiles become directory names, so only allow safe path characters.
+	for _, r := range profile {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return fmt.Errorf("invalid profile %q: use letters, digits, '-' or '_'", profile)
+		}
+	}
+
+	return nil
+}
+
+// ConfigPaths returns the config, token cache, state, address cache, snapshot,
+// saved tire pressure, and saved places file locations for a profile.
+// The default profile (empty or "default") uses ~/.config/mcs/config.toml and
+// ~/.cache/mcs/; named profiles are namespaced under a profiles/<name> subdirectory.
+func ConfigPaths(profile string) (Paths, error) {
+	if err := ValidateProfile(profile); err != nil {
+		return Paths{}, err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return Paths{}, fmt.Errorf("failed to get user home directory: %w", err)
+	}
+
+	configDir := filepath.Join(homeDir, ".config", "mcs")
+	cacheDir := filepath.Join(homeDir, ".cache", "mcs")
+	if profile != "" && profile != DefaultProfile {
+		configDir = filepath.Join(configDir, "profiles", profile)
+		cacheDir = filepath.Join(cacheDir, "profiles", profile)
+	}
+
+	return Paths{
+		ConfigFile:   filepath.Join(configDir, "config.toml"),
+		TokenCache:   filepath.Join(cacheDir, "token.json"),
+		StateFile:    filepath.Join(cacheDir, "state.json"),
+		AddressCache: filepath.Join(cacheDir, "geocode.json"),
+		SnapshotFile: filepath.Join(cacheDir, "snapshots.json"),
+		TireFile:     filepath.Join(configDir, "tires.json"),
+		PlacesFile:   filepath.Join(configDir, "places.json"),
+	}, nil
+}
+
 // Config holds the application configuration.
 type Config struct {
 	Email    string
@@ -17,14 +79,32 @@ type Config struct {
 	Region   api.Region
 }
 
-// Load loads configuration from file and environment variables
-// Environment variables take precedence over file values
-// configPath can be empty to use default location (~/.config/mcs/config.toml).
-func Load(configPath string) (*Config, error) {
-	v := viper.New()
+// Defaults holds optional defaults for CLI flags, read from the [defaults]
+// table of the config file. Zero values mean "not set".
+type Defaults struct {
+	DistanceUnit string
+	TempUnit     string
+	Locale       string
+	Theme        string
+	NoColor      bool
+	PollInterval int    // seconds
+	GeocoderURL  string // Nominatim-compatible server for status location --address
+}
 
-	// Set default values
-	v.SetDefault("region", "MNAO")
+// ClimatePreset holds saved HVAC settings, read from a [climate_presets.<name>]
+// table of the config file.
+type ClimatePreset struct {
+	Temp         float64
+	Unit         string // c or f; empty means the --temp-unit default
+	FrontDefrost bool
+	RearDefrost  bool
+}
+
+// readConfigFile reads the config file into a new viper instance.
+// configPath can be empty to use default location (~/.config/mcs/config.toml);
+// a missing file at the default location is not an error.
+func readConfigFile(configPath string) (*viper.Viper, error) {
+	v := viper.New()
 
 	// Configure viper
 	v.SetConfigType("toml")
@@ -51,6 +131,21 @@ func Load(configPath string) (*Config, error) {
 		}
 	}
 
+	return v, nil
+}
+
+// Load loads configuration from file and environment variables
+// Environment variables take precedence over file values
+// configPath can be empty to use default location (~/.config/mcs/config.toml).
+func Load(configPath string) (*Config, error) {
+	v, err := readConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default values
+	v.SetDefault("region", "MNAO")
+
 	// Bind environment variables
 	v.SetEnvPrefix("MCS")
 	v.AutomaticEnv()
@@ -71,6 +166,48 @@ func Load(configPath string) (*Config, error) {
 	return cfg, nil
 }
 
+// LoadDefaults loads the [defaults] table from the config file. Unlike Load it
+// doesn't require credentials, so it works before the user has logged in.
+func LoadDefaults(configPath string) (Defaults, error) {
+	v, err := readConfigFile(configPath)
+	if err != nil {
+		return Defaults{}, err
+	}
+
+	return Defaults{
+		DistanceUnit: v.GetString("defaults.distance_unit"),
+		TempUnit:     v.GetString("defaults.temp_unit"),
+		Locale:       v.GetString("defaults.locale"),
+		Theme:        v.GetString("defaults.theme"),
+		NoColor:      v.GetBool("defaults.no_color"),
+		PollInterval: v.GetInt("defaults.poll_interval"),
+		GeocoderURL:  v.GetString("defaults.geocoder_url"),
+	}, nil
+}
+
+// LoadClimatePresets loads the [climate_presets.<name>] tables from the config
+// file, keyed by preset name. Names are lowercased, as config keys are
+// case-insensitive.
+func LoadClimatePresets(configPath string) (map[string]ClimatePreset, error) {
+	v, err := readConfigFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	presets := make(map[string]ClimatePreset)
+	for name := range v.GetStringMap("climate_presets") {
+		prefix := "climate_presets." + name + "."
+		presets[name] = ClimatePreset{
+			Temp:         v.GetFloat64(prefix + "temp"),
+			Unit:         v.GetString(prefix + "unit"),
+			FrontDefrost: v.GetBool(prefix + "front_defrost"),
+			RearDefrost:  v.GetBool(prefix + "rear_defrost"),
+		}
+	}
+
+	return presets, nil
+}
+
 // Validate checks if the configuration is valid.
 func (c *Config) Validate() error {
 	if c.Email == "" {
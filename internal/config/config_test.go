@@ -197,3 +197,107 @@ func TestLoad_propagatesReadError(t *testing.T) {
 	assert.Nil(t, cfg)
 	require.Error(t, err, "expected error when reading unreadable config file")
 }
+
+func TestConfigPaths(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	defaultPaths, err := ConfigPaths("")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(homeDir, ".config", "mcs", "config.toml"), defaultPaths.ConfigFile)
+	assert.Equal(t, filepath.Join(homeDir, ".cache", "mcs", "token.json"), defaultPaths.TokenCache)
+	assert.Equal(t, filepath.Join(homeDir, ".cache", "mcs", "state.json"), defaultPaths.StateFile)
+	assert.Equal(t, filepath.Join(homeDir, ".cache", "mcs", "geocode.json"), defaultPaths.AddressCache)
+	assert.Equal(t, filepath.Join(homeDir, ".cache", "mcs", "snapshots.json"), defaultPaths.SnapshotFile)
+	assert.Equal(t, filepath.Join(homeDir, ".config", "mcs", "tires.json"), defaultPaths.TireFile)
+	assert.Equal(t, filepath.Join(homeDir, ".config", "mcs", "places.json"), defaultPaths.PlacesFile)
+
+	namedDefault, err := ConfigPaths(DefaultProfile)
+	require.NoError(t, err)
+	assert.Equal(t, defaultPaths, namedDefault)
+
+	workPaths, err := ConfigPaths("work")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(homeDir, ".config", "mcs", "profiles", "work", "config.toml"), workPaths.ConfigFile)
+	assert.Equal(t, filepath.Join(homeDir, ".cache", "mcs", "profiles", "work", "token.json"), workPaths.TokenCache)
+	assert.NotEqual(t, defaultPaths.StateFile, workPaths.StateFile)
+
+	_, err = ConfigPaths("../work")
+	require.ErrorContains(t, err, "invalid profile")
+}
+
+func TestValidateProfile(t *testing.T) {
+	t.Parallel()
+	for _, valid := range []string{"", "default", "work", "my_car-2"} {
+		require.NoError(t, ValidateProfile(valid), valid)
+	}
+	for _, invalid := range []string{"../x", "a/b", "with space", "."} {
+		require.Error(t, ValidateProfile(invalid), invalid)
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	configContent := `
+email = "file@example.com"
+
+[defaults]
+distance_unit = "mi"
+temp_unit = "f"
+locale = "de-DE"
+no_color = true
+poll_interval = 30
+geocoder_url = "https://geocoder.example.com"
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	defaults, err := LoadDefaults(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, Defaults{DistanceUnit: "mi", TempUnit: "f", Locale: "de-DE", NoColor: true, PollInterval: 30, GeocoderURL: "https://geocoder.example.com"}, defaults)
+}
+
+func TestLoadClimatePresets(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "config.toml")
+	configContent := `
+[climate_presets.Winter]
+temp = 72
+unit = "f"
+front_defrost = true
+rear_defrost = true
+
+[climate_presets.summer]
+temp = 20.5
+`
+	require.NoError(t, os.WriteFile(configPath, []byte(configContent), 0600))
+
+	presets, err := LoadClimatePresets(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]ClimatePreset{
+		"winter": {Temp: 72, Unit: "f", FrontDefrost: true, RearDefrost: true},
+		"summer": {Temp: 20.5},
+	}, presets)
+}
+
+func TestWriteTemplate(t *testing.T) {
+	t.Parallel()
+	configPath := filepath.Join(t.TempDir(), "mcs", "config.toml")
+
+	require.NoError(t, WriteTemplate(configPath, false))
+
+	info, err := os.Stat(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// The template's defaults are all commented out.
+	defaults, err := LoadDefaults(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, Defaults{}, defaults)
+	presets, err := LoadClimatePresets(configPath)
+	require.NoError(t, err)
+	assert.Empty(t, presets)
+
+	require.ErrorIs(t, WriteTemplate(configPath, false), ErrConfigExists)
+	require.NoError(t, WriteTemplate(configPath, true))
+}
@@ -0,0 +1,21 @@
+package config
+
+// Place is a named location saved by mcs location save, such as "home".
+type Place struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Places holds the saved places, keyed by name.
+type Places map[string]Place
+
+// LoadPlacesFrom reads the saved places from the given path.
+// Returns an empty set if the file doesn't exist yet.
+func LoadPlacesFrom(path string) (Places, error) {
+	return loadJSONFile[Places](path, "places")
+}
+
+// SavePlacesTo writes the saved places to the given path.
+func SavePlacesTo(places Places, path string) error {
+	return saveJSONFile(places, path, "places")
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaces_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "places.json")
+
+	places := Places{"home": {Latitude: 35.6762, Longitude: 139.6503}}
+	require.NoError(t, SavePlacesTo(places, path))
+
+	loaded, err := LoadPlacesFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, places, loaded)
+}
+
+func TestLoadPlacesFrom_Errors(t *testing.T) {
+	t.Parallel()
+	places, err := LoadPlacesFrom(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.NotNil(t, places)
+	assert.Empty(t, places)
+
+	corrupt := filepath.Join(t.TempDir(), "places.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0600))
+	_, err = LoadPlacesFrom(corrupt)
+	require.ErrorContains(t, err, "failed to parse places file")
+}
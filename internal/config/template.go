@@ -0,0 +1,74 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ErrConfigExists is returned by WriteTemplate when the config file already exists.
+var ErrConfigExists = errors.New("config file already exists")
+
+// Template is the commented config file written by WriteTemplate.
+const Template = `# mcs configuration.
+#
+# Command-line flags override the [defaults] below, and the MCS_EMAIL,
+# MCS_PASSWORD, and MCS_REGION environment variables override the account
+# settings.
+
+email = ""
+password = ""
+
+# MNAO (North America), MME (Europe), or MJO (Japan).
+region = "MNAO"
+
+[defaults]
+# Unit for range and odometer: km or mi (--distance-unit).
+# distance_unit = "km"
+
+# Unit for tire temperatures: c or f (--temp-unit).
+# temp_unit = "c"
+
+# Number format for odometer and range, as a language tag (--locale).
+# locale = "en-US"
+
+# Status symbols: ascii or emoji (--theme).
+# theme = "ascii"
+
+# Disable colored output (--no-color).
+# no_color = false
+
+# Seconds between status checks for mcs watch (--interval).
+# poll_interval = 60
+
+# Nominatim server for mcs status location --address (--geocoder-url).
+# geocoder_url = "https://nominatim.openstreetmap.org"
+
+# Named climate settings for "mcs climate on --preset <name>".
+# [climate_presets.winter]
+# temp = 22
+# unit = "c"
+# front_defrost = true
+# rear_defrost = true
+`
+
+// WriteTemplate writes Template to path, creating its directory. The file may
+// hold a password, so it is only readable by the owner. An existing file is
+// kept unless overwrite is set.
+func WriteTemplate(path string, overwrite bool) error {
+	if _, err := os.Stat(path); err == nil && !overwrite {
+		return fmt.Errorf("%w: %s", ErrConfigExists, path)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to check config file: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(Template), 0600); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package config
+
+// TirePressure holds the recommended tire pressures saved for one vehicle, as
+// printed on its door jamb label. Zero means not saved.
+type TirePressure struct {
+	FrontPSI float64 `json:"front_psi,omitempty"`
+	RearPSI  float64 `json:"rear_psi,omitempty"`
+}
+
+// TirePressures holds the saved TirePressure for each vehicle, keyed by VIN.
+type TirePressures map[string]TirePressure
+
+// LoadTirePressuresFrom reads the saved tire pressures from the given path.
+// Returns an empty set if the file doesn't exist yet.
+func LoadTirePressuresFrom(path string) (TirePressures, error) {
+	return loadJSONFile[TirePressures](path, "tire pressure")
+}
+
+// SaveTirePressuresTo writes the saved tire pressures to the given path.
+func SaveTirePressuresTo(pressures TirePressures, path string) error {
+	return saveJSONFile(pressures, path, "tire pressure")
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTirePressures_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "nested", "tires.json")
+
+	pressures := TirePressures{"JM3KKEHC1R0000001": {FrontPSI: 36, RearPSI: 33}}
+	require.NoError(t, SaveTirePressuresTo(pressures, path))
+
+	loaded, err := LoadTirePressuresFrom(path)
+	require.NoError(t, err)
+	assert.Equal(t, pressures, loaded)
+}
+
+func TestLoadTirePressuresFrom_Errors(t *testing.T) {
+	t.Parallel()
+	pressures, err := LoadTirePressuresFrom(filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.NotNil(t, pressures)
+	assert.Empty(t, pressures)
+
+	corrupt := filepath.Join(t.TempDir(), "tires.json")
+	require.NoError(t, os.WriteFile(corrupt, []byte("{"), 0600))
+	_, err = LoadTirePressuresFrom(corrupt)
+	require.ErrorContains(t, err, "failed to parse tire pressure file")
+}
@@ -0,0 +1,95 @@
+// Package geocode turns vehicle coordinates into human-readable addresses.
+package geocode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultNominatimURL is the public OpenStreetMap Nominatim server. Its usage
+// policy allows at most one request per second and requires an identifying
+// User-Agent, so callers should cache results.
+const DefaultNominatimURL = "https://nominatim.openstreetmap.org"
+
+// maxResponseBytes bounds how much of a geocoder response is read.
+const maxResponseBytes = 1 << 20
+
+// Geocoder looks up the address at a pair of coordinates.
+type Geocoder interface {
+	ReverseGeocode(ctx context.Context, latitude, longitude float64) (string, error)
+}
+
+// Nominatim reverse-geocodes with the /reverse endpoint of a Nominatim server.
+type Nominatim struct {
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+}
+
+// NewNominatim creates a geocoder for the Nominatim server at baseURL, e.g.
+// DefaultNominatimURL, identifying itself with userAgent.
+func NewNominatim(baseURL, userAgent string) *Nominatim {
+	return &Nominatim{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		userAgent:  userAgent,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// SetTransport replaces the HTTP transport used for lookups, e.g. with one that
+// trusts the same CAs as the API client.
+func (n *Nominatim) SetTransport(transport http.RoundTripper) {
+	n.httpClient = &http.Client{Transport: transport}
+}
+
+// nominatimResponse is the part of a /reverse response mcs uses.
+type nominatimResponse struct {
+	DisplayName string `json:"display_name"`
+	Error       string `json:"error"`
+}
+
+// ReverseGeocode returns the address Nominatim gives for the coordinates, e.g.
+// "1 Market Street, San Francisco, California, 94105, United States".
+func (n *Nominatim) ReverseGeocode(ctx context.Context, latitude, longitude float64) (string, error) {
+	query := url.Values{
+		"format": {"jsonv2"},
+		"lat":    {strconv.FormatFloat(latitude, 'f', -1, 64)},
+		"lon":    {strconv.FormatFloat(longitude, 'f', -1, 64)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, n.baseURL+"/reverse?"+query.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create geocoding request: %w", err)
+	}
+	req.Header.Set("User-Agent", n.userAgent)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("geocoder returned HTTP %d", resp.StatusCode)
+	}
+
+	var response nominatimResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&response); err != nil {
+		return "", fmt.Errorf("failed to parse geocoder response: %w", err)
+	}
+	if response.Error != "" {
+		return "", fmt.Errorf("geocoder error: %s", response.Error)
+	}
+	if response.DisplayName == "" {
+		return "", errors.New("geocoder returned no address")
+	}
+
+	return response.DisplayName, nil
+}
@@ -0,0 +1,58 @@
+package geocode
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNominatim_ReverseGeocode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "address",
+			status: http.StatusOK,
+			body:   `{"display_name": "1 Market Street, San Francisco, California, 94105, United States"}`,
+			want:   "1 Market Street, San Francisco, California, 94105, United States",
+		},
+		{name: "not found", status: http.StatusOK, body: `{"error": "Unable to geocode"}`, wantErr: "geocoder error: Unable to geocode"},
+		{name: "empty", status: http.StatusOK, body: `{}`, wantErr: "geocoder returned no address"},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: ``, wantErr: "geocoder returned HTTP 429"},
+		{name: "malformed", status: http.StatusOK, body: `<html>`, wantErr: "failed to parse geocoder response"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/reverse", r.URL.Path)
+				assert.Equal(t, "jsonv2", r.URL.Query().Get("format"))
+				assert.Equal(t, "37.7749", r.URL.Query().Get("lat"))
+				assert.Equal(t, "-122.4194", r.URL.Query().Get("lon"))
+				assert.Equal(t, "mcs/test", r.Header.Get("User-Agent"))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			address, err := NewNominatim(server.URL+"/", "mcs/test").ReverseGeocode(context.Background(), 37.7749, -122.4194)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, address)
+		})
+	}
+}
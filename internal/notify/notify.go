@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// windowsToastScript shows a toast with the title and body passed in the
+// environment, so neither needs quoting for PowerShell.
+const windowsToastScript = `[Windows.UI.Notifications.ToastNotificationManager, Windows.UI.Notifications, ContentType = WindowsRuntime] | Out-Null
+$template = [Windows.UI.Notifications.ToastNotificationManager]::GetTemplateContent([Windows.UI.Notifications.ToastTemplateType]::ToastText02)
+$text = $template.GetElementsByTagName('text')
+$text.Item(0).AppendChild($template.CreateTextNode($env:MCS_NOTIFY_TITLE)) | Out-Null
+$text.Item(1).AppendChild($template.CreateTextNode($env:MCS_NOTIFY_BODY)) | Out-Null
+[Windows.UI.Notifications.ToastNotificationManager]::CreateToastNotifier('mcs').Show([Windows.UI.Notifications.ToastNotification]::new($template))`
+
+// Send shows a desktop notification: with osascript on macOS, notify-send on
+// Linux and other Unixes, and a PowerShell toast on Windows. It does nothing
+// when the platform has no notifier or the notifier isn't installed.
+func Send(ctx context.Context, title, body string) error {
+	cmd := command(ctx, runtime.GOOS, title, body)
+	if cmd == nil || cmd.Err != nil {
+		return nil
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	return nil
+}
+
+// command returns the command that shows a notification on goos, or nil if
+// there's no known way to.
+func command(ctx context.Context, goos, title, body string) *exec.Cmd {
+	switch goos {
+	case "darwin":
+		// Passing the text as arguments avoids quoting it in AppleScript.
+		return exec.CommandContext(ctx, "osascript",
+			"-e", "on run argv",
+			"-e", "display notification (item 2 of argv) with title (item 1 of argv)",
+			"-e", "end run",
+			title, body)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return exec.CommandContext(ctx, "notify-send", "--app-name=mcs", "--", title, body)
+	case "windows":
+		cmd := exec.CommandContext(ctx, "powershell", "-NoProfile", "-NonInteractive", "-Command", windowsToastScript)
+		cmd.Env = append(os.Environ(), "MCS_NOTIFY_TITLE="+title, "MCS_NOTIFY_BODY="+body)
+
+		return cmd
+	default:
+		return nil
+	}
+}
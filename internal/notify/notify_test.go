@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	t.Run("macOS", func(t *testing.T) {
+		t.Parallel()
+		cmd := command(ctx, "darwin", `mcs "lock"`, "Confirmed after 12s")
+		require.NotNil(t, cmd)
+		assert.Equal(t, []string{"osascript", "-e", "on run argv", "-e", "display notification (item 2 of argv) with title (item 1 of argv)", "-e", "end run", `mcs "lock"`, "Confirmed after 12s"}, cmd.Args)
+	})
+
+	t.Run("Linux", func(t *testing.T) {
+		t.Parallel()
+		cmd := command(ctx, "linux", "mcs lock", "-Confirmed")
+		require.NotNil(t, cmd)
+		assert.Equal(t, []string{"notify-send", "--app-name=mcs", "--", "mcs lock", "-Confirmed"}, cmd.Args)
+	})
+
+	t.Run("Windows", func(t *testing.T) {
+		t.Parallel()
+		cmd := command(ctx, "windows", "mcs lock", "it's confirmed")
+		require.NotNil(t, cmd)
+		assert.Equal(t, "powershell", cmd.Args[0])
+		assert.Contains(t, cmd.Env, "MCS_NOTIFY_TITLE=mcs lock")
+		assert.Contains(t, cmd.Env, "MCS_NOTIFY_BODY=it's confirmed")
+	})
+
+	t.Run("unsupported platform", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, command(ctx, "plan9", "mcs lock", "Confirmed"))
+	})
+}